@@ -25,6 +25,7 @@ func NewAccountCommand() *cobra.Command {
 
 	cmds := accountCommands()
 	applySubcommandOptions(cmds, attachPwdFlagToSubcommands())
+	applySubcommandOptions(cmds, attachBroadcastModeFlagToSubcommands())
 	cmd.AddCommand(cmds...)
 
 	return cmd
@@ -16,7 +16,7 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(NewActorCommands(attachPwdFlagToSubcommands())...)
+	rootCmd.AddCommand(NewActorCommands(append(attachPwdFlagToSubcommands(), attachBroadcastModeFlagToSubcommands()...))...)
 
 	rawChainCleanupRegex = regexp.MustCompile(rawChainCleanupExpr)
 
@@ -31,6 +31,8 @@ const (
 
 var (
 	pwd                  string
+	broadcastMode        string
+	dryRun               bool
 	rawChainCleanupRegex *regexp.Regexp
 	oneMillion           *big.Int
 )
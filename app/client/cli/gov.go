@@ -26,6 +26,7 @@ func NewGovernanceCommand() *cobra.Command {
 
 	cmds := govCommands()
 	applySubcommandOptions(cmds, attachPwdFlagToSubcommands())
+	applySubcommandOptions(cmds, attachBroadcastModeFlagToSubcommands())
 
 	cmd.AddCommand(cmds...)
 
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"github.com/pokt-network/pocket/rpc"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	mempoolCmd := NewMempoolCommand()
+	rootCmd.AddCommand(mempoolCmd)
+}
+
+var (
+	mempoolOffset int
+	mempoolLimit  int
+)
+
+func NewMempoolCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Mempool",
+		Short:   "Commands to inspect the transactions waiting in the mempool of the node",
+		Aliases: []string{"mempool"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.AddCommand(mempoolCommands()...)
+
+	return cmd
+}
+
+func mempoolCommands() []*cobra.Command {
+	txsCmd := &cobra.Command{
+		Use:     "Txs",
+		Short:   "Returns the transactions in the mempool",
+		Long:    "Returns a page of the transactions in the mempool, in the order they will be reaped into a block",
+		Aliases: []string{"txs"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			params := &rpc.GetV1MempoolTxsParams{
+				Offset: &mempoolOffset,
+				Limit:  &mempoolLimit,
+			}
+			response, err := client.GetV1MempoolTxsWithResponse(cmd.Context(), params)
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+	txsCmd.Flags().IntVar(&mempoolOffset, "offset", 0, "number of transactions to skip")
+	txsCmd.Flags().IntVar(&mempoolLimit, "limit", 100, "maximum number of transactions in the page")
+
+	txCmd := &cobra.Command{
+		Use:     "Tx <hash>",
+		Short:   "Returns a transaction in the mempool by hash",
+		Long:    "Returns the transaction with hash <hash> if it is still waiting in the mempool",
+		Aliases: []string{"tx"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			response, err := client.GetV1MempoolTxHashWithResponse(cmd.Context(), args[0])
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+
+	statsCmd := &cobra.Command{
+		Use:     "Stats",
+		Short:   "Returns statistics about the mempool",
+		Long:    "Returns the number and size of the transactions in the mempool, the limits of the mempool and a breakdown by message type",
+		Aliases: []string{"stats"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			response, err := client.GetV1MempoolStatsWithResponse(cmd.Context())
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+
+	return []*cobra.Command{txsCmd, txCmd, statsCmd}
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pokt-network/pocket/rpc"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	queryCmd := NewQueryCommand()
+	rootCmd.AddCommand(queryCmd)
+}
+
+var (
+	queryHeight     int64
+	queryCursor     string
+	queryLimit      int
+	queryDescending bool
+	queryRole       string
+	queryGeoZone    string
+)
+
+func NewQueryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Query",
+		Short:   "Commands to query the state of the blockchain",
+		Aliases: []string{"query", "q"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.AddCommand(queryCommands()...)
+
+	return cmd
+}
+
+func queryCommands() []*cobra.Command {
+	txCmd := &cobra.Command{
+		Use:     "Tx <hash>",
+		Short:   "Returns a transaction by hash",
+		Long:    "Returns the transaction indexed under <hash>, along with its result",
+		Aliases: []string{"tx"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			params := &rpc.GetV1QueryTxParams{Hash: args[0]}
+			if cmd.Flags().Changed("height") {
+				params.Height = &queryHeight
+			}
+			response, err := client.GetV1QueryTxWithResponse(cmd.Context(), params)
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+	txCmd.Flags().Int64Var(&queryHeight, "height", 0, "only return the transaction if it was included at or below this height (defaults to the latest committed height)")
+
+	txsByHeightCmd := &cobra.Command{
+		Use:     "TxsByHeight <height>",
+		Short:   "Returns the transactions of a block",
+		Long:    "Returns a page of the transactions included in the block at <height>",
+		Aliases: []string{"txsbyheight"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			params := &rpc.GetV1QueryTxsByHeightParams{
+				Height:     height,
+				Limit:      &queryLimit,
+				Descending: &queryDescending,
+			}
+			if queryCursor != "" {
+				params.Cursor = &queryCursor
+			}
+			response, err := client.GetV1QueryTxsByHeightWithResponse(cmd.Context(), params)
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+	attachTxsPageFlags(txsByHeightCmd)
+
+	accountTxsCmd := &cobra.Command{
+		Use:     "AccountTxs <address>",
+		Short:   "Returns the transaction history of an address",
+		Long:    "Returns a page of the transactions signed by or sent to <address>",
+		Aliases: []string{"accounttxs"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			params := &rpc.GetV1QueryAccountTxsParams{
+				Address:    args[0],
+				Limit:      &queryLimit,
+				Descending: &queryDescending,
+			}
+			if queryCursor != "" {
+				params.Cursor = &queryCursor
+			}
+			if queryRole != "" {
+				role := rpc.TxRolesEnum(queryRole)
+				params.Role = &role
+			}
+			response, err := client.GetV1QueryAccountTxsWithResponse(cmd.Context(), params)
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+	attachTxsPageFlags(accountTxsCmd)
+	accountTxsCmd.Flags().StringVar(&queryRole, "role", "", "only return the transactions signed by (sender) or sent to (recipient) the address")
+
+	sessionCmd := &cobra.Command{
+		Use:     "Session <appAddress> <relayChain>",
+		Short:   "Returns the session of an application",
+		Long:    "Returns the servicers and fishermen dispatched to the application at <appAddress> to serve its relays to <relayChain>",
+		Aliases: []string{"session"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := rpc.NewClientWithResponses(remoteCLIURL)
+			if err != nil {
+				return err
+			}
+			params := &rpc.GetV1QuerySessionParams{AppAddress: args[0], RelayChain: args[1]}
+			if queryGeoZone != "" {
+				params.GeoZone = &queryGeoZone
+			}
+			if cmd.Flags().Changed("height") {
+				params.Height = &queryHeight
+			}
+			response, err := client.GetV1QuerySessionWithResponse(cmd.Context(), params)
+			if err != nil {
+				return unableToConnectToRpc(err)
+			}
+			return printQueryResponse(response.StatusCode(), response.Body)
+		},
+	}
+	sessionCmd.Flags().StringVar(&queryGeoZone, "geo_zone", "", "geo zone the application operates in during the session")
+	sessionCmd.Flags().Int64Var(&queryHeight, "height", 0, "return the session containing this height (defaults to the latest committed height)")
+
+	return []*cobra.Command{txCmd, txsByHeightCmd, accountTxsCmd, sessionCmd}
+}
+
+func attachTxsPageFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&queryCursor, "cursor", "", "cursor of the page, as returned by the previous page")
+	cmd.Flags().IntVar(&queryLimit, "limit", 100, "maximum number of transactions in the page")
+	cmd.Flags().BoolVar(&queryDescending, "descending", false, "order the transactions from the latest to the oldest")
+}
+
+// DISCUSS(#310): the raw JSON response is printed for now, same as the other commands
+func printQueryResponse(statusCode int, body []byte) error {
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("the query failed with HTTP status code %d: %s", statusCode, body)
+	}
+	fmt.Println(string(body))
+	return nil
+}
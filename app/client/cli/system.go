@@ -71,9 +71,49 @@ func systemCommands() []*cobra.Command {
 					return nil
 				}
 
+				return rpcResponseCodeUnhealthy(statusCode, response.Body)
+			},
+		},
+		{
+			Use:     "Status",
+			Short:   "Node status",
+			Long:    "Queries the node RPC to obtain the state of its modules: FSM state, consensus & sync progress, P2P network and mempool",
+			Aliases: []string{"status"},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				client, err := rpc.NewClientWithResponses(remoteCLIURL)
+				if err != nil {
+					return err
+				}
+				response, err := client.GetV1NodeStatusWithResponse(cmd.Context())
+				if err != nil {
+					return unableToConnectToRpc(err)
+				}
+				statusCode := response.StatusCode()
+				if statusCode == http.StatusOK {
+					printNodeStatus(response.JSON200)
+					return nil
+				}
+
 				return rpcResponseCodeUnhealthy(statusCode, response.Body)
 			},
 		},
 	}
 	return cmds
 }
+
+func printNodeStatus(status *rpc.NodeStatus) {
+	syncProgress := "synched"
+	if status.Sync.CatchingUp {
+		syncProgress = "catching up"
+	}
+
+	fmt.Printf("Node @ %s reports the following status:\n\n", boldText(remoteCLIURL))
+	fmt.Printf("Address:   %s\n", status.Address)
+	fmt.Printf("Version:   %s\n", status.Version)
+	fmt.Printf("Chain ID:  %s\n", status.ChainId)
+	fmt.Printf("FSM state: %s\n", boldText(status.FsmState))
+	fmt.Printf("Consensus: height %d, round %d, step %d\n", status.Consensus.Height, status.Consensus.Round, status.Consensus.Step)
+	fmt.Printf("Sync:      local height %d, network height %d (%s)\n", status.Sync.LocalHeight, status.Sync.NetworkHeight, syncProgress)
+	fmt.Printf("P2P:       %d peers in the address book, %d RainTree levels\n", status.P2p.AddressBookSize, status.P2p.RaintreeLevels)
+	fmt.Printf("Mempool:   %d transactions, %d bytes\n", status.Mempool.TxCount, status.Mempool.TxsBytesTotal)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"net/http"
 	"os"
 	"strings"
 
@@ -117,8 +118,28 @@ func prepareTxBytes(msg typesUtil.Message, pk crypto.PrivateKey) ([]byte, error)
 	return bz, nil
 }
 
-// postRawTx posts a signed transaction
-func postRawTx(ctx context.Context, pk crypto.PrivateKey, j []byte) (*rpc.PostV1ClientBroadcastTxSyncResponse, error) {
+const (
+	broadcastModeSync   = "sync"
+	broadcastModeAsync  = "async"
+	broadcastModeCommit = "commit"
+)
+
+// rawTxResponse is the response of the broadcast endpoint matching the broadcast mode
+type rawTxResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+func (r *rawTxResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// postRawTx posts a signed transaction to the broadcast endpoint of the `--broadcast_mode`, or to the simulation
+// endpoint if `--dry-run` is set
+func postRawTx(ctx context.Context, pk crypto.PrivateKey, j []byte) (*rawTxResponse, error) {
 	client, err := rpc.NewClientWithResponses(remoteCLIURL)
 	if err != nil {
 		return nil, err
@@ -128,11 +149,36 @@ func postRawTx(ctx context.Context, pk crypto.PrivateKey, j []byte) (*rpc.PostV1
 		RawHexBytes: hex.EncodeToString(j),
 	}
 
-	resp, err := client.PostV1ClientBroadcastTxSyncWithResponse(ctx, req)
-	if err != nil {
-		return nil, err
+	if dryRun {
+		resp, err := client.PostV1ClientSimulateTxWithResponse(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &rawTxResponse{Body: resp.Body, HTTPResponse: resp.HTTPResponse}, nil
+	}
+
+	switch broadcastMode {
+	case broadcastModeSync:
+		resp, err := client.PostV1ClientBroadcastTxSyncWithResponse(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &rawTxResponse{Body: resp.Body, HTTPResponse: resp.HTTPResponse}, nil
+	case broadcastModeAsync:
+		resp, err := client.PostV1ClientBroadcastTxAsyncWithResponse(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &rawTxResponse{Body: resp.Body, HTTPResponse: resp.HTTPResponse}, nil
+	case broadcastModeCommit:
+		resp, err := client.PostV1ClientBroadcastTxCommitWithResponse(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return &rawTxResponse{Body: resp.Body, HTTPResponse: resp.HTTPResponse}, nil
+	default:
+		return nil, fmt.Errorf("invalid broadcast mode %s, expected one of: %s, %s, %s", broadcastMode, broadcastModeSync, broadcastModeAsync, broadcastModeCommit)
 	}
-	return resp, nil
 }
 
 func readPassphrase(currPwd string) string {
@@ -185,6 +231,13 @@ func attachPwdFlagToSubcommands() []cmdOption {
 	}}
 }
 
+func attachBroadcastModeFlagToSubcommands() []cmdOption {
+	return []cmdOption{func(c *cobra.Command) {
+		c.Flags().StringVar(&broadcastMode, "broadcast_mode", broadcastModeSync, "sync: returns once the transaction is in the mempool, async: returns without waiting, commit: waits for the transaction to be committed and returns its result")
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "simulates the transaction instead of broadcasting it, and returns its fee, result & state changes")
+	}}
+}
+
 func attachNewPwdFlagToSubcommands() []cmdOption {
 	return []cmdOption{func(c *cobra.Command) {
 		c.Flags().StringVar(&pwd, "new_pwd", "", "new passphrase for private key, non empty usage bypass interactive prompt")
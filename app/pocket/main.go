@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"os"
 
 	"github.com/pokt-network/pocket/app"
 	"github.com/pokt-network/pocket/logger"
@@ -10,6 +11,11 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == snapshotCmd {
+		runSnapshotCmd(os.Args[2:])
+		return
+	}
+
 	configFilename := flag.String("config", "", "Relative or absolute path to the config file.")
 	genesisFilename := flag.String("genesis", "", "Relative or absolute path to the genesis file.")
 	bootstrapNodes := flag.String("bootstrap-nodes", "", "Comma separated list of bootstrap nodes.")
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pokt-network/pocket/logger"
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime"
+	"github.com/pokt-network/pocket/shared/modules"
+)
+
+const (
+	snapshotCmd        = "snapshot"
+	snapshotCreateCmd  = "create"
+	snapshotRestoreCmd = "restore"
+)
+
+// runSnapshotCmd handles `pocket snapshot create|restore`, which export the state of a node into a snapshot
+// archive and bootstrap a node from one respectively. The node must not be running while they are executed.
+func runSnapshotCmd(args []string) {
+	if err := runSnapshot(args); err != nil {
+		logger.Global.Fatal().Err(err).Msgf("Failed to run the %s command", snapshotCmd)
+	}
+}
+
+// runSnapshot returns the errors of the snapshot commands rather than exiting, so the deferred clean up runs
+func runSnapshot(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: pocket %s <%s|%s> [flags]", snapshotCmd, snapshotCreateCmd, snapshotRestoreCmd)
+	}
+
+	subCmd := args[0]
+	flagSet := flag.NewFlagSet(fmt.Sprintf("%s %s", snapshotCmd, subCmd), flag.ExitOnError)
+	configFilename := flagSet.String("config", "", "Relative or absolute path to the config file.")
+	genesisFilename := flagSet.String("genesis", "", "Relative or absolute path to the genesis file.")
+
+	switch subCmd {
+	case snapshotCreateCmd:
+		outputFilename := flagSet.String("output", "", "Relative or absolute path to the snapshot file to create.")
+		_ = flagSet.Parse(args[1:])
+		if *outputFilename == "" {
+			return fmt.Errorf("the -output flag is required")
+		}
+		return createSnapshot(*configFilename, *genesisFilename, *outputFilename)
+
+	case snapshotRestoreCmd:
+		inputFilename := flagSet.String("input", "", "Relative or absolute path to the snapshot file to restore.")
+		_ = flagSet.Parse(args[1:])
+		if *inputFilename == "" {
+			return fmt.Errorf("the -input flag is required")
+		}
+		return restoreSnapshot(*configFilename, *genesisFilename, *inputFilename)
+
+	default:
+		return fmt.Errorf("unknown %s command: %s", snapshotCmd, subCmd)
+	}
+}
+
+// createSnapshot writes the snapshot of the latest committed state into `outputFilename`. The file is removed if the
+// snapshot cannot be written entirely, so a truncated snapshot is never left behind.
+func createSnapshot(configFilename, genesisFilename, outputFilename string) (err error) {
+	persistenceMod, err := createPersistenceModule(configFilename, genesisFilename)
+	if err != nil {
+		return err
+	}
+	defer persistenceMod.Stop()
+
+	outputFile, err := os.Create(outputFilename)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer func() {
+		if closeErr := outputFile.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close snapshot file: %w", closeErr)
+		}
+		if err != nil {
+			if removeErr := os.Remove(outputFilename); removeErr != nil {
+				logger.Global.Error().Err(removeErr).Str("output", outputFilename).Msg("Failed to remove partial snapshot file")
+			}
+		}
+	}()
+
+	height, err := persistenceMod.CreateSnapshot(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	logger.Global.Info().Uint64("height", height).Str("output", outputFilename).Msg("Snapshot created")
+	return nil
+}
+
+// restoreSnapshot bootstraps the state of the node from the snapshot in `inputFilename`
+func restoreSnapshot(configFilename, genesisFilename, inputFilename string) error {
+	persistenceMod, err := createPersistenceModule(configFilename, genesisFilename)
+	if err != nil {
+		return err
+	}
+	defer persistenceMod.Stop()
+
+	inputFile, err := os.Open(inputFilename)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer inputFile.Close()
+
+	height, err := persistenceMod.RestoreSnapshot(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	logger.Global.Info().Uint64("height", height).Str("input", inputFilename).Msg("Snapshot restored")
+	return nil
+}
+
+// createPersistenceModule creates the persistence module on its own since none of the other modules are needed to manage snapshots
+func createPersistenceModule(configFilename, genesisFilename string) (modules.PersistenceModule, error) {
+	runtimeMgr := runtime.NewManagerFromFiles(configFilename, genesisFilename)
+	bus, err := runtime.CreateBus(runtimeMgr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bus: %w", err)
+	}
+
+	persistenceMod, err := persistence.Create(bus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create persistence module: %w", err)
+	}
+
+	return persistenceMod.(modules.PersistenceModule), nil
+}
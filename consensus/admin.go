@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"fmt"
+)
+
+// Implementation of the admin functions of the ConsensusModule, which let operators control a running node (e.g. through
+// the admin endpoints of the RPC) without the debug client.
+
+func (m *consensusModule) PauseConsensus() {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.logger.Info().Msg("Pausing consensus")
+	m.paceMaker.SetManualMode(true)
+}
+
+func (m *consensusModule) ResumeConsensus() {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if !m.paceMaker.IsManualMode() {
+		return
+	}
+
+	m.logger.Info().Msg("Resuming consensus")
+	m.paceMaker.SetManualMode(false)
+	// A paused pacemaker holds the view once it reaches the NewRound step, so it has to be started again
+	if m.height > 0 && m.step == NewRound {
+		m.paceMaker.ForceNextView()
+	}
+}
+
+func (m *consensusModule) IsConsensusPaused() bool {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return m.paceMaker.IsManualMode()
+}
+
+func (m *consensusModule) TriggerSnapshot() (height uint64, path string, err error) {
+	m.m.RLock()
+	currentHeight := m.height
+	m.m.RUnlock()
+
+	if currentHeight == 0 {
+		return 0, "", fmt.Errorf("cannot create a snapshot before the genesis block is committed")
+	}
+
+	// The snapshot is created without holding the lock so consensus is not blocked in the meantime
+	return m.stateSync.CreateSnapshot()
+}
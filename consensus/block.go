@@ -5,12 +5,19 @@ import (
 	"unsafe"
 
 	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 )
 
-func (m *consensusModule) commitBlock(block *coreTypes.Block) error {
+// The commit QC is persisted alongside the block so peers synching from this node can verify it
+func (m *consensusModule) commitBlock(block *coreTypes.Block, commitQC *typesCons.QuorumCertificate) error {
+	qcBytes, err := codec.GetCodec().Marshal(commitQC)
+	if err != nil {
+		return err
+	}
+
 	// Commit the context
-	if err := m.utilityContext.Commit(block.BlockHeader.QuorumCertificate); err != nil {
+	if err := m.utilityContext.Commit(qcBytes); err != nil {
 		return err
 	}
 
@@ -29,6 +36,67 @@ func (m *consensusModule) commitBlock(block *coreTypes.Block) error {
 
 	m.utilityContext = nil
 
+	m.stateSync.HandleCommittedBlock(block.BlockHeader.Height)
+
+	return nil
+}
+
+// CommitSyncedBlock validates a block retrieved from a peer during state sync against its commit QC,
+// applies it on top of the local state and commits it before moving the node onto the next height.
+func (m *consensusModule) CommitSyncedBlock(block *coreTypes.Block) error {
+	if block == nil || block.BlockHeader == nil {
+		return typesCons.ErrNilBlock
+	}
+	blockHeight := block.BlockHeader.Height
+
+	commitQC := new(typesCons.QuorumCertificate)
+	if err := codec.GetCodec().Unmarshal(block.BlockHeader.QuorumCertificate, commitQC); err != nil {
+		return err
+	}
+
+	// The QC must be a commit QC for this exact block
+	if commitQC.Height != blockHeight || commitQC.Step != Commit ||
+		commitQC.Block == nil || commitQC.Block.BlockHeader == nil ||
+		commitQC.Block.BlockHeader.StateHash != block.BlockHeader.StateHash {
+		return typesCons.ErrSyncedBlockQCMismatch(blockHeight, commitQC.Height)
+	}
+
+	// The validator set used to verify the QC is the one at the height of the block being synched. The QC is
+	// validated before the state of the consensus module is changed so an invalid block leaves it untouched.
+	if err := m.validateQuorumCertificateAtHeight(commitQC, blockHeight); err != nil {
+		return err
+	}
+
+	// The utility context applying the block is created at the height of the block; the previous height is
+	// restored if the block cannot be applied or committed
+	previousHeight := m.height
+	m.height = blockHeight
+
+	if err := m.refreshUtilityContext(); err != nil {
+		m.height = previousHeight
+		return err
+	}
+
+	if err := m.applyBlock(block); err != nil {
+		if releaseErr := m.ReleaseUtilityContext(); releaseErr != nil {
+			m.logger.Warn().Err(releaseErr).Msg("Error releasing utility context")
+		}
+		m.height = previousHeight
+		return err
+	}
+
+	if err := m.commitBlock(block, commitQC); err != nil {
+		if releaseErr := m.ReleaseUtilityContext(); releaseErr != nil {
+			m.logger.Warn().Err(releaseErr).Msg("Error releasing utility context")
+		}
+		m.height = previousHeight
+		return err
+	}
+
+	m.SetHeight(blockHeight + 1)
+	m.ResetForNewHeight()
+	m.ResetRound()
+
 	return nil
 }
 
@@ -6,8 +6,13 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/pokt-network/pocket/consensus"
 	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/runtime"
 	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/messaging"
 	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -179,3 +184,321 @@ func TestStateSync_ServerGetBlock_FailNonExistingBlock(t *testing.T) {
 	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numExpectedMsgs, 250, false)
 	require.Error(t, err)
 }
+
+func TestStateSync_ClientRequestsMissingBlocks_Success(t *testing.T) {
+	// Test preparation
+	clockMock := clock.NewMock()
+	timeReminder(t, clockMock, time.Second)
+
+	// Test configs
+	runtimeMgrs := GenerateNodeRuntimeMgrs(t, numValidators, clockMock)
+	buses := GenerateBuses(t, runtimeMgrs)
+
+	// Create & start test pocket nodes
+	eventsChannel := make(modules.EventsChannel, 100)
+	pocketNodes := CreateTestConsensusPocketNodes(t, buses, eventsChannel)
+	StartAllTestPocketNodes(t, pocketNodes)
+
+	// Choose node 1 as the requester node which is behind the rest of the network
+	requesterNode := pocketNodes[1]
+	requesterNodePeerAddress := requesterNode.GetBus().GetConsensusModule().GetNodeAddress()
+
+	// Choose node 2 as the server node which is ahead of the requester node
+	serverNode := pocketNodes[2]
+	serverNodePeerAddress := serverNode.GetBus().GetConsensusModule().GetNodeAddress()
+	serverNodeMaxHeight := uint64(3)
+
+	// Put the requester node in sync mode
+	syncModeEvent, err := messaging.PackMessage(&messaging.StateMachineTransitionEvent{
+		Event:         string(coreTypes.StateMachineEvent_Consensus_IsSyncing),
+		PreviousState: string(coreTypes.StateMachineState_Consensus_Unsynched),
+		NewState:      string(coreTypes.StateMachineState_Consensus_SyncMode),
+	})
+	require.NoError(t, err)
+	requesterNode.GetBus().PublishEventToBus(syncModeEvent)
+
+	// The requester node asks all the other validators for their state sync metadata
+	errMsg := "StateSync Metadata Request"
+	receivedMsgs, err := WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numValidators-1, 250, false)
+	require.NoError(t, err)
+	for _, receivedMsg := range receivedMsgs {
+		msg, err := codec.GetCodec().FromAny(receivedMsg)
+		require.NoError(t, err)
+
+		metadataReq := msg.(*typesCons.StateSyncMessage).GetMetadataReq()
+		require.NotNil(t, metadataReq)
+		require.Equal(t, requesterNodePeerAddress, metadataReq.PeerAddress)
+	}
+
+	// The server node advertises that it has more blocks than the requester node
+	P2PSend(t, requesterNode, newStateSyncMetadataRes(t, serverNodePeerAddress, serverNodeMaxHeight))
+
+	// The requester node waits for the metadata of its other peers before requesting any block, since they may
+	// advertise a higher height
+	errMsg = "StateSync Get Block Request Message"
+	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, 1, 250, false)
+	require.Error(t, err)
+
+	// The other peers advertise that they are not ahead of the requester node
+	for _, pocketNode := range pocketNodes {
+		if pocketNode == requesterNode || pocketNode == serverNode {
+			continue
+		}
+		P2PSend(t, requesterNode, newStateSyncMetadataRes(t, pocketNode.GetBus().GetConsensusModule().GetNodeAddress(), 0))
+	}
+
+	// The requester node requests each of the blocks it is missing
+	receivedMsgs, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, int(serverNodeMaxHeight), 250, false)
+	require.NoError(t, err)
+	requireBlocksRequested(t, receivedMsgs, requesterNodePeerAddress, serverNodeMaxHeight)
+}
+
+func TestStateSync_ClientRequestsMissingBlocks_MetadataTimeout(t *testing.T) {
+	// Test preparation
+	clockMock := clock.NewMock()
+	timeReminder(t, clockMock, time.Second)
+
+	// Test configs
+	runtimeMgrs := GenerateNodeRuntimeMgrs(t, numValidators, clockMock)
+	buses := GenerateBuses(t, runtimeMgrs)
+
+	// Create & start test pocket nodes
+	eventsChannel := make(modules.EventsChannel, 100)
+	pocketNodes := CreateTestConsensusPocketNodes(t, buses, eventsChannel)
+	StartAllTestPocketNodes(t, pocketNodes)
+
+	requesterNode := pocketNodes[1]
+	requesterNodePeerAddress := requesterNode.GetBus().GetConsensusModule().GetNodeAddress()
+	serverNode := pocketNodes[2]
+	serverNodePeerAddress := serverNode.GetBus().GetConsensusModule().GetNodeAddress()
+	serverNodeMaxHeight := uint64(3)
+
+	// Put the requester node in sync mode
+	syncModeEvent, err := messaging.PackMessage(&messaging.StateMachineTransitionEvent{
+		Event:         string(coreTypes.StateMachineEvent_Consensus_IsSyncing),
+		PreviousState: string(coreTypes.StateMachineState_Consensus_Unsynched),
+		NewState:      string(coreTypes.StateMachineState_Consensus_SyncMode),
+	})
+	require.NoError(t, err)
+	requesterNode.GetBus().PublishEventToBus(syncModeEvent)
+
+	errMsg := "StateSync Metadata Request"
+	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numValidators-1, 250, false)
+	require.NoError(t, err)
+
+	// Only the server node advertises its metadata
+	P2PSend(t, requesterNode, newStateSyncMetadataRes(t, serverNodePeerAddress, serverNodeMaxHeight))
+
+	errMsg = "StateSync Get Block Request Message"
+	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, 1, 250, false)
+	require.Error(t, err)
+
+	// The requester node stops waiting for the other peers once the metadata request times out
+	clockMock.Add(10 * time.Second)
+
+	receivedMsgs, err := WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, int(serverNodeMaxHeight), 250, false)
+	require.NoError(t, err)
+	requireBlocksRequested(t, receivedMsgs, requesterNodePeerAddress, serverNodeMaxHeight)
+}
+
+func TestStateSync_ClientAppliesSyncedBlocks_Success(t *testing.T) {
+	// Test preparation
+	clockMock := clock.NewMock()
+	timeReminder(t, clockMock, time.Second)
+
+	// Test configs
+	runtimeMgrs := GenerateNodeRuntimeMgrs(t, numValidators, clockMock)
+	buses := GenerateBuses(t, runtimeMgrs)
+
+	// Create & start test pocket nodes
+	eventsChannel := make(modules.EventsChannel, 100)
+	pocketNodes := CreateTestConsensusPocketNodes(t, buses, eventsChannel)
+	StartAllTestPocketNodes(t, pocketNodes)
+
+	requesterNode := pocketNodes[1]
+	requesterNodePeerAddress := requesterNode.GetBus().GetConsensusModule().GetNodeAddress()
+	serverNode := pocketNodes[2]
+	serverNodePeerAddress := serverNode.GetBus().GetConsensusModule().GetNodeAddress()
+	serverNodeMaxHeight := uint64(3)
+
+	// Put the requester node in sync mode
+	syncModeEvent, err := messaging.PackMessage(&messaging.StateMachineTransitionEvent{
+		Event:         string(coreTypes.StateMachineEvent_Consensus_IsSyncing),
+		PreviousState: string(coreTypes.StateMachineState_Consensus_Unsynched),
+		NewState:      string(coreTypes.StateMachineState_Consensus_SyncMode),
+	})
+	require.NoError(t, err)
+	requesterNode.GetBus().PublishEventToBus(syncModeEvent)
+
+	errMsg := "StateSync Metadata Request"
+	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numValidators-1, 250, false)
+	require.NoError(t, err)
+
+	// All the peers advertise their metadata, and only the server node is ahead of the requester node
+	for _, pocketNode := range pocketNodes {
+		if pocketNode == requesterNode {
+			continue
+		}
+		maxHeight := uint64(0)
+		if pocketNode == serverNode {
+			maxHeight = serverNodeMaxHeight
+		}
+		P2PSend(t, requesterNode, newStateSyncMetadataRes(t, pocketNode.GetBus().GetConsensusModule().GetNodeAddress(), maxHeight))
+	}
+
+	errMsg = "StateSync Get Block Request Message"
+	receivedMsgs, err := WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, int(serverNodeMaxHeight), 250, false)
+	require.NoError(t, err)
+	requireBlocksRequested(t, receivedMsgs, requesterNodePeerAddress, serverNodeMaxHeight)
+
+	// A block that was not requested, above the network height, is dropped rather than applied
+	P2PSend(t, requesterNode, newStateSyncGetBlockRes(t, runtimeMgrs, serverNodePeerAddress, serverNodeMaxHeight+1))
+	_, err = WaitForStateMachineEvents(t, clockMock, eventsChannel, coreTypes.StateMachineEvent_Consensus_IsCaughtUp, 1, 250, false)
+	require.Error(t, err)
+	require.Equal(t, uint64(0), requesterNode.GetBus().GetConsensusModule().CurrentHeight())
+
+	// The server node responds with the blocks that were requested
+	for height := uint64(1); height <= serverNodeMaxHeight; height++ {
+		P2PSend(t, requesterNode, newStateSyncGetBlockRes(t, runtimeMgrs, serverNodePeerAddress, height))
+	}
+
+	// The requester node applies the blocks and signals the state machine that it caught up with the network
+	_, err = WaitForStateMachineEvents(t, clockMock, eventsChannel, coreTypes.StateMachineEvent_Consensus_IsCaughtUp, 1, 250, false)
+	require.NoError(t, err)
+	require.Equal(t, serverNodeMaxHeight+1, requesterNode.GetBus().GetConsensusModule().CurrentHeight())
+}
+
+func TestStateSync_ClientRetriesMetadataRequest_NoPeerResponds(t *testing.T) {
+	// Test preparation
+	clockMock := clock.NewMock()
+	timeReminder(t, clockMock, time.Second)
+
+	// Test configs
+	runtimeMgrs := GenerateNodeRuntimeMgrs(t, numValidators, clockMock)
+	// The pacemaker must not time out while the clock is moved forward, or the new rounds would flood the events channel
+	for _, runtimeMgr := range runtimeMgrs {
+		runtimeMgr.GetConfig().Consensus.PacemakerConfig.TimeoutMsec = uint64(time.Hour.Milliseconds())
+	}
+	buses := GenerateBuses(t, runtimeMgrs)
+
+	// Create & start test pocket nodes
+	eventsChannel := make(modules.EventsChannel, 100)
+	pocketNodes := CreateTestConsensusPocketNodes(t, buses, eventsChannel)
+	StartAllTestPocketNodes(t, pocketNodes)
+
+	requesterNode := pocketNodes[1]
+
+	// Put the requester node in sync mode
+	syncModeEvent, err := messaging.PackMessage(&messaging.StateMachineTransitionEvent{
+		Event:         string(coreTypes.StateMachineEvent_Consensus_IsSyncing),
+		PreviousState: string(coreTypes.StateMachineState_Consensus_Unsynched),
+		NewState:      string(coreTypes.StateMachineState_Consensus_SyncMode),
+	})
+	require.NoError(t, err)
+	requesterNode.GetBus().PublishEventToBus(syncModeEvent)
+
+	errMsg := "StateSync Metadata Request"
+	_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numValidators-1, 250, false)
+	require.NoError(t, err)
+
+	// None of the peers responds: the metadata keeps being requested, with a backoff that doubles after every
+	// attempt, and the node never considers itself synched since it is not the only validator
+	for _, backoff := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second} {
+		clockMock.Add(backoff + time.Second)
+
+		_, err = WaitForNetworkStateSyncEvents(t, clockMock, eventsChannel, errMsg, numValidators-1, 250, false)
+		require.NoError(t, err)
+	}
+
+	_, err = WaitForStateMachineEvents(t, clockMock, eventsChannel, coreTypes.StateMachineEvent_Consensus_IsCaughtUp, 1, 250, false)
+	require.Error(t, err)
+}
+
+func newStateSyncMetadataRes(t *testing.T, peerAddress string, maxHeight uint64) *anypb.Any {
+	stateSyncMetadataResMessage := &typesCons.StateSyncMessage{
+		Message: &typesCons.StateSyncMessage_MetadataRes{
+			MetadataRes: &typesCons.StateSyncMetadataResponse{
+				PeerAddress: peerAddress,
+				MinHeight:   1,
+				MaxHeight:   maxHeight,
+			},
+		},
+	}
+	anyProto, err := anypb.New(stateSyncMetadataResMessage)
+	require.NoError(t, err)
+	return anyProto
+}
+
+// requireBlocksRequested asserts that the messages are the block requests of the requester, for all the heights up to `maxHeight`
+func requireBlocksRequested(t *testing.T, receivedMsgs []*anypb.Any, requesterNodePeerAddress string, maxHeight uint64) {
+	requestedHeights := make(map[uint64]bool, maxHeight)
+	for _, receivedMsg := range receivedMsgs {
+		msg, err := codec.GetCodec().FromAny(receivedMsg)
+		require.NoError(t, err)
+
+		getBlockReq := msg.(*typesCons.StateSyncMessage).GetGetBlockReq()
+		require.NotNil(t, getBlockReq)
+		require.Equal(t, requesterNodePeerAddress, getBlockReq.PeerAddress)
+		requestedHeights[getBlockReq.Height] = true
+	}
+	for height := uint64(1); height <= maxHeight; height++ {
+		require.True(t, requestedHeights[height], "block at height %d was not requested", height)
+	}
+}
+
+// newStateSyncGetBlockRes returns the response of the server with the block at `height`, along with a commit QC
+// signed by all the validators
+func newStateSyncGetBlockRes(t *testing.T, runtimeMgrs []*runtime.Manager, peerAddress string, height uint64) *anypb.Any {
+	qcBlock := &coreTypes.Block{
+		BlockHeader: &coreTypes.BlockHeader{
+			Height:    height,
+			StateHash: stateHash,
+		},
+		Transactions: make([][]byte, 0),
+	}
+	commitQC := &typesCons.QuorumCertificate{
+		Height:             height,
+		Step:               consensus.Commit,
+		Block:              qcBlock,
+		ThresholdSignature: &typesCons.ThresholdSignature{},
+	}
+
+	msgToSign := &typesCons.HotstuffMessage{
+		Height: height,
+		Step:   consensus.Commit,
+		Block:  qcBlock,
+	}
+	bytesToSign, err := msgToSign.SignableBytes()
+	require.NoError(t, err)
+	for _, runtimeMgr := range runtimeMgrs {
+		privateKey, err := cryptoPocket.NewPrivateKey(runtimeMgr.GetConfig().PrivateKey)
+		require.NoError(t, err)
+		signature, err := privateKey.Sign(bytesToSign)
+		require.NoError(t, err)
+		commitQC.ThresholdSignature.Signatures = append(commitQC.ThresholdSignature.Signatures, &typesCons.PartialSignature{
+			Signature: signature,
+			Address:   privateKey.Address().String(),
+		})
+	}
+	qcBytes, err := codec.GetCodec().Marshal(commitQC)
+	require.NoError(t, err)
+
+	stateSyncGetBlockResMessage := &typesCons.StateSyncMessage{
+		Message: &typesCons.StateSyncMessage_GetBlockRes{
+			GetBlockRes: &typesCons.GetBlockResponse{
+				PeerAddress: peerAddress,
+				Block: &coreTypes.Block{
+					BlockHeader: &coreTypes.BlockHeader{
+						Height:            height,
+						StateHash:         stateHash,
+						QuorumCertificate: qcBytes,
+					},
+					Transactions: make([][]byte, 0),
+				},
+			},
+		},
+	}
+	anyProto, err := anypb.New(stateSyncGetBlockResMessage)
+	require.NoError(t, err)
+	return anyProto
+}
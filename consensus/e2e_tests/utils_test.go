@@ -263,6 +263,30 @@ func WaitForNetworkStateSyncEvents(
 	return waitForEventsInternal(clck, eventsChannel, consensus.StateSyncMessageContentType, numExpectedMsgs, millis, includeFilter, errMsg, failOnExtraMessages)
 }
 
+// This is a helper for `waitForEventsInternal` that waits for the given event to be sent to the state machine
+func WaitForStateMachineEvents(
+	t *testing.T,
+	clck *clock.Mock,
+	eventsChannel modules.EventsChannel,
+	event coreTypes.StateMachineEvent,
+	numExpectedMsgs int,
+	millis time.Duration,
+	failOnExtraMessages bool,
+) (messages []*anypb.Any, err error) {
+	includeFilter := func(anyMsg *anypb.Any) bool {
+		msg, err := codec.GetCodec().FromAny(anyMsg)
+		require.NoError(t, err)
+
+		transitionEvent, ok := msg.(*messaging.StateMachineTransitionEvent)
+		require.True(t, ok)
+
+		return transitionEvent.Event == string(event)
+	}
+
+	errMsg := fmt.Sprintf("StateMachine event: %s", event)
+	return waitForEventsInternal(clck, eventsChannel, messaging.StateMachineTransitionEventType, numExpectedMsgs, millis, includeFilter, errMsg, failOnExtraMessages)
+}
+
 // RESEARCH(#462): Research ways to eliminate time-based non-determinism from the test framework
 // IMPROVE: This function can be extended to testing events outside of just the consensus module.
 func waitForEventsInternal(
@@ -490,16 +514,29 @@ func baseRpcMock(t *testing.T, _ modules.EventsChannel) *mockModules.MockRPCModu
 	rpcMock.EXPECT().Start().Return(nil).AnyTimes()
 	rpcMock.EXPECT().SetBus(gomock.Any()).Return().AnyTimes()
 	rpcMock.EXPECT().GetModuleName().Return(modules.RPCModuleName).AnyTimes()
+	rpcMock.EXPECT().HandleEvent(gomock.Any()).Return(nil).AnyTimes()
 
 	return rpcMock
 }
 
-func baseStateMachineMock(t *testing.T, _ modules.EventsChannel) *mockModules.MockStateMachineModule {
+func baseStateMachineMock(t *testing.T, eventsChannel modules.EventsChannel) *mockModules.MockStateMachineModule {
 	ctrl := gomock.NewController(t)
 	stateMachineMock := mockModules.NewMockStateMachineModule(ctrl)
 	stateMachineMock.EXPECT().Start().Return(nil).AnyTimes()
 	stateMachineMock.EXPECT().SetBus(gomock.Any()).Return().AnyTimes()
 	stateMachineMock.EXPECT().GetModuleName().Return(modules.StateMachineModuleName).AnyTimes()
+	// The events sent to the state machine are published on the events channel so the tests can wait for them
+	stateMachineMock.EXPECT().
+		SendEvent(gomock.Any()).
+		DoAndReturn(func(event coreTypes.StateMachineEvent, _ ...any) error {
+			transitionEvent, err := messaging.PackMessage(&messaging.StateMachineTransitionEvent{
+				Event: string(event),
+			})
+			require.NoError(t, err)
+			eventsChannel <- transitionEvent
+			return nil
+		}).
+		AnyTimes()
 
 	return stateMachineMock
 }
@@ -1,9 +1,55 @@
 package consensus
 
 import (
+	"fmt"
+
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/messaging"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
+// HandleEvent handles the events relevant to the consensus module that are published to the bus
+func (m *consensusModule) HandleEvent(event *anypb.Any) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	evt, err := codec.GetCodec().FromAny(event)
+	if err != nil {
+		return err
+	}
+
+	switch event.MessageName() {
+	case messaging.StateMachineTransitionEventType:
+		stateMachineTransitionEvent, ok := evt.(*messaging.StateMachineTransitionEvent)
+		if !ok {
+			return fmt.Errorf("failed to cast event to StateMachineTransitionEvent")
+		}
+		return m.handleStateMachineTransitionEvent(stateMachineTransitionEvent)
+
+	default:
+		return fmt.Errorf("unknown event type: %s", event.MessageName())
+	}
+}
+
+func (m *consensusModule) handleStateMachineTransitionEvent(event *messaging.StateMachineTransitionEvent) error {
+	stateMachine := m.GetBus().GetStateMachineModule()
+
+	switch coreTypes.StateMachineState(event.NewState) {
+	case coreTypes.StateMachineState_P2P_Bootstrapped:
+		// IMPROVE: Skip straight to `Consensus_IsCaughtUp` if the node can determine it is synched without asking its peers
+		return stateMachine.SendEvent(coreTypes.StateMachineEvent_Consensus_IsUnsynched)
+	case coreTypes.StateMachineState_Consensus_Unsynched:
+		return stateMachine.SendEvent(coreTypes.StateMachineEvent_Consensus_IsSyncing)
+	case coreTypes.StateMachineState_Consensus_SyncMode:
+		return m.stateSync.StartSyncing()
+	case coreTypes.StateMachineState_Consensus_Synced:
+		m.logger.Info().Uint64("height", m.height).Msg("Node is synched with the network")
+	}
+
+	return nil
+}
+
 // publishNewHeightEvent publishes a new height event to the bus so that other interested IntegratableModules can react to it if necessary
 func (m *consensusModule) publishNewHeightEvent(height uint64) {
 	newHeightEvent, err := messaging.PackMessage(&messaging.ConsensusNewHeightEvent{Height: height})
@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"fmt"
+
+	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+// findConflictingVote returns the vote the leader already indexed that conflicts with `msg`, i.e. a vote of the same
+// validator at the same height, round and step for a different block, or nil if there is none
+func (m *consensusModule) findConflictingVote(msg *typesCons.HotstuffMessage) *typesCons.HotstuffMessage {
+	for _, indexedMsg := range m.hotstuffMempool[msg.GetStep()].GetAll() {
+		if indexedMsg.ConflictsWith(msg) {
+			return indexedMsg
+		}
+	}
+	return nil
+}
+
+// submitDoubleSignEvidence signs a `MessageDoubleSign` transaction carrying the conflicting votes with the node's
+// private key, adds it to the utility mempool and gossips it so the stake of the validator is burnt
+func (m *consensusModule) submitDoubleSignEvidence(voteA, voteB *typesCons.HotstuffMessage) error {
+	txBz, err := m.prepareDoubleSignTx(voteA, voteB)
+	if err != nil {
+		return err
+	}
+
+	if err := m.GetBus().GetUtilityModule().HandleTransaction(txBz); err != nil {
+		return err
+	}
+
+	txGossipMessage, err := typesUtil.PrepareTxGossipMessage(txBz)
+	if err != nil {
+		return err
+	}
+	return m.GetBus().GetP2PModule().Broadcast(txGossipMessage)
+}
+
+func (m *consensusModule) prepareDoubleSignTx(voteA, voteB *typesCons.HotstuffMessage) ([]byte, error) {
+	msg := &typesUtil.MessageDoubleSign{
+		VoteA:           voteA,
+		VoteB:           voteB,
+		ReporterAddress: m.privateKey.Address(),
+	}
+	anyMsg, err := codec.GetCodec().ToAny(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &coreTypes.Transaction{
+		Msg:   anyMsg,
+		Nonce: fmt.Sprintf("%d", cryptoPocket.GetNonce()),
+	}
+	signBytes, err := tx.SignableBytes()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := m.privateKey.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature = &coreTypes.Signature{
+		Signature: signature,
+		PublicKey: m.privateKey.PublicKey().Bytes(),
+	}
+
+	return codec.GetCodec().Marshal(tx)
+}
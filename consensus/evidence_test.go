@@ -0,0 +1,60 @@
+package consensus
+
+import (
+	"testing"
+
+	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvidence_FindConflictingVote(t *testing.T) {
+	validatorKey, err := cryptoPocket.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherValidatorKey, err := cryptoPocket.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	m := &consensusModule{
+		hotstuffMempool: map[typesCons.HotstuffStep]*hotstuffFIFOMempool{
+			Prepare: NewHotstuffFIFOMempool(1e6),
+		},
+	}
+	vote := newTestVote(t, validatorKey, "a")
+	require.NoError(t, m.hotstuffMempool[Prepare].Push(vote))
+
+	require.Nil(t, m.findConflictingVote(newTestVote(t, validatorKey, "a")), "the same vote does not conflict")
+	require.Nil(t, m.findConflictingVote(newTestVote(t, otherValidatorKey, "b")), "votes of other validators do not conflict")
+	require.Equal(t, vote, m.findConflictingVote(newTestVote(t, validatorKey, "b")))
+}
+
+func TestEvidence_PrepareDoubleSignTx(t *testing.T) {
+	validatorKey, err := cryptoPocket.GeneratePrivateKey()
+	require.NoError(t, err)
+	reporterKey, err := cryptoPocket.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	m := &consensusModule{privateKey: reporterKey.(cryptoPocket.Ed25519PrivateKey)}
+	voteA, voteB := newTestVote(t, validatorKey, "a"), newTestVote(t, validatorKey, "b")
+	txBz, err := m.prepareDoubleSignTx(voteA, voteB)
+	require.NoError(t, err)
+
+	tx, err := coreTypes.TxFromBytes(txBz)
+	require.NoError(t, err)
+	require.NoError(t, tx.ValidateBasic())
+	anyMsg, err := codec.GetCodec().FromAny(tx.Msg)
+	require.NoError(t, err)
+	msg, ok := anyMsg.(*typesUtil.MessageDoubleSign)
+	require.True(t, ok)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, reporterKey.Address(), cryptoPocket.Address(msg.GetSigner()))
+}
+
+func newTestVote(t *testing.T, key cryptoPocket.PrivateKey, stateHash string) *typesCons.HotstuffMessage {
+	block := &coreTypes.Block{BlockHeader: &coreTypes.BlockHeader{Height: 1, StateHash: stateHash}}
+	vote, err := CreateVoteMessage(1, 1, Prepare, block, key)
+	require.NoError(t, err)
+	return vote
+}
@@ -129,7 +129,7 @@ func isSignatureValid(msg *typesCons.HotstuffMessage, pubKeyString string, signa
 		logger.Global.Warn().Err(err).Msgf("Error getting PublicKey from bytes")
 		return false
 	}
-	bytesToVerify, err := getSignableBytes(msg)
+	bytesToVerify, err := msg.SignableBytes()
 	if err != nil {
 		logger.Global.Warn().Err(err).Msgf("Error getting bytes to verify")
 		return false
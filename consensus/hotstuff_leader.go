@@ -243,7 +243,7 @@ func (handler *HotstuffLeaderMessageHandler) HandleCommitMessage(m *consensusMod
 	}
 	m.broadcastToValidators(decideProposeMessage)
 
-	if err := m.commitBlock(m.block); err != nil {
+	if err := m.commitBlock(m.block, commitQC); err != nil {
 		m.logger.Error().Err(err).Msg(typesCons.ErrCommitBlock.Error())
 		m.paceMaker.InterruptRound("failed to commit block")
 		return
@@ -283,6 +283,14 @@ func (handler *HotstuffLeaderMessageHandler) anteHandle(m *consensusModule, msg
 		return err
 	}
 
+	// Discard votes conflicting with one the validator already sent, and submit both as evidence of it double signing
+	if conflictingVote := m.findConflictingVote(msg); conflictingVote != nil {
+		if err := m.submitDoubleSignEvidence(conflictingVote, msg); err != nil {
+			m.logger.Error().Err(err).Msg(typesCons.ErrSubmitDoubleSignEvidence.Error())
+		}
+		return typesCons.ErrDoubleSignVote
+	}
+
 	// Index the hotstuff message in the consensus mempool
 	if err := m.indexHotstuffMessage(msg); err != nil {
 		return err
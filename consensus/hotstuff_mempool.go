@@ -155,6 +155,7 @@ func hashMsg(msg *typesCons.HotstuffMessage) string {
 }
 
 func onDuplicateMessageDetected(item *typesCons.HotstuffMessage) {
-	// TODO(#432): Potential place to check for double signing
+	// NB: An identical message is not evidence of double signing; conflicting votes are detected by the leader before
+	// they are indexed. See `findConflictingVote`.
 	log.Printf("duplicate message detected - hash: %s", hashMsg(item))
 }
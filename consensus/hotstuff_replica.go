@@ -152,7 +152,7 @@ func (handler *HotstuffReplicaMessageHandler) HandleDecideMessage(m *consensusMo
 		return
 	}
 
-	if err := m.commitBlock(m.block); err != nil {
+	if err := m.commitBlock(m.block, quorumCert); err != nil {
 		m.logger.Error().Err(err).Msg("Could not commit block")
 		m.paceMaker.InterruptRound("failed to commit block")
 		return
@@ -248,6 +248,11 @@ func (m *consensusModule) applyBlock(block *coreTypes.Block) error {
 }
 
 func (m *consensusModule) validateQuorumCertificate(qc *typesCons.QuorumCertificate) error {
+	return m.validateQuorumCertificateAtHeight(qc, m.CurrentHeight())
+}
+
+// validateQuorumCertificateAtHeight validates the QC against the validator set at `height`
+func (m *consensusModule) validateQuorumCertificateAtHeight(qc *typesCons.QuorumCertificate, height uint64) error {
 	if qc == nil {
 		return typesCons.ErrNilQC
 	}
@@ -263,7 +268,7 @@ func (m *consensusModule) validateQuorumCertificate(qc *typesCons.QuorumCertific
 	msgToJustify := qcToHotstuffMessage(qc)
 	numValid := 0
 
-	validators, err := m.getValidatorsAtHeight(m.CurrentHeight())
+	validators, err := m.getValidatorsAtHeight(height)
 	if err != nil {
 		return err
 	}
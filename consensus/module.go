@@ -296,6 +296,18 @@ func (m *consensusModule) CurrentStep() uint64 {
 	return uint64(m.step)
 }
 
+func (m *consensusModule) GetSyncHeights() (localHeight, networkHeight uint64, err error) {
+	// The sync metadata of the peers is guarded by the consensus lock
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	state, err := m.stateSync.GetSyncState()
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint64(state.LatestHeight()), uint64(state.LatestNetworkHeight()), nil
+}
+
 // TODO: Populate the entire state from the persistence module: validator set, quorum cert, last block hash, etc...
 func (m *consensusModule) loadPersistedState() error {
 	persistenceContext, err := m.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
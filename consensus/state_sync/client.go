@@ -0,0 +1,477 @@
+package state_sync
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	typesCons "github.com/pokt-network/pocket/consensus/types"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
+)
+
+// This file contains the client side of the state sync protocol: the business logic used by a node
+// to retrieve the blocks it is missing from its peers and apply them until it catches up with the network.
+
+type SyncState interface {
+	// latest local height
+	LatestHeight() int64
+	// latest network height (from the aggregation of Peer Sync Meta)
+	LatestNetworkHeight() int64
+	// retrieve peer meta (actively updated through churn management)
+	GetPeers() []PeerSyncMeta
+	// returns ordered array of missing block heights
+	GetMissingBlockHeights() []int64
+}
+
+// TODO: needs to be shared between P2P as the Churn Management Process updates this information
+type PeerSyncMeta interface {
+	// the unique identifier associated with the peer
+	GetPeerID() string
+	// the maximum height the peer has in the block store
+	GetMaxHeight() int64
+	// the minimum height the peer has in the block store
+	GetMinHeight() int64
+}
+
+var (
+	_ SyncState    = &syncState{}
+	_ PeerSyncMeta = &peerSyncMeta{}
+)
+
+type peerSyncMeta struct {
+	peerAddress string
+	minHeight   int64
+	maxHeight   int64
+}
+
+func (p *peerSyncMeta) GetPeerID() string {
+	return p.peerAddress
+}
+
+func (p *peerSyncMeta) GetMaxHeight() int64 {
+	return p.maxHeight
+}
+
+func (p *peerSyncMeta) GetMinHeight() int64 {
+	return p.minHeight
+}
+
+// syncState is a snapshot of the local and network sync state at the time it was created
+type syncState struct {
+	latestHeight int64
+	peers        []PeerSyncMeta
+}
+
+func (s *syncState) LatestHeight() int64 {
+	return s.latestHeight
+}
+
+func (s *syncState) LatestNetworkHeight() int64 {
+	networkHeight := s.latestHeight
+	for _, peer := range s.peers {
+		if peer.GetMaxHeight() > networkHeight {
+			networkHeight = peer.GetMaxHeight()
+		}
+	}
+	return networkHeight
+}
+
+func (s *syncState) GetPeers() []PeerSyncMeta {
+	return s.peers
+}
+
+func (s *syncState) GetMissingBlockHeights() []int64 {
+	networkHeight := s.LatestNetworkHeight()
+	missingHeights := make([]int64, 0, networkHeight-s.latestHeight)
+	for height := s.latestHeight + 1; height <= networkHeight; height++ {
+		missingHeights = append(missingHeights, height)
+	}
+	return missingHeights
+}
+
+func (s *syncState) isCaughtUp() bool {
+	return s.latestHeight >= s.LatestNetworkHeight()
+}
+
+func (m *stateSync) StartSyncing() error {
+	m.logger.Info().Msg("Starting state sync")
+
+	m.currentMode = Sync
+	m.peersMetadata = make(map[string]*peerSyncMeta)
+	m.pendingBlockRequests = make(map[uint64]time.Time)
+	m.receivedBlocks = make(map[uint64]*coreTypes.Block)
+	m.metadataRequestAttempts = 0
+	m.metadataCollected = false
+
+	numPeers, err := m.requestMetadata()
+	if err != nil {
+		return err
+	}
+
+	// A node without any peers is, by definition, synched with the network it knows about
+	if numPeers == 0 {
+		return m.setSynched()
+	}
+
+	m.startSyncTicker()
+	return nil
+}
+
+// requestMetadata requests the state sync metadata from the peers of the node and returns how many peers it was
+// requested from
+func (m *stateSync) requestMetadata() (numPeers int, err error) {
+	consensusMod := m.GetBus().GetConsensusModule()
+	nodeAddress := consensusMod.GetNodeAddress()
+
+	// IMPROVE: Request the metadata from all the peers in the P2P address book rather than the validator set only
+	validators, err := m.getLatestValidators()
+	if err != nil {
+		return 0, err
+	}
+
+	stateSyncMetadataReqMessage := &typesCons.StateSyncMessage{
+		Message: &typesCons.StateSyncMessage_MetadataReq{
+			MetadataReq: &typesCons.StateSyncMetadataRequest{
+				PeerAddress: nodeAddress,
+			},
+		},
+	}
+
+	for _, val := range validators {
+		if val.GetAddress() == nodeAddress {
+			continue
+		}
+		numPeers++
+		valAddress := cryptoPocket.AddressFromString(val.GetAddress())
+		if err := m.SendStateSyncMessage(stateSyncMetadataReqMessage, valAddress, consensusMod.CurrentHeight()); err != nil {
+			m.logger.Error().Err(err).Str("proto_type", "StateSyncMetadataRequest").Msg("failed to send StateSyncMessage")
+		}
+	}
+
+	m.numMetadataPeers = numPeers
+	m.metadataRequestAttempts++
+	m.metadataRequestedAt = m.GetBus().GetRuntimeMgr().GetClock().Now()
+	return numPeers, nil
+}
+
+func (m *stateSync) HandleStateSyncMetadataResponse(metaDataRes *typesCons.StateSyncMetadataResponse) error {
+	fields := map[string]any{
+		"peer":      metaDataRes.PeerAddress,
+		"minHeight": metaDataRes.MinHeight,
+		"maxHeight": metaDataRes.MaxHeight,
+	}
+	m.logger.Info().Fields(fields).Msg("Received StateSyncMetadataResponse")
+
+	m.peersMetadata[metaDataRes.PeerAddress] = &peerSyncMeta{
+		peerAddress: metaDataRes.PeerAddress,
+		minHeight:   int64(metaDataRes.MinHeight),
+		maxHeight:   int64(metaDataRes.MaxHeight),
+	}
+
+	if m.currentMode != Sync {
+		return nil
+	}
+
+	// The network height is only known once all the peers advertised their metadata, or once they had the time to.
+	// Otherwise, the first peer to respond could make the node believe it is caught up. See `HandleSyncTick`.
+	if !m.metadataCollected {
+		if len(m.peersMetadata) < m.numMetadataPeers {
+			return nil
+		}
+		m.metadataCollected = true
+	}
+
+	return m.syncMissingBlocks()
+}
+
+func (m *stateSync) HandleSyncTick() error {
+	if m.currentMode != Sync {
+		return nil
+	}
+
+	if !m.metadataCollected {
+		clock := m.GetBus().GetRuntimeMgr().GetClock()
+		if clock.Since(m.metadataRequestedAt) < m.metadataRequestBackoff() {
+			return nil
+		}
+
+		// None of the peers is a state sync server (or reachable). The node keeps asking rather than considering
+		// itself synched, since an isolated node would otherwise join consensus at a stale height.
+		if len(m.peersMetadata) == 0 {
+			m.logger.Warn().Int("attempt", m.metadataRequestAttempts).Msg("No peer advertised its state sync metadata; requesting it again")
+			numPeers, err := m.requestMetadata()
+			if err != nil {
+				return err
+			}
+			// The other validators may have left the validator set since the last request
+			if numPeers == 0 {
+				return m.setSynched()
+			}
+			return nil
+		}
+
+		// Syncs with the peers that responded in time
+		m.metadataCollected = true
+	}
+
+	// Requests the blocks whose requests timed out again, if any
+	return m.syncMissingBlocks()
+}
+
+func (m *stateSync) HandleGetBlockResponse(blockRes *typesCons.GetBlockResponse) error {
+	block := blockRes.Block
+	if block == nil || block.BlockHeader == nil {
+		return typesCons.ErrNilBlock
+	}
+	blockHeight := block.BlockHeader.Height
+
+	fields := map[string]any{
+		"height": blockHeight,
+		"peer":   blockRes.PeerAddress,
+	}
+	m.logger.Info().Fields(fields).Msg("Received GetBlockResponse")
+
+	if m.currentMode != Sync {
+		m.logger.Debug().Fields(fields).Msg("Discarding GetBlockResponse because the node is not in sync mode")
+		return nil
+	}
+
+	// Only the blocks that were requested are kept, so peers cannot make the node buffer arbitrary blocks
+	if _, ok := m.pendingBlockRequests[blockHeight]; !ok {
+		m.logger.Debug().Fields(fields).Msg("Discarding GetBlockResponse because the block was not requested")
+		return nil
+	}
+	state, err := m.getSyncState()
+	if err != nil {
+		return err
+	}
+	if int64(blockHeight) > state.LatestNetworkHeight() {
+		m.logger.Debug().Fields(fields).Msg("Discarding GetBlockResponse because the block is above the network height")
+		return nil
+	}
+
+	delete(m.pendingBlockRequests, blockHeight)
+	m.receivedBlocks[blockHeight] = block
+
+	return m.syncMissingBlocks()
+}
+
+// syncMissingBlocks applies all the received blocks that can be applied in order, and requests the
+// next batch of missing blocks from the peers until the node catches up with the network.
+func (m *stateSync) syncMissingBlocks() error {
+	state, err := m.getSyncState()
+	if err != nil {
+		return err
+	}
+
+	for {
+		nextHeight := uint64(state.LatestHeight() + 1)
+		block, ok := m.receivedBlocks[nextHeight]
+		if !ok {
+			break
+		}
+		delete(m.receivedBlocks, nextHeight)
+
+		if err := m.GetBus().GetConsensusModule().CommitSyncedBlock(block); err != nil {
+			// The block will be requested again, potentially from a different peer, as part of the next batch
+			m.logger.Error().Err(err).Uint64("height", nextHeight).Msg("Failed to commit synched block")
+			break
+		}
+
+		if state, err = m.getSyncState(); err != nil {
+			return err
+		}
+	}
+
+	// Drop any blocks that were received for heights that were already committed
+	for height := range m.receivedBlocks {
+		if int64(height) <= state.LatestHeight() {
+			delete(m.receivedBlocks, height)
+		}
+	}
+
+	if state.isCaughtUp() {
+		return m.setSynched()
+	}
+
+	return m.requestMissingBlocks(state)
+}
+
+// metadataRequestBackoff returns how long to wait for the metadata of the peers after the last request. It doubles
+// after every unanswered request, up to `maxMetadataRequestBackoff`.
+func (m *stateSync) metadataRequestBackoff() time.Duration {
+	backoff := metadataRequestTimeout
+	for i := 1; i < m.metadataRequestAttempts && backoff < maxMetadataRequestBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxMetadataRequestBackoff {
+		return maxMetadataRequestBackoff
+	}
+	return backoff
+}
+
+// requestMissingBlocks requests the lowest missing block heights, each from a random eligible peer, while
+// keeping at most `maxPendingBlockRequests` in flight and skipping the ones that were already received.
+func (m *stateSync) requestMissingBlocks(state SyncState) error {
+	consensusMod := m.GetBus().GetConsensusModule()
+	clock := m.GetBus().GetRuntimeMgr().GetClock()
+	nodeAddress := consensusMod.GetNodeAddress()
+
+	// Requests that timed out are dropped so the blocks can be requested again
+	for height, requestedAt := range m.pendingBlockRequests {
+		if clock.Since(requestedAt) >= blockRequestTimeout {
+			delete(m.pendingBlockRequests, height)
+		}
+	}
+
+	for _, height := range state.GetMissingBlockHeights() {
+		if len(m.pendingBlockRequests) >= maxPendingBlockRequests {
+			break
+		}
+
+		blockHeight := uint64(height)
+		if _, ok := m.receivedBlocks[blockHeight]; ok {
+			continue
+		}
+		if _, ok := m.pendingBlockRequests[blockHeight]; ok {
+			continue
+		}
+
+		peer, err := getRandomEligiblePeerForHeight(state, height)
+		if err != nil {
+			m.logger.Warn().Err(err).Int64("height", height).Msg("Cannot request missing block")
+			continue
+		}
+
+		stateSyncGetBlockMessage := &typesCons.StateSyncMessage{
+			Message: &typesCons.StateSyncMessage_GetBlockReq{
+				GetBlockReq: &typesCons.GetBlockRequest{
+					PeerAddress: nodeAddress,
+					Height:      blockHeight,
+				},
+			},
+		}
+
+		peerAddress := cryptoPocket.AddressFromString(peer.GetPeerID())
+		if err := m.SendStateSyncMessage(stateSyncGetBlockMessage, peerAddress, blockHeight); err != nil {
+			m.logger.Error().Err(err).Str("proto_type", "GetBlockRequest").Msg("failed to send StateSyncMessage")
+			continue
+		}
+		m.pendingBlockRequests[blockHeight] = clock.Now()
+	}
+
+	return nil
+}
+
+// startSyncTicker periodically calls `HandleSyncTick` through the consensus module, which holds its lock, until
+// the node leaves sync mode
+func (m *stateSync) startSyncTicker() {
+	m.stopSyncTicker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.syncTickerCancelFunc = cancel
+
+	consensusMod := m.GetBus().GetConsensusModule()
+	ticker := m.GetBus().GetRuntimeMgr().GetClock().Ticker(syncTickInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := consensusMod.HandleStateSyncTick(); err != nil {
+					m.logger.Error().Err(err).Msg("Failed to handle state sync tick")
+				}
+			}
+		}
+	}()
+}
+
+func (m *stateSync) stopSyncTicker() {
+	if m.syncTickerCancelFunc != nil {
+		m.syncTickerCancelFunc()
+		m.syncTickerCancelFunc = nil
+	}
+}
+
+// setSynched moves the node out of sync mode and signals the state machine that it has caught up with the network
+func (m *stateSync) setSynched() error {
+	m.logger.Info().Msg("State sync is complete; node is caught up with the network")
+
+	m.stopSyncTicker()
+	m.currentMode = Synched
+	m.pendingBlockRequests = make(map[uint64]time.Time)
+	m.receivedBlocks = make(map[uint64]*coreTypes.Block)
+
+	return m.GetBus().GetStateMachineModule().SendEvent(coreTypes.StateMachineEvent_Consensus_IsCaughtUp)
+}
+
+func (m *stateSync) GetSyncState() (SyncState, error) {
+	state, err := m.getSyncState()
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (m *stateSync) getSyncState() (*syncState, error) {
+	readCtx, err := m.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return nil, err
+	}
+	defer readCtx.Close()
+
+	latestHeight, err := readCtx.GetMaximumBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]PeerSyncMeta, 0, len(m.peersMetadata))
+	for _, peer := range m.peersMetadata {
+		peers = append(peers, peer)
+	}
+
+	return &syncState{
+		latestHeight: int64(latestHeight),
+		peers:        peers,
+	}, nil
+}
+
+func (m *stateSync) getLatestValidators() ([]*coreTypes.Actor, error) {
+	readCtx, err := m.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return nil, err
+	}
+	defer readCtx.Close()
+
+	latestHeight, err := readCtx.GetMaximumBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := readCtx.GetAllValidators(int64(latestHeight))
+	if err != nil {
+		return nil, typesCons.ErrPersistenceGetAllValidators
+	}
+
+	return validators, nil
+}
+
+// Random selection of eligible peers enables a fair distribution of block requests over time via the law of large numbers.
+// An eligible peer is one where `PeerMeta.MinHeight <= blockHeight <= PeerMeta.MaxHeight`.
+func getRandomEligiblePeerForHeight(state SyncState, blockHeight int64) (PeerSyncMeta, error) {
+	eligiblePeers := make([]PeerSyncMeta, 0)
+	for _, peer := range state.GetPeers() {
+		if peer.GetMinHeight() <= blockHeight && blockHeight <= peer.GetMaxHeight() {
+			eligiblePeers = append(eligiblePeers, peer)
+		}
+	}
+
+	if len(eligiblePeers) == 0 {
+		return nil, typesCons.ErrNoEligiblePeer
+	}
+
+	return eligiblePeers[rand.Intn(len(eligiblePeers))], nil //nolint:gosec // G404 - Weak random source is okay for peer selection
+}
@@ -41,6 +41,10 @@ func getMessageType(msg *typesCons.StateSyncMessage) string {
 		return "GetBlockRequest"
 	case *typesCons.StateSyncMessage_GetBlockRes:
 		return "GetBlockResponse"
+	case *typesCons.StateSyncMessage_SnapshotChunkReq:
+		return "SnapshotChunkRequest"
+	case *typesCons.StateSyncMessage_SnapshotChunkRes:
+		return "SnapshotChunkResponse"
 	default:
 		return "Unknown"
 	}
@@ -1,8 +1,13 @@
 package state_sync
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	typesCons "github.com/pokt-network/pocket/consensus/types"
 	"github.com/pokt-network/pocket/logger"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/modules"
 )
@@ -10,6 +15,31 @@ import (
 const (
 	DefaultLogPrefix    = "NODE"
 	stateSyncModuleName = "stateSyncModule"
+
+	// The maximum number of blocks that can be requested from peers at any given time
+	// TECHDEBT: Move this into the consensus config
+	maxPendingBlockRequests = 10
+	// How long to wait for a block to be received before requesting it again
+	// TECHDEBT: Move this into the consensus config
+	blockRequestTimeout = 5 * time.Second
+	// How long to wait for all the peers to advertise their metadata before syncing with the ones that did, or
+	// requesting it again if none did
+	// TECHDEBT: Move this into the consensus config
+	metadataRequestTimeout = 5 * time.Second
+	// The maximum time to wait between two metadata requests when no peer advertised its metadata, since the
+	// timeout doubles after every unanswered request
+	maxMetadataRequestBackoff = time.Minute
+	// How often the progress of state sync is checked so it does not stall when the peers stop responding
+	syncTickInterval = time.Second
+
+	// The size of the chunks snapshots are split into when they are served to peers
+	snapshotChunkSize = 512 * 1024 // 512KiB
+	// The directory snapshots are kept in if one is not configured
+	defaultSnapshotsDirName = "pocket_snapshots"
+	// The number of most recent snapshots kept if it is not configured
+	defaultSnapshotsToKeep = 2
+	// Snapshots are named after the height they were taken at, e.g. `snapshot_42`
+	snapshotFilePrefix = "snapshot_"
 )
 
 type SyncMode string
@@ -33,9 +63,29 @@ type StateSyncModule interface {
 	// and catch up to the global world state
 	HandleGetBlockResponse(*typesCons.GetBlockResponse) error
 
+	// Called periodically while the node is in sync mode to stop waiting for the metadata of the peers that do not
+	// respond, and to request the blocks that were not received in time again
+	HandleSyncTick() error
+
 	IsServerModEnabled() bool
 	EnableServerMode()
 
+	// Puts the node in sync mode: it requests the state sync metadata from its peers and starts
+	// requesting, applying and committing the blocks it is missing until it catches up with the network
+	StartSyncing() error
+
+	// Returns the latest local height along with the sync metadata advertised by the peers
+	GetSyncState() (SyncState, error)
+
+	// Creates a snapshot of the state at the latest committed height, waiting for the one being created in the
+	// background if any, and returns its height & path. Only the most recent snapshots are kept.
+	// Unlike the other entrypoints, it must be called without holding the consensus module lock.
+	CreateSnapshot() (height uint64, path string, err error)
+
+	// Creates a snapshot in the background if the node is a state sync server and `height` is a multiple of the
+	// configured snapshot interval. It is called after every block is committed.
+	HandleCommittedBlock(height uint64)
+
 	SendStateSyncMessage(msg *typesCons.StateSyncMessage, nodeAddress cryptoPocket.Address, height uint64) error
 }
 
@@ -51,6 +101,29 @@ type stateSync struct {
 	currentMode SyncMode
 	serverMode  bool
 
+	// IMPORTANT: The fields below are not guarded by a lock of their own because every entrypoint into
+	// the state sync module is called while the consensus module lock is held.
+
+	// peersMetadata is the latest sync metadata advertised by each peer, keyed by the peer's address
+	peersMetadata map[string]*peerSyncMeta
+	// pendingBlockRequests maps the heights of blocks that were requested to the time they were requested at
+	pendingBlockRequests map[uint64]time.Time
+	// receivedBlocks is a buffer of blocks received out of order that cannot be applied yet
+	receivedBlocks map[uint64]*coreTypes.Block
+
+	// numMetadataPeers is the number of peers the metadata was last requested from, at `metadataRequestedAt`
+	numMetadataPeers        int
+	metadataRequestedAt     time.Time
+	metadataRequestAttempts int
+	// metadataCollected is set once all the peers advertised their metadata or the metadata request timed out
+	metadataCollected bool
+
+	// syncTickerCancelFunc stops the ticker calling `HandleSyncTick` while the node is in sync mode
+	syncTickerCancelFunc context.CancelFunc
+
+	// snapshotMu ensures a single snapshot is created at a time
+	snapshotMu sync.Mutex
+
 	logger    *modules.Logger
 	logPrefix string
 }
@@ -61,7 +134,10 @@ func CreateStateSync(bus modules.Bus, options ...modules.ModuleOption) (modules.
 
 func (*stateSync) Create(bus modules.Bus, options ...modules.ModuleOption) (modules.Module, error) {
 	m := &stateSync{
-		logPrefix: DefaultLogPrefix,
+		logPrefix:            DefaultLogPrefix,
+		peersMetadata:        make(map[string]*peerSyncMeta),
+		pendingBlockRequests: make(map[uint64]time.Time),
+		receivedBlocks:       make(map[uint64]*coreTypes.Block),
 	}
 
 	for _, option := range options {
@@ -70,8 +146,8 @@ func (*stateSync) Create(bus modules.Bus, options ...modules.ModuleOption) (modu
 
 	bus.RegisterModule(m)
 
-	// when node is starting, it is in sync mode, as it might need to bootstrap to the latest state
-	// TODO: change this to to reflect the state in the fsm once merged
+	// when node is starting, it is in sync mode, as it might need to bootstrap to the latest state.
+	// The mode is updated as the node's state machine transitions through the `Consensus_*` states.
 	m.currentMode = Sync
 	m.serverMode = false
 
@@ -115,40 +191,3 @@ func (m *stateSync) EnableServerMode() {
 	m.currentMode = Server
 	m.serverMode = true
 }
-
-// TODO(#352): Implement this function
-// Placeholder function
-func (m *stateSync) HandleGetBlockResponse(blockRes *typesCons.GetBlockResponse) error {
-	consensusMod := m.GetBus().GetConsensusModule()
-	serverNodePeerId := consensusMod.GetNodeAddress()
-	clientPeerId := blockRes.PeerAddress
-
-	fields := map[string]any{
-		"currentHeight": blockRes.Block.BlockHeader.Height,
-		"sender":        serverNodePeerId,
-		"receiver":      clientPeerId,
-	}
-
-	m.logger.Info().Fields(fields).Msgf("Received GetBlockResponse: %s", blockRes)
-
-	return nil
-}
-
-// TODO(#352): Implement the business to handle these correctly
-// Placeholder function
-func (m *stateSync) HandleStateSyncMetadataResponse(metaDataRes *typesCons.StateSyncMetadataResponse) error {
-	consensusMod := m.GetBus().GetConsensusModule()
-	serverNodePeerId := consensusMod.GetNodeAddress()
-	clientPeerId := metaDataRes.PeerAddress
-	currentHeight := consensusMod.CurrentHeight()
-
-	fields := map[string]any{
-		"currentHeight": currentHeight,
-		"sender":        serverNodePeerId,
-		"receiver":      clientPeerId,
-	}
-
-	m.logger.Info().Fields(fields).Msgf("Received StateSyncMetadataResponse: %s", metaDataRes)
-
-	return nil
-}
@@ -2,6 +2,11 @@ package state_sync
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	typesCons "github.com/pokt-network/pocket/consensus/types"
 	"github.com/pokt-network/pocket/shared/codec"
@@ -18,6 +23,9 @@ type StateSyncServerModule interface {
 
 	// Send the block being requested by the peer
 	HandleGetBlockRequest(*typesCons.GetBlockRequest) error
+
+	// Send a chunk of a snapshot of the local state so the peer can bootstrap from it rather than replaying every block
+	HandleSnapshotChunkRequest(*typesCons.SnapshotChunkRequest) error
 }
 
 func (m *stateSync) HandleStateSyncMetadataRequest(metadataReq *typesCons.StateSyncMetadataRequest) error {
@@ -101,6 +109,192 @@ func (m *stateSync) HandleGetBlockRequest(blockReq *typesCons.GetBlockRequest) e
 	return m.SendStateSyncMessage(&stateSyncMessage, cryptoPocket.AddressFromString(clientPeerAddress), blockReq.Height)
 }
 
+func (m *stateSync) HandleSnapshotChunkRequest(chunkReq *typesCons.SnapshotChunkRequest) error {
+	consensusMod := m.GetBus().GetConsensusModule()
+	serverNodePeerAddress := consensusMod.GetNodeAddress()
+	clientPeerAddress := chunkReq.PeerAddress
+	// current height is the height of the block that is being processed, so we need to subtract 1 for the last finalized block
+	lastPersistedBlockHeight := consensusMod.CurrentHeight() - 1
+
+	fields := map[string]any{
+		"height":   lastPersistedBlockHeight,
+		"sender":   serverNodePeerAddress,
+		"receiver": clientPeerAddress,
+	}
+
+	m.logger.Info().Fields(fields).Msgf("Received StateSync SnapshotChunkRequest: %s", chunkReq)
+
+	// Snapshots are never created while handling a request, only served if they were already created
+	snapshotsDir := m.getSnapshotsDir()
+	snapshotHeight := chunkReq.Height
+	if snapshotHeight == 0 {
+		snapshotHeights, err := listSnapshotHeights(snapshotsDir)
+		if err != nil {
+			return err
+		}
+		if len(snapshotHeights) == 0 {
+			return fmt.Errorf("no snapshot is available")
+		}
+		snapshotHeight = snapshotHeights[len(snapshotHeights)-1]
+	}
+	snapshotPath := getSnapshotPath(snapshotsDir, snapshotHeight)
+
+	snapshotFile, err := os.Open(snapshotPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("snapshot at height: %d is not available", snapshotHeight)
+	} else if err != nil {
+		return err
+	}
+	defer snapshotFile.Close()
+
+	snapshotInfo, err := snapshotFile.Stat()
+	if err != nil {
+		return err
+	}
+	numChunks := uint32((snapshotInfo.Size() + snapshotChunkSize - 1) / snapshotChunkSize)
+	if chunkReq.ChunkIndex >= numChunks {
+		return fmt.Errorf("requested snapshot chunk: %d is out of range; the snapshot at height %d has %d chunks", chunkReq.ChunkIndex, snapshotHeight, numChunks)
+	}
+
+	chunk := make([]byte, snapshotChunkSize)
+	n, err := snapshotFile.ReadAt(chunk, int64(chunkReq.ChunkIndex)*snapshotChunkSize)
+	if err != nil && n == 0 {
+		return err
+	}
+
+	stateSyncMessage := typesCons.StateSyncMessage{
+		Message: &typesCons.StateSyncMessage_SnapshotChunkRes{
+			SnapshotChunkRes: &typesCons.SnapshotChunkResponse{
+				PeerAddress: serverNodePeerAddress,
+				Height:      snapshotHeight,
+				ChunkIndex:  chunkReq.ChunkIndex,
+				NumChunks:   numChunks,
+				Chunk:       chunk[:n],
+			},
+		},
+	}
+
+	return m.SendStateSyncMessage(&stateSyncMessage, cryptoPocket.AddressFromString(clientPeerAddress), snapshotHeight)
+}
+
+func (m *stateSync) CreateSnapshot() (height uint64, path string, err error) {
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+
+	return m.createSnapshot()
+}
+
+func (m *stateSync) HandleCommittedBlock(height uint64) {
+	snapshotInterval := m.GetBus().GetRuntimeMgr().GetConfig().Persistence.GetSnapshotInterval()
+	if !m.serverMode || snapshotInterval == 0 || height%snapshotInterval != 0 {
+		return
+	}
+
+	go func() {
+		// A snapshot that is still being created when the next one is due is enough to serve peers in the meantime
+		if !m.snapshotMu.TryLock() {
+			m.logger.Warn().Uint64("height", height).Msg("Skipping snapshot since the previous one is still being created")
+			return
+		}
+		defer m.snapshotMu.Unlock()
+
+		if _, _, err := m.createSnapshot(); err != nil {
+			m.logger.Error().Err(err).Uint64("height", height).Msg("Failed to create snapshot")
+		}
+	}()
+}
+
+// createSnapshot creates a snapshot of the state at the latest committed height and removes the snapshots that are
+// no longer kept. It must be called while holding `snapshotMu`.
+func (m *stateSync) createSnapshot() (height uint64, path string, err error) {
+	snapshotsDir := m.getSnapshotsDir()
+	if err := os.MkdirAll(snapshotsDir, os.ModePerm); err != nil {
+		return 0, "", err
+	}
+
+	// The snapshot is written to a temporary file first so a partially written snapshot is never served
+	tmpFile, err := os.CreateTemp(snapshotsDir, "snapshot_*.tmp")
+	if err != nil {
+		return 0, "", err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	height, err = m.GetBus().GetPersistenceModule().CreateSnapshot(tmpFile)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	path = getSnapshotPath(snapshotsDir, height)
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return 0, "", err
+	}
+
+	snapshotsToKeep := int(m.GetBus().GetRuntimeMgr().GetConfig().Persistence.GetSnapshotsToKeep())
+	if snapshotsToKeep == 0 {
+		snapshotsToKeep = defaultSnapshotsToKeep
+	}
+	if err := pruneSnapshots(snapshotsDir, snapshotsToKeep); err != nil {
+		m.logger.Warn().Err(err).Msg("Failed to remove old snapshots")
+	}
+	return height, path, nil
+}
+
+func (m *stateSync) getSnapshotsDir() string {
+	snapshotsDir := m.GetBus().GetRuntimeMgr().GetConfig().Persistence.GetSnapshotsDir()
+	if snapshotsDir == "" {
+		snapshotsDir = filepath.Join(os.TempDir(), defaultSnapshotsDirName)
+	}
+	return snapshotsDir
+}
+
+func getSnapshotPath(snapshotsDir string, height uint64) string {
+	return filepath.Join(snapshotsDir, fmt.Sprintf("%s%d", snapshotFilePrefix, height))
+}
+
+// Returns the heights of the snapshots in `snapshotsDir` in ascending order
+func listSnapshotHeights(snapshotsDir string) ([]uint64, error) {
+	entries, err := os.ReadDir(snapshotsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	heights := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		heightStr := strings.TrimPrefix(entry.Name(), snapshotFilePrefix)
+		if entry.IsDir() || heightStr == entry.Name() {
+			continue
+		}
+		// Skips the snapshots that are still being written, among others
+		height, err := strconv.ParseUint(heightStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights, nil
+}
+
+// Removes all the snapshots in `snapshotsDir` but the `snapshotsToKeep` most recent ones
+func pruneSnapshots(snapshotsDir string, snapshotsToKeep int) error {
+	heights, err := listSnapshotHeights(snapshotsDir)
+	if err != nil {
+		return err
+	}
+	for len(heights) > snapshotsToKeep {
+		if err := os.Remove(getSnapshotPath(snapshotsDir, heights[0])); err != nil {
+			return err
+		}
+		heights = heights[1:]
+	}
+	return nil
+}
+
 // Get a block from persistence module given block height
 func (m *stateSync) getBlockAtHeight(blockHeight uint64) (*coreTypes.Block, error) {
 	blockStore := m.GetBus().GetPersistenceModule().GetBlockStore()
@@ -0,0 +1,35 @@
+package state_sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneSnapshots(t *testing.T) {
+	snapshotsDir := t.TempDir()
+
+	heights, err := listSnapshotHeights(filepath.Join(snapshotsDir, "missing"))
+	require.NoError(t, err)
+	require.Empty(t, heights, "a missing directory has no snapshots")
+
+	for _, height := range []uint64{20, 3, 10, 100} {
+		require.NoError(t, os.WriteFile(getSnapshotPath(snapshotsDir, height), []byte("snapshot"), 0o600))
+	}
+	// Snapshots that are still being written and unrelated files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotsDir, "snapshot_123.tmp"), []byte("partial"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(snapshotsDir, "notes"), []byte("notes"), 0o600))
+
+	heights, err = listSnapshotHeights(snapshotsDir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 10, 20, 100}, heights)
+
+	require.NoError(t, pruneSnapshots(snapshotsDir, 2))
+	heights, err = listSnapshotHeights(snapshotsDir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{20, 100}, heights)
+	require.FileExists(t, filepath.Join(snapshotsDir, "snapshot_123.tmp"))
+	require.FileExists(t, filepath.Join(snapshotsDir, "notes"))
+}
@@ -31,6 +31,13 @@ func (m *consensusModule) HandleStateSyncMessage(stateSyncMessageAny *anypb.Any)
 	}
 }
 
+func (m *consensusModule) HandleStateSyncTick() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.stateSync.HandleSyncTick()
+}
+
 func (m *consensusModule) handleStateSyncMessage(stateSyncMessage *typesCons.StateSyncMessage) error {
 	switch stateSyncMessage.Message.(type) {
 	case *typesCons.StateSyncMessage_MetadataReq:
@@ -49,6 +56,16 @@ func (m *consensusModule) handleStateSyncMessage(stateSyncMessage *typesCons.Sta
 		return m.stateSync.HandleGetBlockRequest(stateSyncMessage.GetGetBlockReq())
 	case *typesCons.StateSyncMessage_GetBlockRes:
 		return m.stateSync.HandleGetBlockResponse(stateSyncMessage.GetGetBlockRes())
+	case *typesCons.StateSyncMessage_SnapshotChunkReq:
+		m.logger.Info().Str("proto_type", "SnapshotChunkRequest").Msg("Handling StateSyncMessage SnapshotChunkReq")
+		if !m.stateSync.IsServerModEnabled() {
+			return fmt.Errorf("server module is not enabled")
+		}
+		return m.stateSync.HandleSnapshotChunkRequest(stateSyncMessage.GetSnapshotChunkReq())
+	case *typesCons.StateSyncMessage_SnapshotChunkRes:
+		// State sync clients only sync blocks; a node bootstraps from a snapshot with `pocket snapshot restore`
+		m.logger.Debug().Str("proto_type", "SnapshotChunkResponse").Msg("Discarding StateSyncMessage SnapshotChunkRes")
+		return nil
 	default:
 		return fmt.Errorf("unspecified state sync message type")
 	}
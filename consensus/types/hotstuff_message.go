@@ -0,0 +1,44 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/pokt-network/pocket/shared/codec"
+)
+
+// SignableBytes returns the bytes the partial signature of a vote is computed over, i.e. a subset of its fields.
+// For reference, see section 4.3 of the the hotstuff whitepaper, partial signatures are
+// computed over `tsignr(hm.type, m.viewNumber , m.nodei)`. https://arxiv.org/pdf/1803.05069.pdf
+func (x *HotstuffMessage) SignableBytes() ([]byte, error) {
+	msgToSign := &HotstuffMessage{
+		Height: x.GetHeight(),
+		Step:   x.GetStep(),
+		Round:  x.GetRound(),
+		Block:  x.GetBlock(),
+	}
+	return codec.GetCodec().Marshal(msgToSign)
+}
+
+// ConflictsWith returns true if both messages are votes signed by the same validator at the same height, round and
+// step for different blocks, which is evidence of the validator double signing
+func (x *HotstuffMessage) ConflictsWith(other *HotstuffMessage) bool {
+	if x.GetType() != HotstuffMessageType_HOTSTUFF_MESSAGE_VOTE || other.GetType() != HotstuffMessageType_HOTSTUFF_MESSAGE_VOTE {
+		return false
+	}
+	partialSig, otherPartialSig := x.GetPartialSignature(), other.GetPartialSignature()
+	if partialSig == nil || otherPartialSig == nil || partialSig.GetAddress() != otherPartialSig.GetAddress() {
+		return false
+	}
+	if x.GetHeight() != other.GetHeight() || x.GetRound() != other.GetRound() || x.GetStep() != other.GetStep() {
+		return false
+	}
+	signableBytes, err := x.SignableBytes()
+	if err != nil {
+		return false
+	}
+	otherSignableBytes, err := other.SignableBytes()
+	if err != nil {
+		return false
+	}
+	return !bytes.Equal(signableBytes, otherSignableBytes)
+}
@@ -171,6 +171,10 @@ const (
 	nilLeaderIdError                            = "attempting to send a message to leader when LeaderId is nil"
 	newPersistenceReadContextError              = "error creating new persistence read context"
 	persistenceGetAllValidatorsError            = "error getting all validators from persistence"
+	noEligiblePeerError                         = "no eligible peer found to request the block from"
+	syncedBlockQCMismatchError                  = "the QC of the synced block does not match the block"
+	doubleSignVoteError                         = "the vote conflicts with another vote of the same validator"
+	submitDoubleSignEvidenceError               = "error submitting the evidence of a validator double signing"
 )
 
 var (
@@ -210,6 +214,9 @@ var (
 	ErrNilLeaderId                            = errors.New(nilLeaderIdError)
 	ErrNewPersistenceReadContext              = errors.New(newPersistenceReadContextError)
 	ErrPersistenceGetAllValidators            = errors.New(persistenceGetAllValidatorsError)
+	ErrNoEligiblePeer                         = errors.New(noEligiblePeerError)
+	ErrDoubleSignVote                         = errors.New(doubleSignVoteError)
+	ErrSubmitDoubleSignEvidence               = errors.New(submitDoubleSignEvidenceError)
 )
 
 func ErrInvalidBlockSize(blockSize, maxSize uint64) error {
@@ -261,6 +268,10 @@ func ErrQCInvalid(step HotstuffStep) error {
 	return fmt.Errorf("invalid QC in step %s", StepToString[step])
 }
 
+func ErrSyncedBlockQCMismatch(blockHeight, qcHeight uint64) error {
+	return fmt.Errorf("%s: block height %d; QC height %d", syncedBlockQCMismatchError, blockHeight, qcHeight)
+}
+
 func ErrLeaderElection(msg *HotstuffMessage) error {
 	return fmt.Errorf("leader election failed: Validator cannot take part in consensus at height %d round %d", msg.Height, msg.Round)
 }
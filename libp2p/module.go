@@ -39,6 +39,7 @@ import (
 	typesP2P "github.com/pokt-network/pocket/p2p/types"
 	"github.com/pokt-network/pocket/runtime/configs"
 	"github.com/pokt-network/pocket/runtime/configs/types"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/messaging"
 	"github.com/pokt-network/pocket/shared/modules"
@@ -242,6 +243,11 @@ func (mod *libp2pModule) GetAddress() (crypto.Address, error) {
 	return privateKey.Address(), nil
 }
 
+// GetNetworkInfo implements the respective `modules.P2PModule` interface method.
+func (mod *libp2pModule) GetNetworkInfo() *coreTypes.P2PNetworkInfo {
+	return typesP2P.GetNetworkInfo(mod.network)
+}
+
 // HandleEvent implements the respective `modules.Module` interface method.
 func (mod *libp2pModule) HandleEvent(msg *anypb.Any) error {
 	return nil
@@ -142,6 +142,10 @@ func (p2pNet *libp2pNetwork) GetAddrBook() typesP2P.AddrBook {
 	return addrBook
 }
 
+func (p2pNet *libp2pNetwork) GetRainTreeLevels() uint32 {
+	return 0
+}
+
 func (p2pNet *libp2pNetwork) AddPeerToAddrBook(peer *typesP2P.NetworkPeer) error {
 	p2pNet.addrBookMap[peer.Address.String()] = peer
 
@@ -78,10 +78,7 @@ func (*loggerModule) Create(bus modules.Bus, options ...modules.ModuleOption) (m
 	Global.config = m.config
 	Global.CreateLoggerForModule("global")
 
-	// Mapping config string value to the proto enum
-	if pocketLogLevel, ok := configs.LogLevel_value[`LOG_LEVEL_`+strings.ToUpper(Global.config.GetLevel())]; ok {
-		zerolog.SetGlobalLevel(pocketLogLevelToZeroLog[configs.LogLevel(pocketLogLevel)])
-	} else {
+	if err := Global.SetLogLevel(Global.config.GetLevel()); err != nil {
 		zerolog.SetGlobalLevel(zerolog.NoLevel)
 	}
 
@@ -111,6 +108,22 @@ func (m *loggerModule) GetLogger() modules.Logger {
 	return m.Logger
 }
 
+// SetLogLevel implements the respective `modules.LoggerModule` interface member.
+func (*loggerModule) SetLogLevel(level string) error {
+	// Mapping config string value to the proto enum
+	pocketLogLevel, ok := configs.LogLevel_value[`LOG_LEVEL_`+strings.ToUpper(level)]
+	if !ok {
+		return fmt.Errorf("unknown log level: %s", level)
+	}
+	zerolog.SetGlobalLevel(pocketLogLevelToZeroLog[configs.LogLevel(pocketLogLevel)])
+	return nil
+}
+
+// GetLogLevel implements the respective `modules.LoggerModule` interface member.
+func (*loggerModule) GetLogLevel() string {
+	return zerolog.GlobalLevel().String()
+}
+
 // INVESTIGATE(#420): https://github.com/pokt-network/pocket/issues/480
 // SetFields sets the fields for the global logger
 func (m *loggerModule) SetFields(fields map[string]any) {
@@ -217,6 +217,10 @@ func (n *rainTreeNetwork) GetAddrBook() typesP2P.AddrBook {
 	return n.peersManager.getNetworkView().addrBook
 }
 
+func (n *rainTreeNetwork) GetRainTreeLevels() uint32 {
+	return n.peersManager.getNetworkView().maxNumLevels
+}
+
 func (n *rainTreeNetwork) AddPeerToAddrBook(peer *typesP2P.NetworkPeer) error {
 	n.peersManager.wg.Add(1)
 	n.peersManager.eventCh <- addressBookEvent{addToAddressBook, peer}
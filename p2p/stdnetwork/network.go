@@ -79,6 +79,10 @@ func (n *network) GetAddrBook() typesP2P.AddrBook {
 	return addrBook
 }
 
+func (n *network) GetRainTreeLevels() uint32 {
+	return 0
+}
+
 func (n *network) AddPeerToAddrBook(peer *typesP2P.NetworkPeer) error {
 	n.addrBookMap[peer.Address.String()] = peer
 	return nil
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"sort"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
 // AddrBook is a way of representing NetworkPeer sets
 type AddrBook []*NetworkPeer
 
@@ -7,3 +13,27 @@ type AddrBook []*NetworkPeer
 //
 // Since maps cannot be sorted arbitrarily in Go, to achieve sorting, we need to rely on `addrList` which is a slice of addresses/strings and therefore we can sort it the way we want.
 type AddrBookMap map[string]*NetworkPeer
+
+// GetNetworkInfo returns the peers in the address book of the network, sorted by address, along with its RainTree levels
+func GetNetworkInfo(network Network) *coreTypes.P2PNetworkInfo {
+	addrBook := network.GetAddrBook()
+	peers := make([]*coreTypes.Peer, 0, len(addrBook))
+	for _, networkPeer := range addrBook {
+		peer := &coreTypes.Peer{
+			Address:    networkPeer.Address.String(),
+			ServiceUrl: networkPeer.ServiceURL,
+		}
+		if networkPeer.PublicKey != nil {
+			peer.PublicKey = networkPeer.PublicKey.String()
+		}
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Address < peers[j].Address
+	})
+
+	return &coreTypes.P2PNetworkInfo{
+		Peers:          peers,
+		RaintreeLevels: network.GetRainTreeLevels(),
+	}
+}
@@ -20,6 +20,9 @@ type Network interface {
 	AddPeerToAddrBook(peer *NetworkPeer) error
 	RemovePeerFromAddrBook(peer *NetworkPeer) error
 
+	// Returns the number of RainTree levels of the address book, or 0 if the implementation does not use RainTree
+	GetRainTreeLevels() uint32
+
 	// This function was added to specifically support the RainTree implementation.
 	// Handles the raw data received from the network and returns the data to be processed
 	// by the application layer.
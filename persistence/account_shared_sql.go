@@ -14,6 +14,9 @@ const (
 )
 
 func (p *PostgresContext) getAccountAmount(accountSchema types.ProtocolAccountSchema, identifier string, height int64) (amount string, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return "", err
+	}
 	ctx, tx := p.getCtxAndTx()
 	amount = defaultAccountAmountStr
 	if err = tx.QueryRow(ctx, accountSchema.GetAccountAmountQuery(identifier, height)).Scan(&amount); err != pgx.ErrNoRows {
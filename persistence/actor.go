@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"fmt"
+
 	"github.com/pokt-network/pocket/persistence/types"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 )
@@ -9,6 +11,9 @@ import (
 // can easily be refactored and condensed into a single function using a generic type or a common
 // interface.
 func (p *PostgresContext) GetAllApps(height int64) (apps []*coreTypes.Actor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.ApplicationActor.GetAllQuery(height))
 	if err != nil {
@@ -35,6 +40,9 @@ func (p *PostgresContext) GetAllApps(height int64) (apps []*coreTypes.Actor, err
 }
 
 func (p *PostgresContext) GetAllValidators(height int64) (vals []*coreTypes.Actor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.ValidatorActor.GetAllQuery(height))
 	if err != nil {
@@ -62,6 +70,9 @@ func (p *PostgresContext) GetAllValidators(height int64) (vals []*coreTypes.Acto
 }
 
 func (p *PostgresContext) GetAllServicers(height int64) (sn []*coreTypes.Actor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.ServicerActor.GetAllQuery(height))
 	if err != nil {
@@ -88,6 +99,9 @@ func (p *PostgresContext) GetAllServicers(height int64) (sn []*coreTypes.Actor,
 }
 
 func (p *PostgresContext) GetAllFishermen(height int64) (f []*coreTypes.Actor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.FishermanActor.GetAllQuery(height))
 	if err != nil {
@@ -127,3 +141,15 @@ func (p *PostgresContext) GetAllStakedActors(height int64) (allActors []*coreTyp
 	}
 	return
 }
+
+func (p *PostgresContext) GetActor(actorType coreTypes.ActorType, address []byte, height int64) (*coreTypes.Actor, error) {
+	actorSchema, ok := actorTypeToSchemaName[actorType]
+	if !ok {
+		return nil, fmt.Errorf("no schema found for actor type: %s", actorType)
+	}
+	exists, err := p.GetExists(actorSchema, address, height)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return p.getActor(actorSchema, address, height)
+}
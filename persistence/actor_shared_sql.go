@@ -12,6 +12,9 @@ import (
 )
 
 func (p *PostgresContext) GetExists(actorSchema types.ProtocolActorSchema, address []byte, height int64) (exists bool, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return false, err
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	if err = tx.QueryRow(ctx, actorSchema.GetExistsQuery(hex.EncodeToString(address), height)).Scan(&exists); err != nil {
@@ -57,6 +60,9 @@ func (p *PostgresContext) GetActorsUpdated(actorSchema types.ProtocolActorSchema
 }
 
 func (p *PostgresContext) getActor(actorSchema types.ProtocolActorSchema, address []byte, height int64) (actor *coreTypes.Actor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	actor, height, err = p.getActorFromRow(actorSchema.GetActorType(), tx.QueryRow(ctx, actorSchema.GetQuery(hex.EncodeToString(address), height)))
 	if err != nil {
@@ -154,6 +160,9 @@ func (p *PostgresContext) UpdateActor(actorSchema types.ProtocolActorSchema, act
 }
 
 func (p *PostgresContext) GetActorsReadyToUnstake(actorSchema types.ProtocolActorSchema, height int64) (actors []*moduleTypes.UnstakingActor, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	rows, err := tx.Query(ctx, actorSchema.GetReadyToUnstakeQuery(height))
@@ -173,6 +182,9 @@ func (p *PostgresContext) GetActorsReadyToUnstake(actorSchema types.ProtocolActo
 }
 
 func (p *PostgresContext) GetActorStatus(actorSchema types.ProtocolActorSchema, address []byte, height int64) (int32, error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return int32(coreTypes.StakeStatus_UnknownStatus), err
+	}
 	var unstakingHeight int64
 	ctx, tx := p.getCtxAndTx()
 
@@ -203,6 +215,9 @@ func (p *PostgresContext) SetActorUnstakingHeightAndStatus(actorSchema types.Pro
 }
 
 func (p *PostgresContext) GetActorPauseHeightIfExists(actorSchema types.ProtocolActorSchema, address []byte, height int64) (pausedHeight int64, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return types.DefaultBigInt, err
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	if err := tx.QueryRow(ctx, actorSchema.GetPausedHeightQuery(hex.EncodeToString(address), height)).Scan(&pausedHeight); err != nil {
@@ -247,6 +262,9 @@ func (p *PostgresContext) setActorStakeAmount(actorSchema types.ProtocolActorSch
 }
 
 func (p *PostgresContext) GetActorOutputAddress(actorSchema types.ProtocolActorSchema, operatorAddr []byte, height int64) ([]byte, error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	var outputAddr string
@@ -258,6 +276,9 @@ func (p *PostgresContext) GetActorOutputAddress(actorSchema types.ProtocolActorS
 }
 
 func (p *PostgresContext) getActorStakeAmount(actorSchema types.ProtocolActorSchema, address []byte, height int64) (string, error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return "", err
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	var stakeAmount string
@@ -2,31 +2,76 @@ package persistence
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
 
+	"github.com/pokt-network/pocket/persistence/indexer"
 	"github.com/pokt-network/pocket/persistence/kvstore"
 	"github.com/pokt-network/pocket/persistence/types"
-	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
-	"github.com/pokt-network/pocket/shared/utils"
+	"github.com/pokt-network/pocket/shared/modules"
 )
 
 func (p *persistenceModule) TransactionExists(transactionHash string) (bool, error) {
-	hash, err := hex.DecodeString(transactionHash)
+	txResult, err := p.GetTransactionByHash(transactionHash)
 	if err != nil {
 		return false, err
 	}
-	res, err := p.txIndexer.GetByHash(hash)
-	if res == nil {
-		// check for not found
-		if err != nil && err.Error() == kvstore.BadgerKeyNotFoundError {
-			return false, nil
-		}
-		return false, err
+	return txResult != nil, nil
+}
+
+// GetTransactionByHash resolves both the hash of the transaction result and the hash of the transaction itself, which
+// is the only one known before the transaction is committed (e.g. by the clients that broadcast it)
+func (p *persistenceModule) GetTransactionByHash(transactionHash string) (modules.TxResult, error) {
+	hash, err := hex.DecodeString(transactionHash)
+	if err != nil {
+		return nil, err
 	}
-	return true, err
+	txReader, release := p.newCommittedTxReader()
+	defer release()
+	txResult, err := txReader.GetByHash(hash)
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		txResult, err = txReader.GetByTxHash(hash)
+	}
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, nil
+	}
+	return txResult, err
+}
+
+func (p *persistenceModule) GetTransactionsByHeight(height int64, cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+	txReader, release := p.newCommittedTxReader()
+	defer release()
+	return txReader.GetPageByHeight(height, cursor, limit, descending)
+}
+
+func (p *persistenceModule) GetTransactionsBySender(sender string, cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+	txReader, release := p.newCommittedTxReader()
+	defer release()
+	return txReader.GetPageBySender(sender, cursor, limit, descending)
+}
+
+func (p *persistenceModule) GetTransactionsByRecipient(recipient string, cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+	txReader, release := p.newCommittedTxReader()
+	defer release()
+	return txReader.GetPageByRecipient(recipient, cursor, limit, descending)
+}
+
+func (p *persistenceModule) GetTransactionsByAddress(address string, cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+	txReader, release := p.newCommittedTxReader()
+	defer release()
+	return txReader.GetPageByAddress(address, cursor, limit, descending)
 }
+
+// newCommittedTxReader returns a reader of the transactions of the committed blocks only, since the queries above
+// are served outside of consensus while the transactions of the block being applied are indexed. The reader must be
+// released once it is no longer needed.
+func (p *persistenceModule) newCommittedTxReader() (txReader indexer.TxReader, release func()) {
+	p.stateTrees.committedMu.RLock()
+	defer p.stateTrees.committedMu.RUnlock()
+	return p.txIndexer.NewFlushedReader()
+}
+
 func (p *PostgresContext) GetMinimumBlockHeight() (latestHeight uint64, err error) {
 	ctx, tx := p.getCtxAndTx()
 
@@ -74,8 +119,19 @@ func (p *PostgresContext) prepareBlock(proposerAddr, quorumCert []byte) (*coreTy
 		ProposerAddress:   proposerAddr,
 		QuorumCertificate: quorumCert,
 	}
+	// The transactions are included in the block so it can be replayed by peers synching from this node
+	txResults, err := p.txIndexer.GetByHeight(p.Height, false)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([][]byte, len(txResults))
+	for i, txResult := range txResults {
+		txs[i] = txResult.GetTx()
+	}
+
 	block := &coreTypes.Block{
-		BlockHeader: blockHeader,
+		BlockHeader:  blockHeader,
+		Transactions: txs,
 	}
 
 	return block, nil
@@ -93,13 +149,3 @@ func (p *PostgresContext) insertBlock(block *coreTypes.Block) error {
 	_, err := tx.Exec(ctx, types.InsertBlockQuery(blockHeader.Height, blockHeader.StateHash, blockHeader.ProposerAddress, blockHeader.QuorumCertificate))
 	return err
 }
-
-// Stores the block in the key-value store
-func (p *PostgresContext) storeBlock(block *coreTypes.Block) error {
-	blockBz, err := codec.GetCodec().Marshal(block)
-	if err != nil {
-		return err
-	}
-	log.Printf("Storing block %d in block store.\n", block.BlockHeader.Height)
-	return p.blockStore.Set(utils.HeightToBytes(uint64(p.Height)), blockBz)
-}
@@ -0,0 +1,304 @@
+package persistence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/celestiaorg/smt"
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/jackc/pgx/v5"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/utils"
+)
+
+// A block is committed across several stores: the Postgres database, the block store, the tx indexer and the
+// node & value stores of the state trees. To make the commit atomic, the writes to the key-value stores are
+// buffered in memory while the block is being applied, and the commit follows a write-ahead log protocol:
+//
+//  1. A commit record, containing the block and all the buffered writes, is durably written to the commit log
+//  2. The block is inserted into Postgres and the SQL transaction is committed; this is the point of no return
+//  3. The block is stored, the buffered writes are flushed and the new tree roots are persisted
+//  4. The commit record is deleted
+//
+// On startup, a commit record that is still in the commit log means the node stopped in the middle of a commit.
+// If Postgres contains the block, the commit is completed by replaying the record; otherwise it is discarded.
+
+const (
+	commitLogDirName = "commit_log"
+
+	txIndexerStoreName = "tx_indexer"
+)
+
+var (
+	pendingCommitKey  = []byte("pending_commit")
+	committedRootsKey = []byte("committed_roots")
+)
+
+type commitRecord struct {
+	Height    uint64                       `json:"height"`
+	StateHash string                       `json:"state_hash"`
+	Block     []byte                       `json:"block"`
+	TreeRoots [][]byte                     `json:"tree_roots"` // Ordered by `merkleTree`
+	Writes    map[string][]kvstore.KVWrite `json:"writes"`     // Keyed by the name of the store they were made to
+
+	PrunedHeights []uint64 `json:"pruned_heights,omitempty"` // Heights whose blocks are removed from the block store
+}
+
+type committedRoots struct {
+	Height    uint64   `json:"height"`
+	TreeRoots [][]byte `json:"tree_roots"` // Ordered by `merkleTree`
+}
+
+// The commit log is kept next to the state trees since the roots of the trees are persisted in it
+func initializeCommitLog(treesStoreDir string) (kvstore.KVStore, error) {
+	if treesStoreDir == "" {
+		return kvstore.NewMemKVStore(), nil
+	}
+	return kvstore.NewDurableKVStore(filepath.Join(treesStoreDir, commitLogDirName))
+}
+
+// Returns all the buffered stores written to while applying a block, keyed by name
+func (p *PostgresContext) getBufferedStores() map[string]kvstore.Buffer {
+	buffers := make(map[string]kvstore.Buffer, 2*int(numMerkleTrees)+1)
+	buffers[txIndexerStoreName] = p.txIndexer
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		buffers[merkleTreeToString[tree]+"_nodes"] = p.stateTrees.nodeStores[tree]
+		buffers[merkleTreeToString[tree]+"_values"] = p.stateTrees.valueStores[tree]
+	}
+	return buffers
+}
+
+func (p *PostgresContext) newCommitRecord(block *coreTypes.Block, prunedHeights []uint64) (*commitRecord, error) {
+	blockBz, err := codec.GetCodec().Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &commitRecord{
+		Height:    block.BlockHeader.Height,
+		StateHash: block.BlockHeader.StateHash,
+		Block:     blockBz,
+		TreeRoots: make([][]byte, 0, int(numMerkleTrees)),
+		Writes:    make(map[string][]kvstore.KVWrite),
+
+		PrunedHeights: prunedHeights,
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		record.TreeRoots = append(record.TreeRoots, p.stateTrees.merkleTrees[tree].Root())
+	}
+	for name, buffer := range p.getBufferedStores() {
+		if writes := buffer.PendingWrites(); len(writes) > 0 {
+			record.Writes[name] = writes
+		}
+	}
+	return record, nil
+}
+
+func (p *PostgresContext) logCommitRecord(record *commitRecord) error {
+	recordBz, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return p.commitLog.Set(pendingCommitKey, recordBz)
+}
+
+// applyCommitRecord writes a block that was committed to Postgres to all the key-value stores. It is idempotent
+// so it can be replayed if the node stops before it completes.
+func (p *PostgresContext) applyCommitRecord(record *commitRecord) error {
+	blockStoreBatch := p.blockStore.NewBatch()
+	defer blockStoreBatch.Cancel()
+	if err := blockStoreBatch.Set(utils.HeightToBytes(record.Height), record.Block); err != nil {
+		return err
+	}
+	for _, height := range record.PrunedHeights {
+		if err := blockStoreBatch.Delete(utils.HeightToBytes(height)); err != nil {
+			return err
+		}
+	}
+	if err := blockStoreBatch.Write(); err != nil {
+		return err
+	}
+
+	buffers := p.getBufferedStores()
+	for name, writes := range record.Writes {
+		buffer, ok := buffers[name]
+		if !ok {
+			return fmt.Errorf("unknown store in commit record: %s", name)
+		}
+		// Replace whatever is buffered with the writes of the record, which are the ones that were committed
+		buffer.Discard()
+		buffer.AddPendingWrites(writes)
+	}
+	if err := p.flushCommittedState(record.Height, record.TreeRoots, record.PrunedHeights); err != nil {
+		return err
+	}
+
+	return p.commitLog.Delete(pendingCommitKey)
+}
+
+// flushCommittedState flushes the writes buffered by all the key-value stores and persists the roots of the state
+// trees at the given height, while removing those of the pruned heights. The committed state of the trees is locked
+// throughout so it is never read half-updated.
+func (p *PostgresContext) flushCommittedState(height uint64, roots [][]byte, prunedHeights []uint64) error {
+	p.stateTrees.committedMu.Lock()
+	defer p.stateTrees.committedMu.Unlock()
+
+	for _, buffer := range p.getBufferedStores() {
+		if err := buffer.Flush(); err != nil {
+			return err
+		}
+	}
+	return p.persistCommittedRoots(height, roots, prunedHeights)
+}
+
+// persistCommittedRoots persists the roots of the state trees at the given height so they can be reopened at
+// those roots on startup or read at that height later on, and makes them the roots the trees are reset to when
+// a context is released
+func (p *PostgresContext) persistCommittedRoots(height uint64, roots [][]byte, prunedHeights []uint64) error {
+	rootsBz, err := json.Marshal(&committedRoots{
+		Height:    height,
+		TreeRoots: roots,
+	})
+	if err != nil {
+		return err
+	}
+	batch := p.commitLog.NewBatch()
+	defer batch.Cancel()
+	if err := batch.Set(committedRootsKey, rootsBz); err != nil {
+		return err
+	}
+	if err := batch.Set(treeRootsKey(height), rootsBz); err != nil {
+		return err
+	}
+	for _, prunedHeight := range prunedHeights {
+		if err := batch.Delete(treeRootsKey(prunedHeight)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		p.stateTrees.committedRoots[tree] = roots[int(tree)]
+	}
+	p.stateTrees.committedHeight = height
+	return nil
+}
+
+// discardUncommittedState drops everything that was written to the key-value stores since the last commit
+// and resets the state trees to their last committed roots
+func (p *PostgresContext) discardUncommittedState() {
+	p.resetSavePoints()
+	for _, buffer := range p.getBufferedStores() {
+		buffer.Discard()
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		p.stateTrees.merkleTrees[tree].SetRoot(p.stateTrees.committedRoots[tree])
+	}
+	p.stateHash = ""
+}
+
+// recoverFromCommitLog completes or discards a commit that was interrupted, reopens the state trees at their
+// last committed roots, and verifies they match the state hash of the latest block.
+func (m *persistenceModule) recoverFromCommitLog() error {
+	readCtx, err := m.NewReadContext(-1) // Unknown height
+	if err != nil {
+		return err
+	}
+	p := readCtx.(*PostgresContext)
+	defer p.Close()
+
+	recordBz, err := m.commitLog.Get(pendingCommitKey)
+	if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+		return err
+	}
+	if err == nil {
+		record := new(commitRecord)
+		if err := json.Unmarshal(recordBz, record); err != nil {
+			return err
+		}
+		blockHash, err := p.GetBlockHash(int64(record.Height))
+		switch {
+		case err == nil && blockHash == record.StateHash:
+			m.logger.Warn().Uint64("height", record.Height).Msg("Completing the commit of a block that was interrupted")
+			if err := p.applyCommitRecord(record); err != nil {
+				return err
+			}
+		case err == nil || errors.Is(err, pgx.ErrNoRows):
+			m.logger.Warn().Uint64("height", record.Height).Msg("Discarding the commit of a block that was never committed")
+			if err := m.commitLog.Delete(pendingCommitKey); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+
+	rootsBz, err := m.commitLog.Get(committedRootsKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the committed roots of the state trees: %w", err)
+	}
+	roots := new(committedRoots)
+	if err := json.Unmarshal(rootsBz, roots); err != nil {
+		return err
+	}
+	if len(roots.TreeRoots) != int(numMerkleTrees) {
+		return fmt.Errorf("expected %d committed state tree roots but got %d", int(numMerkleTrees), len(roots.TreeRoots))
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		nodeStore, valueStore := m.stateTrees.nodeStores[tree], m.stateTrees.valueStores[tree]
+		m.stateTrees.merkleTrees[tree] = smt.ImportSparseMerkleTree(nodeStore, valueStore, sha256.New(), roots.TreeRoots[int(tree)])
+		m.stateTrees.committedRoots[tree] = roots.TreeRoots[int(tree)]
+	}
+	m.stateTrees.committedHeight = roots.Height
+
+	return p.verifyLatestBlock(roots.Height)
+}
+
+// verifyLatestBlock verifies that all the stores agree on the latest committed block
+func (p *PostgresContext) verifyLatestBlock(treesHeight uint64) error {
+	height, err := p.GetMaximumBlockHeight()
+	if err != nil {
+		return err
+	}
+	if height != treesHeight {
+		return fmt.Errorf("the state trees were committed at height %d but the latest block is at height %d", treesHeight, height)
+	}
+
+	blockHash, err := p.GetBlockHash(int64(height))
+	if err != nil {
+		return err
+	}
+	if stateHash := p.getStateHash(); stateHash != blockHash {
+		return fmt.Errorf("the state hash %s does not match the hash of the latest block %d: %s", stateHash, height, blockHash)
+	}
+
+	blockBz, err := p.blockStore.Get(utils.HeightToBytes(height))
+	if err != nil {
+		return fmt.Errorf("the latest block %d is missing from the block store: %w", height, err)
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return err
+	}
+	if block.BlockHeader.GetStateHash() != blockHash {
+		return fmt.Errorf("the latest block %d in the block store does not match the database: %s != %s", height, block.BlockHeader.GetStateHash(), blockHash)
+	}
+
+	return nil
+}
+
+// Returns the roots of empty state trees
+func emptyTreeRoots() map[merkleTree][]byte {
+	roots := make(map[merkleTree][]byte, int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		roots[tree] = bytes.Repeat([]byte{0}, sha256.Size)
+	}
+	return roots
+}
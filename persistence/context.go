@@ -4,6 +4,8 @@ package persistence
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/pokt-network/pocket/persistence/indexer"
@@ -13,6 +15,8 @@ import (
 
 var _ modules.PersistenceRWContext = &PostgresContext{}
 
+var errSimulationContext = errors.New("not supported by a simulation context")
+
 // TECHDEBT: All the functions of `PostgresContext` should be organized in appropriate packages and use pointer receivers
 type PostgresContext struct {
 	Height int64 // TECHDEBT: `Height` is only externalized for testing purposes. Replace with a `Debug` interface containing helpers
@@ -21,29 +25,63 @@ type PostgresContext struct {
 
 	stateHash string
 
+	// Save points created within this context, ordered from oldest to newest
+	savePoints []*savePoint
+
 	logger *modules.Logger
 
-	// TECHDEBT(#361): These three values are pointers to objects maintained by the PersistenceModule.
+	// TECHDEBT(#361): These values are pointers to objects maintained by the PersistenceModule.
 	//                 Need to simply access them via the bus.
 	blockStore kvstore.KVStore
 	txIndexer  indexer.TxIndexer
 	stateTrees *stateTrees
+	commitLog  kvstore.KVStore
+
+	pruningPolicy *pruningPolicy
+	// The lowest height whose state is fully retained, looked up once by `GetRetainedHeights`
+	minRetainedHeight *int64
+
+	// Set for the contexts opened by `NewSimulationContext`, which do not have access to the stores above
+	simulation bool
 }
 
+// NewSavePoint creates a save point, identified by `bytes` (e.g. a tx hash), that the context can be rolled back to.
+// It covers the SQL state, the state trees and the transactions indexed within this context.
 func (p *PostgresContext) NewSavePoint(bytes []byte) error {
-	p.logger.Info().Bool("TODO", true).Msg("NewSavePoint not implemented")
+	if p.simulation {
+		return errSimulationContext
+	}
+	sp, err := p.newSavePoint(bytes)
+	if err != nil {
+		return err
+	}
+	p.savePoints = append(p.savePoints, sp)
 	return nil
 }
 
-// TECHDEBT(#327): Guarantee atomicity betweens `prepareBlock`, `insertBlock` and `storeBlock` for save points & rollbacks.
+// RollbackToSavePoint reverts all the changes made since the latest save point identified by `bytes`
+// was created, and discards it along with any save points created after it.
 func (p *PostgresContext) RollbackToSavePoint(bytes []byte) error {
-	p.logger.Info().Bool("TODO", true).Msg("RollbackToSavePoint not fully implemented")
-	return p.getTx().Rollback(context.TODO())
+	if p.simulation {
+		return errSimulationContext
+	}
+	idx := p.findSavePoint(bytes)
+	if idx < 0 {
+		return fmt.Errorf("save point not found: %x", bytes)
+	}
+	if err := p.rollbackToSavePoint(p.savePoints[idx]); err != nil {
+		return err
+	}
+	p.savePoints = p.savePoints[:idx]
+	return nil
 }
 
 // IMPROVE(#361): Guarantee the integrity of the state
 // Full details in the thread from the PR review: https://github.com/pokt-network/pocket/pull/285#discussion_r1018471719
 func (p *PostgresContext) ComputeStateHash() (string, error) {
+	if p.simulation {
+		return "", errSimulationContext
+	}
 	stateHash, err := p.updateMerkleTrees()
 	if err != nil {
 		return "", err
@@ -52,8 +90,11 @@ func (p *PostgresContext) ComputeStateHash() (string, error) {
 	return p.stateHash, nil
 }
 
-// TECHDEBT(#327): Make sure these operations are atomic
+// Commit atomically commits the block across all the stores; see `commit_log.go` for details on the protocol
 func (p *PostgresContext) Commit(proposerAddr, quorumCert []byte) error {
+	if p.simulation {
+		return errSimulationContext
+	}
 	p.logger.Info().Int64("height", p.Height).Msg("About to commit block & context")
 
 	// Create a persistence block proto
@@ -62,21 +103,36 @@ func (p *PostgresContext) Commit(proposerAddr, quorumCert []byte) error {
 		return err
 	}
 
-	// Store block in the KV store
-	if err := p.storeBlock(block); err != nil {
+	// Prune whatever is no longer retained so it is removed atomically along with the rest of the commit
+	prunedHeights, err := p.prune()
+	if err != nil {
 		return err
 	}
 
-	// Insert the block into the SQL DB
-	if err := p.insertBlock(block); err != nil {
+	// Durably log everything that needs to be written to the key-value stores
+	record, err := p.newCommitRecord(block, prunedHeights)
+	if err != nil {
+		return err
+	}
+	if err := p.logCommitRecord(record); err != nil {
 		return err
 	}
 
-	// Commit the SQL transaction
+	// Insert the block into the SQL DB and commit the SQL transaction
 	ctx := context.TODO()
+	if err := p.insertBlock(block); err != nil {
+		return p.abortCommit(err)
+	}
 	if err := p.getTx().Commit(ctx); err != nil {
-		return err
+		return p.abortCommit(err)
+	}
+
+	// The block is committed from this point on, so any failure below is repaired on the next startup
+	if err := p.applyCommitRecord(record); err != nil {
+		p.logger.Fatal().Err(err).Int64("height", p.Height).Msg("Failed to write a committed block to the key-value stores")
 	}
+	p.resetSavePoints()
+
 	if err := p.conn.Close(ctx); err != nil {
 		p.logger.Error().Err(err).Bool("TODO", true).Msg("Error when closing DB connection")
 	}
@@ -84,6 +140,14 @@ func (p *PostgresContext) Commit(proposerAddr, quorumCert []byte) error {
 	return nil
 }
 
+// abortCommit removes the commit record of a block that failed to be committed to the SQL DB
+func (p *PostgresContext) abortCommit(commitErr error) error {
+	if err := p.commitLog.Delete(pendingCommitKey); err != nil {
+		p.logger.Error().Err(err).Int64("height", p.Height).Msg("Failed to remove the commit record of an aborted commit")
+	}
+	return commitErr
+}
+
 func (p *PostgresContext) Release() error {
 	p.logger.Info().Int64("height", p.Height).Msg("About to release context")
 	ctx := context.TODO()
@@ -102,6 +166,9 @@ func (p *PostgresContext) Close() error {
 
 // INVESTIGATE(#361): Revisit if is used correctly in the context of the lifecycle of a persistenceContext and a utilityContext
 func (p *PostgresContext) IndexTransaction(txResult modules.TxResult) error {
+	if p.simulation {
+		return errSimulationContext
+	}
 	return p.txIndexer.Index(txResult)
 }
 
@@ -110,6 +177,11 @@ func (p *PostgresContext) resetContext() (err error) {
 		return nil
 	}
 
+	// A simulation context has no uncommitted state outside of its SQL transaction
+	if !p.simulation {
+		p.discardUncommittedState()
+	}
+
 	tx := p.getTx()
 	if p.tx == nil {
 		return nil
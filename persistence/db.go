@@ -168,6 +168,12 @@ func initializeAccountTables(ctx context.Context, db *pgx.Conn) error {
 	if _, err := db.Exec(ctx, fmt.Sprintf(`%s %s %s %s`, CreateTable, IfNotExists, types.PoolTableName, types.Pool.GetTableSchema())); err != nil {
 		return err
 	}
+	if _, err := db.Exec(ctx, fmt.Sprintf(`%s %s %s %s`, CreateTable, IfNotExists, types.ReportCardTableName, types.ReportCardTableSchema)); err != nil {
+		return err
+	}
+	if _, err := db.Exec(ctx, fmt.Sprintf(`%s %s %s %s`, CreateTable, IfNotExists, types.DoubleSignEvidenceTableName, types.DoubleSignEvidenceTableSchema)); err != nil {
+		return err
+	}
 	return nil
 }
 
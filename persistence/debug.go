@@ -18,6 +18,8 @@ var nonActorClearFunctions = []func() string{
 	types.Pool.ClearAllAccounts,
 	types.ClearAllGovParamsQuery,
 	types.ClearAllGovFlagsQuery,
+	types.ClearAllReportCardsQuery,
+	types.ClearAllDoubleSignEvidenceQuery,
 	types.ClearAllBlocksQuery,
 }
 
@@ -89,6 +91,11 @@ func (m *persistenceModule) clearAllState(_ *messaging.DebugMessage) error {
 		return err
 	}
 
+	// Clear the commit log, which includes the committed roots of the trees
+	if err := m.commitLog.ClearAll(); err != nil {
+		return err
+	}
+
 	m.logger.Info().Msg("Cleared all the state")
 	// reclaming memory manually because the above calls deallocate and reallocate a lot of memory
 	debug.FreeOSMemory()
@@ -135,8 +142,24 @@ func (p *PostgresContext) clearAllTreeState() error {
 		}
 
 		// Needed in order to make sure the root is re-set correctly after clearing
-		p.stateTrees.merkleTrees[treeType] = smt.NewSparseMerkleTree(valueStore, nodeStore, sha256.New())
+		p.stateTrees.merkleTrees[treeType] = smt.NewSparseMerkleTree(nodeStore, valueStore, sha256.New())
 	}
+	p.stateTrees.committedMu.Lock()
+	defer p.stateTrees.committedMu.Unlock()
+	p.stateTrees.committedRoots = emptyTreeRoots()
+	p.stateTrees.committedHeight = 0
 
-	return nil
+	// The roots of the previous heights are gone along with the nodes of the trees
+	rootKeys, _, err := p.commitLog.GetAll(treeRootsKeyPrefix, false)
+	if err != nil {
+		return err
+	}
+	batch := p.commitLog.NewBatch()
+	defer batch.Cancel()
+	for _, key := range rootKeys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
 }
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/pokt-network/pocket/persistence/types"
+)
+
+// --- Double Sign Evidence Functions ---
+
+func (p *PostgresContext) GetDoubleSignEvidenceExists(validatorAddr []byte, voteHeight, voteRound uint64, voteStep int32, height int64) (exists bool, err error) {
+	ctx, tx := p.getCtxAndTx()
+	err = tx.QueryRow(ctx, types.DoubleSignEvidenceExistsQuery(hex.EncodeToString(validatorAddr), voteHeight, voteRound, voteStep, height)).Scan(&exists)
+	return
+}
+
+func (p *PostgresContext) SetDoubleSignEvidence(validatorAddr []byte, voteHeight, voteRound uint64, voteStep int32) error {
+	ctx, tx := p.getCtxAndTx()
+	_, err := tx.Exec(ctx, types.InsertDoubleSignEvidenceQuery(hex.EncodeToString(validatorAddr), voteHeight, voteRound, voteStep, p.Height))
+	return err
+}
+
+// getDoubleSignEvidenceKeysUpdated returns the state tree keys of the double sign evidence handled at the height
+func (p *PostgresContext) getDoubleSignEvidenceKeysUpdated(height int64) ([][]byte, error) {
+	ctx, tx := p.getCtxAndTx()
+	rows, err := tx.Query(ctx, types.GetDoubleSignEvidenceAtHeightQuery(height))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys [][]byte
+	for rows.Next() {
+		var (
+			addrHex               string
+			voteHeight, voteRound uint64
+			voteStep              int32
+		)
+		if err := rows.Scan(&addrHex, &voteHeight, &voteRound, &voteStep); err != nil {
+			return nil, err
+		}
+		addr, err := hex.DecodeString(addrHex)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, doubleSignEvidenceKey(addr, voteHeight, voteRound, voteStep))
+	}
+	return keys, rows.Err()
+}
+
+// doubleSignEvidenceKey is the address of the validator followed by the big endian height, round & step of its votes
+func doubleSignEvidenceKey(validatorAddr []byte, voteHeight, voteRound uint64, voteStep int32) []byte {
+	key := make([]byte, len(validatorAddr)+20)
+	n := copy(key, validatorAddr)
+	binary.BigEndian.PutUint64(key[n:], voteHeight)
+	binary.BigEndian.PutUint64(key[n+8:], voteRound)
+	binary.BigEndian.PutUint32(key[n+16:], uint32(voteStep))
+	return key
+}
@@ -137,6 +137,9 @@ func (m *persistenceModule) populateGenesisState(state *genesis.GenesisState) {
 //		can easily be refactored and condensed into a single function using a generic type or a common
 //	 interface.
 func (p *PostgresContext) GetAllAccounts(height int64) (accs []*coreTypes.Account, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.Account.GetAllQuery(height))
 	if err != nil {
@@ -154,6 +157,9 @@ func (p *PostgresContext) GetAllAccounts(height int64) (accs []*coreTypes.Accoun
 
 // CLEANUP: Consolidate with GetAllAccounts.
 func (p *PostgresContext) GetAllPools(height int64) (accs []*coreTypes.Account, err error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
 	ctx, tx := p.getCtxAndTx()
 	rows, err := tx.Query(ctx, types.Pool.GetAllQuery(height))
 	if err != nil {
@@ -143,6 +143,9 @@ func setParamOrFlag[T types.SupportedParamTypes](p *PostgresContext, paramName s
 }
 
 func getParamOrFlag[T int | string | []byte](p *PostgresContext, tableName, paramName string, height int64) (i T, enabled bool, err error) {
+	if err = p.ensureHeightRetained(height); err != nil {
+		return
+	}
 	ctx, tx := p.getCtxAndTx()
 
 	var stringVal string
@@ -3,7 +3,9 @@
 package indexer
 
 import (
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/pokt-network/pocket/shared/codec"
@@ -18,13 +20,37 @@ import (
 
 // `TxIndexer` interface defines methods to index and query transactions.
 type TxIndexer interface {
+	TxReader
+
 	// `Index` analyzes, indexes and stores a single transaction result.
-	// `Index` indexes by `(hash, height, sender, recipient)`
+	// `Index` indexes by `(hash, txHash, height, sender, recipient)`
 	Index(result shared.TxResult) error
 
+	// `PruneHeight` removes all the transactions indexed at the given height
+	PruneHeight(height int64) error
+
+	// `NewFlushedReader` returns a reader of the flushed transactions only (i.e. those of the committed blocks) as of
+	// this point in time, which is safe to use while other transactions are being indexed. `release` must always be called.
+	NewFlushedReader() (reader TxReader, release func())
+
+	// Close stops the underlying db connection
+	Close() error
+
+	// Transactions are only persisted once the buffer is flushed (i.e. when the block is committed), and the
+	// journal allows the transactions indexed since a checkpoint to be reverted (e.g. when rolling back to a save point)
+	kvstore.Journal
+	kvstore.Buffer
+}
+
+// `TxReader` interface defines methods to query indexed transactions.
+type TxReader interface {
 	// `GetByHash` returns the transaction specified by the hash if indexed or nil otherwise
 	GetByHash(hash []byte) (shared.TxResult, error)
 
+	// `GetByTxHash` returns the transaction whose proto bytes have the hash (i.e. `coreTypes.TxHash`) if indexed or nil
+	// otherwise. Unlike the hash of the result, it is known as soon as the transaction is signed.
+	GetByTxHash(txHash []byte) (shared.TxResult, error)
+
 	// `GetByHeight` returns all transactions specified by height or nil if there are no transactions at that height; may be ordered descending/ascending
 	GetByHeight(height int64, descending bool) ([]shared.TxResult, error)
 
@@ -34,21 +60,42 @@ type TxIndexer interface {
 	// GetByRecipient returns all transactions *sent to address*; may be ordered descending/ascending
 	GetByRecipient(recipient string, descending bool) ([]shared.TxResult, error)
 
-	// Close stops the underlying db connection
-	Close() error
+	// The paginated queries return up to `limit` transactions ordered by (height, index), starting at the `cursor`
+	// (empty for the first page), along with the cursor of the next page, which is empty once there are no more
+	// transactions.
+
+	// `GetPageByHeight` returns a page of the transactions at the given height
+	GetPageByHeight(height int64, cursor string, limit int, descending bool) (txResults []shared.TxResult, nextCursor string, err error)
+
+	// `GetPageBySender` returns a page of the transactions signed by *sender*
+	GetPageBySender(sender string, cursor string, limit int, descending bool) (txResults []shared.TxResult, nextCursor string, err error)
+
+	// `GetPageByRecipient` returns a page of the transactions *sent to address*
+	GetPageByRecipient(recipient string, cursor string, limit int, descending bool) (txResults []shared.TxResult, nextCursor string, err error)
+
+	// `GetPageByAddress` returns a page of the transactions either signed by or sent to *address* (i.e. its history)
+	GetPageByAddress(address string, cursor string, limit int, descending bool) (txResults []shared.TxResult, nextCursor string, err error)
 }
 
 // Implementation
-var _ TxIndexer = &txIndexer{}
+var (
+	_ TxIndexer = &txIndexer{}
+	_ TxReader  = &txReader{}
+)
 var _ shared.TxResult = &TxRes{}
 
 const (
 	hashPrefix      = 'h'
+	txHashPrefix    = 't'
 	heightPrefix    = 'b' // b for block
 	senderPrefix    = 's'
 	recipientPrefix = 'r'
 )
 
+// The cursors of the paginated queries are the position of a transaction (i.e. `height/txIndex`, see `positionKey`),
+// which is the suffix of all the keys indexing it by height, sender & recipient
+var ErrInvalidCursor = errors.New("invalid transaction cursor")
+
 // =,- are the default parameters in the [example repository](https://github.com/jordanorelli/lexnum#example)
 // INVESTIGATE: We can research to see if there are more optimal parameters
 var elenEncoder = lexnum.NewEncoder('=', '-')
@@ -78,7 +125,21 @@ func (x *TxRes) HashFromBytes(bz []byte) ([]byte, error) {
 }
 
 type txIndexer struct {
-	db kvstore.KVStore
+	txReader
+	db kvstore.BufferedKVStore
+}
+
+// txReader queries the transactions indexed in a store, which is either the buffered store of the indexer or a
+// snapshot of it
+type txReader struct {
+	store kvstore.KVReader
+}
+
+func newTxIndexer(db kvstore.BufferedKVStore) *txIndexer {
+	return &txIndexer{
+		txReader: txReader{store: db},
+		db:       db,
+	}
 }
 
 func NewTxIndexer(databasePath string) (TxIndexer, error) {
@@ -87,15 +148,19 @@ func NewTxIndexer(databasePath string) (TxIndexer, error) {
 	}
 
 	db, err := kvstore.NewKVStore(databasePath)
-	return &txIndexer{
-		db: db,
-	}, err
+	if err != nil {
+		return nil, err
+	}
+	return newTxIndexer(kvstore.NewBufferedKVStore(db)), nil
+}
+
+// NewTxIndexerWithStore returns a `TxIndexer` backed by the given store, which is buffered until it is flushed
+func NewTxIndexerWithStore(store kvstore.KVStore) TxIndexer {
+	return newTxIndexer(kvstore.NewBufferedKVStore(store))
 }
 
 func NewMemTxIndexer() (TxIndexer, error) {
-	return &txIndexer{
-		db: kvstore.NewMemKVStore(),
-	}, nil
+	return newTxIndexer(kvstore.NewBufferedKVStore(kvstore.NewMemKVStore())), nil
 }
 
 func (indexer *txIndexer) Index(result shared.TxResult) error {
@@ -107,62 +172,268 @@ func (indexer *txIndexer) Index(result shared.TxResult) error {
 	if err != nil {
 		return err
 	}
-	hashKey, err := indexer.indexByHash(hash, bz)
+	// All the keys of a transaction are written in a single batch so it is never partially indexed
+	batch := indexer.db.NewBatch()
+	defer batch.Cancel()
+	hashKey, err := indexer.indexByHash(batch, hash, bz)
 	if err != nil {
 		return err
 	}
-	if err := indexer.indexByHeightAndIndex(result.GetHeight(), result.GetIndex(), hashKey); err != nil {
+	if err := indexer.indexByTxHash(batch, crypto.SHA3Hash(result.GetTx()), hashKey); err != nil {
 		return err
 	}
-	if err := indexer.indexBySender(result.GetSignerAddr(), hashKey); err != nil {
+	position := positionKey(result.GetHeight(), result.GetIndex())
+	if err := indexer.indexByHeightAndIndex(batch, position, hashKey); err != nil {
 		return err
 	}
-	if err := indexer.indexByRecipient(result.GetRecipientAddr(), hashKey); err != nil {
+	if err := indexer.indexBySender(batch, result.GetSignerAddr(), position, hashKey); err != nil {
 		return err
 	}
-	return nil
+	if err := indexer.indexByRecipient(batch, result.GetRecipientAddr(), position, hashKey); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (reader *txReader) GetByHash(hash []byte) (shared.TxResult, error) {
+	return reader.get(reader.hashKey(hash))
+}
+
+func (reader *txReader) GetByTxHash(txHash []byte) (shared.TxResult, error) {
+	hashKey, err := reader.store.Get(reader.txHashKey(txHash))
+	if err != nil {
+		return nil, err
+	}
+	return reader.get(hashKey)
+}
+
+func (reader *txReader) GetByHeight(height int64, descending bool) ([]shared.TxResult, error) {
+	return reader.getAll(reader.heightKey(height), descending)
+}
+
+func (reader *txReader) GetBySender(sender string, descending bool) ([]shared.TxResult, error) {
+	return reader.getAll(reader.senderKey(sender), descending)
+}
+
+func (reader *txReader) GetByRecipient(recipient string, descending bool) ([]shared.TxResult, error) {
+	return reader.getAll(reader.recipientKey(recipient), descending)
 }
 
-func (indexer *txIndexer) GetByHash(hash []byte) (shared.TxResult, error) {
-	return indexer.get(indexer.hashKey(hash))
+func (reader *txReader) GetPageByHeight(height int64, cursor string, limit int, descending bool) ([]shared.TxResult, string, error) {
+	return reader.getPage(reader.key(heightPrefix, ""), reader.heightKey(height), cursor, limit, descending)
 }
 
-func (indexer *txIndexer) GetByHeight(height int64, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.heightKey(height), descending)
+func (reader *txReader) GetPageBySender(sender string, cursor string, limit int, descending bool) ([]shared.TxResult, string, error) {
+	prefix := reader.senderKey(sender)
+	return reader.getPage(prefix, prefix, cursor, limit, descending)
+}
+
+func (reader *txReader) GetPageByRecipient(recipient string, cursor string, limit int, descending bool) ([]shared.TxResult, string, error) {
+	prefix := reader.recipientKey(recipient)
+	return reader.getPage(prefix, prefix, cursor, limit, descending)
+}
+
+// The transactions signed by & sent to the address are both ordered by position, so they are merged into a single
+// page. A transaction that an address sends to itself is at the same position in both and only returned once.
+func (reader *txReader) GetPageByAddress(address string, cursor string, limit int, descending bool) ([]shared.TxResult, string, error) {
+	if limit <= 0 {
+		return nil, "", kvstore.ErrInvalidPageLimit
+	}
+	// Either of them may make up the entire page, followed by the position of the next cursor
+	sentPrefix := reader.senderKey(address)
+	sent, _, err := reader.getPositions(sentPrefix, sentPrefix, cursor, limit+1, descending)
+	if err != nil {
+		return nil, "", err
+	}
+	receivedPrefix := reader.recipientKey(address)
+	received, _, err := reader.getPositions(receivedPrefix, receivedPrefix, cursor, limit+1, descending)
+	if err != nil {
+		return nil, "", err
+	}
+
+	before := func(a, b string) bool {
+		if descending {
+			return a > b
+		}
+		return a < b
+	}
+	var positions []txPosition
+	for len(sent) > 0 || len(received) > 0 {
+		var next txPosition
+		switch {
+		case len(received) == 0 || (len(sent) > 0 && before(sent[0].position, received[0].position)):
+			next, sent = sent[0], sent[1:]
+		case len(sent) == 0 || before(received[0].position, sent[0].position):
+			next, received = received[0], received[1:]
+		default:
+			next, sent, received = sent[0], sent[1:], received[1:]
+		}
+		positions = append(positions, next)
+	}
+
+	var nextCursor string
+	if len(positions) > limit {
+		nextCursor = positions[limit].position
+		positions = positions[:limit]
+	}
+	txResults, err := reader.getAtPositions(positions)
+	if err != nil {
+		return nil, "", err
+	}
+	return txResults, nextCursor, nil
 }
 
-func (indexer *txIndexer) GetBySender(sender string, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.senderKey(sender), descending)
+func (indexer *txIndexer) PruneHeight(height int64) error {
+	heightKeys, hashKeys, err := indexer.db.GetAll(indexer.heightKey(height), false)
+	if err != nil {
+		return err
+	}
+	batch := indexer.db.NewBatch()
+	defer batch.Cancel()
+	for i, hashKey := range hashKeys {
+		txResult, err := indexer.get(hashKey)
+		if err != nil {
+			return err
+		}
+		position := positionKey(txResult.GetHeight(), txResult.GetIndex())
+		if err := batch.Delete(indexer.senderPositionKey(txResult.GetSignerAddr(), position)); err != nil {
+			return err
+		}
+		if recipient := txResult.GetRecipientAddr(); recipient != "" {
+			if err := batch.Delete(indexer.recipientPositionKey(recipient, position)); err != nil {
+				return err
+			}
+		}
+		if err := batch.Delete(indexer.txHashKey(crypto.SHA3Hash(txResult.GetTx()))); err != nil {
+			return err
+		}
+		if err := batch.Delete(hashKey); err != nil {
+			return err
+		}
+		if err := batch.Delete(heightKeys[i]); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
 }
 
-func (indexer *txIndexer) GetByRecipient(recipient string, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.recipientKey(recipient), descending)
+func (indexer *txIndexer) NewFlushedReader() (TxReader, func()) {
+	snapshot := indexer.db.NewFlushedReadSnapshot()
+	return &txReader{store: snapshot}, snapshot.Release
 }
 
 func (indexer *txIndexer) Close() error {
 	return indexer.db.Stop()
 }
 
+func (indexer *txIndexer) Checkpoint() int {
+	return indexer.db.Checkpoint()
+}
+
+func (indexer *txIndexer) RevertTo(checkpoint int) error {
+	return indexer.db.RevertTo(checkpoint)
+}
+
+func (indexer *txIndexer) ResetJournal() {
+	indexer.db.ResetJournal()
+}
+
+func (indexer *txIndexer) PendingWrites() []kvstore.KVWrite {
+	return indexer.db.PendingWrites()
+}
+
+func (indexer *txIndexer) Flush() error {
+	return indexer.db.Flush()
+}
+
+func (indexer *txIndexer) Discard() {
+	indexer.db.Discard()
+}
+
+func (indexer *txIndexer) AddPendingWrites(writes []kvstore.KVWrite) {
+	indexer.db.AddPendingWrites(writes)
+}
+
 // kv helper functions
 
-func (indexer *txIndexer) getAll(prefix []byte, descending bool) (result []shared.TxResult, err error) {
-	_, hashKeys, err := indexer.db.GetAll(prefix, descending)
+func (reader *txReader) getAll(prefix []byte, descending bool) (result []shared.TxResult, err error) {
+	// The hash keys are streamed so only the transactions, and not the entire index, are loaded into memory
+	it, err := reader.store.Iterator(prefix, nil, nil, descending)
 	if err != nil {
 		return nil, err
 	}
-	for _, hashKey := range hashKeys {
+	defer it.Close()
+
+	for it.Next() {
 		var txResult shared.TxResult
-		txResult, err = indexer.get(hashKey)
+		txResult, err = reader.get(it.Value())
 		if err != nil {
 			return
 		}
 		result = append(result, txResult)
 	}
-	return
+	return result, it.Error()
 }
 
-func (indexer *txIndexer) get(key []byte) (shared.TxResult, error) {
-	bz, err := indexer.db.Get(key)
+// txPosition is the position of an indexed transaction along with the key of its hash
+type txPosition struct {
+	position string
+	hashKey  []byte
+}
+
+// getPositions reads a page of the positions indexed with the given prefix. The keys are made of `keyBase` followed
+// by the position, so the cursor is only valid if the key it corresponds to has the prefix.
+func (reader *txReader) getPositions(keyBase, prefix []byte, cursor string, limit int, descending bool) (positions []txPosition, nextCursor string, err error) {
+	var cursorKey []byte
+	if cursor != "" {
+		cursorKey = append(append([]byte(nil), keyBase...), cursor...)
+		if !bytes.HasPrefix(cursorKey, prefix) {
+			return nil, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+		}
+	}
+	keys, hashKeys, nextCursorKey, err := kvstore.ReadPage(reader.store, prefix, cursorKey, limit, descending)
+	if err != nil {
+		return nil, "", err
+	}
+	positions = make([]txPosition, 0, len(keys))
+	for i, key := range keys {
+		positions = append(positions, txPosition{
+			position: string(key[len(keyBase):]),
+			hashKey:  hashKeys[i],
+		})
+	}
+	if nextCursorKey != nil {
+		nextCursor = string(nextCursorKey[len(keyBase):])
+	}
+	return positions, nextCursor, nil
+}
+
+func (reader *txReader) getPage(keyBase, prefix []byte, cursor string, limit int, descending bool) ([]shared.TxResult, string, error) {
+	positions, nextCursor, err := reader.getPositions(keyBase, prefix, cursor, limit, descending)
+	if err != nil {
+		return nil, "", err
+	}
+	txResults, err := reader.getAtPositions(positions)
+	if err != nil {
+		return nil, "", err
+	}
+	return txResults, nextCursor, nil
+}
+
+func (reader *txReader) getAtPositions(positions []txPosition) ([]shared.TxResult, error) {
+	txResults := make([]shared.TxResult, 0, len(positions))
+	for _, position := range positions {
+		txResult, err := reader.get(position.hashKey)
+		if err != nil {
+			return nil, err
+		}
+		txResults = append(txResults, txResult)
+	}
+	return txResults, nil
+}
+
+func (reader *txReader) get(key []byte) (shared.TxResult, error) {
+	bz, err := reader.store.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -171,48 +442,65 @@ func (indexer *txIndexer) get(key []byte) (shared.TxResult, error) {
 
 // index helper functions
 
-func (indexer *txIndexer) indexByHash(hash, bz []byte) (hashKey []byte, err error) {
+func (indexer *txIndexer) indexByHash(batch kvstore.Batch, hash, bz []byte) (hashKey []byte, err error) {
 	key := indexer.hashKey(hash)
-	return key, indexer.db.Set(key, bz)
+	return key, batch.Set(key, bz)
+}
+
+func (indexer *txIndexer) indexByTxHash(batch kvstore.Batch, txHash, bz []byte) error {
+	return batch.Set(indexer.txHashKey(txHash), bz)
 }
 
-func (indexer *txIndexer) indexByHeightAndIndex(height int64, index int32, bz []byte) error {
-	return indexer.db.Set(indexer.heightAndIndexKey(height, index), bz)
+func (indexer *txIndexer) indexByHeightAndIndex(batch kvstore.Batch, position string, bz []byte) error {
+	return batch.Set(indexer.key(heightPrefix, position), bz)
 }
 
-func (indexer *txIndexer) indexBySender(sender string, bz []byte) error {
-	return indexer.db.Set(indexer.senderKey(sender), bz)
+func (indexer *txIndexer) indexBySender(batch kvstore.Batch, sender, position string, bz []byte) error {
+	return batch.Set(indexer.senderPositionKey(sender, position), bz)
 }
 
-func (indexer *txIndexer) indexByRecipient(recipient string, bz []byte) error {
+func (indexer *txIndexer) indexByRecipient(batch kvstore.Batch, recipient, position string, bz []byte) error {
 	if recipient == "" {
 		return nil
 	}
-	return indexer.db.Set(indexer.recipientKey(recipient), bz)
+	return batch.Set(indexer.recipientPositionKey(recipient, position), bz)
 }
 
 // key helper functions
 
-func (indexer *txIndexer) hashKey(hash []byte) []byte {
-	return indexer.key(hashPrefix, hex.EncodeToString(hash))
+func (reader *txReader) hashKey(hash []byte) []byte {
+	return reader.key(hashPrefix, hex.EncodeToString(hash))
+}
+
+func (reader *txReader) txHashKey(txHash []byte) []byte {
+	return reader.key(txHashPrefix, hex.EncodeToString(txHash))
+}
+
+// positionKey orders the transactions by height, then by index within the block
+func positionKey(height int64, index int32) string {
+	return elenEncoder.EncodeInt(int(height)) + "/" + elenEncoder.EncodeInt(int(index))
+}
+
+func (reader *txReader) heightKey(height int64) []byte {
+	return reader.key(heightPrefix, elenEncoder.EncodeInt(int(height))+"/")
 }
 
-func (indexer *txIndexer) heightAndIndexKey(height int64, index int32) []byte {
-	return append(indexer.heightKey(height), []byte(elenEncoder.EncodeInt(int(index)))...)
+func (reader *txReader) senderKey(address string) []byte {
+	return reader.key(senderPrefix, address+"/")
 }
 
-func (indexer *txIndexer) heightKey(height int64) []byte {
-	return indexer.key(heightPrefix, elenEncoder.EncodeInt(int(height))+"/")
+func (reader *txReader) senderPositionKey(address, position string) []byte {
+	return reader.key(senderPrefix, address+"/"+position)
 }
 
-func (indexer *txIndexer) senderKey(address string) []byte {
-	return indexer.key(senderPrefix, address)
+func (reader *txReader) recipientKey(address string) []byte {
+	return reader.key(recipientPrefix, address+"/")
 }
 
-func (indexer *txIndexer) recipientKey(address string) []byte {
-	return indexer.key(recipientPrefix, address)
+func (reader *txReader) recipientPositionKey(address, position string) []byte {
+	return reader.key(recipientPrefix, address+"/"+position)
 }
 
-func (indexer *txIndexer) key(prefix rune, postfix string) []byte {
+func (reader *txReader) key(prefix rune, postfix string) []byte {
 	return []byte(fmt.Sprintf("%s/%s", string(prefix), postfix))
 }
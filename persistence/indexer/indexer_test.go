@@ -114,6 +114,31 @@ func TestGetByHash(t *testing.T) {
 	requireTxResultsEqual(t, txResult2, txResultFromHash2)
 }
 
+func TestGetByTxHash(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index a transaction
+	txResult := NewTestingTransactionResult(t, 0, 0)
+	err = txIndexer.Index(txResult)
+	require.NoError(t, err)
+	// the hash of the transaction resolves its result
+	txHash := crypto.SHA3Hash(txResult.GetTx())
+	txResultFromTxHash, err := txIndexer.GetByTxHash(txHash)
+	require.NoError(t, err)
+	requireTxResultsEqual(t, txResult, txResultFromTxHash)
+	// the hash of the result is not the hash of the transaction
+	hash, err := txResult.Hash()
+	require.NoError(t, err)
+	_, err = txIndexer.GetByTxHash(hash)
+	require.Error(t, err)
+	// pruning the height removes the transaction hash too
+	err = txIndexer.PruneHeight(0)
+	require.NoError(t, err)
+	_, err = txIndexer.GetByTxHash(txHash)
+	require.Error(t, err)
+}
+
 func TestGetByHeight(t *testing.T) {
 	txIndexer, err := NewMemTxIndexer()
 	defer closeIndexer(t, txIndexer)
@@ -186,6 +211,232 @@ func TestGetByRecipient(t *testing.T) {
 	require.Equal(t, 0, len(txResultsFromSenderBad))
 }
 
+func TestGetBySender_History(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index 3 transactions signed by the same sender, the last 2 within the same block
+	sender := randomAddress(t)
+	txResults := make([]shared.TxResult, 0, 3)
+	for _, position := range [][2]int{{1, 0}, {2, 0}, {2, 1}} {
+		txResult := NewTestingTransactionResult(t, position[0], position[1]).(*TxRes)
+		txResult.SignerAddr = sender
+		require.NoError(t, txIndexer.Index(txResult))
+		txResults = append(txResults, txResult)
+	}
+	// all of them are part of the sender's history, ordered by height and index
+	txResultsFromSender, err := txIndexer.GetBySender(sender, false)
+	require.NoError(t, err)
+	require.Len(t, txResultsFromSender, 3)
+	for i := range txResults {
+		requireTxResultsEqual(t, txResults[i], txResultsFromSender[i])
+	}
+	txResultsFromSender, err = txIndexer.GetBySender(sender, true)
+	require.NoError(t, err)
+	require.Len(t, txResultsFromSender, 3)
+	requireTxResultsEqual(t, txResults[2], txResultsFromSender[0])
+}
+
+func TestGetPageByHeight(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index 5 transactions at height 1 & one at height 2
+	txResults := make([]shared.TxResult, 0, 5)
+	for index := 0; index < 5; index++ {
+		txResult := NewTestingTransactionResult(t, 1, index)
+		require.NoError(t, txIndexer.Index(txResult))
+		txResults = append(txResults, txResult)
+	}
+	require.NoError(t, txIndexer.Index(NewTestingTransactionResult(t, 2, 0)))
+
+	// page through height 1 in both orders
+	for _, descending := range []bool{false, true} {
+		var pagedTxResults []shared.TxResult
+		cursor, numPages := "", 0
+		for {
+			page, nextCursor, err := txIndexer.GetPageByHeight(1, cursor, 2, descending)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), 2)
+			pagedTxResults = append(pagedTxResults, page...)
+			numPages++
+			if nextCursor == "" {
+				break
+			}
+			cursor = nextCursor
+		}
+		require.Equal(t, 3, numPages)
+		require.Len(t, pagedTxResults, 5)
+		for i := range txResults {
+			expected := txResults[i]
+			if descending {
+				expected = txResults[len(txResults)-1-i]
+			}
+			requireTxResultsEqual(t, expected, pagedTxResults[i])
+		}
+	}
+
+	// the cursor of a page at another height is rejected
+	_, nextCursor, err := txIndexer.GetPageByHeight(1, "", 2, false)
+	require.NoError(t, err)
+	_, _, err = txIndexer.GetPageByHeight(2, nextCursor, 2, false)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestGetPageByAddress(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	address := randomAddress(t)
+	// the address sends a transaction, receives one, sends one to itself & receives one in the same block
+	sent := NewTestingTransactionResult(t, 1, 0).(*TxRes)
+	sent.SignerAddr = address
+	received := NewTestingTransactionResult(t, 2, 0).(*TxRes)
+	received.RecipientAddr = address
+	toSelf := NewTestingTransactionResult(t, 3, 0).(*TxRes)
+	toSelf.SignerAddr, toSelf.RecipientAddr = address, address
+	receivedAfter := NewTestingTransactionResult(t, 3, 1).(*TxRes)
+	receivedAfter.RecipientAddr = address
+	history := []shared.TxResult{sent, received, toSelf, receivedAfter}
+	for _, txResult := range history {
+		require.NoError(t, txIndexer.Index(txResult))
+	}
+	// some transactions unrelated to the address
+	for index := 2; index < 4; index++ {
+		require.NoError(t, txIndexer.Index(NewTestingTransactionResult(t, 3, index)))
+	}
+
+	page, nextCursor, err := txIndexer.GetPageByAddress(address, "", 3, false)
+	require.NoError(t, err)
+	require.Len(t, page, 3)
+	for i := range page {
+		requireTxResultsEqual(t, history[i], page[i])
+	}
+	require.NotEmpty(t, nextCursor)
+	page, nextCursor, err = txIndexer.GetPageByAddress(address, nextCursor, 3, false)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	requireTxResultsEqual(t, receivedAfter, page[0])
+	require.Empty(t, nextCursor)
+
+	// the latest transactions come first in descending order
+	page, nextCursor, err = txIndexer.GetPageByAddress(address, "", 2, true)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	requireTxResultsEqual(t, receivedAfter, page[0])
+	requireTxResultsEqual(t, toSelf, page[1])
+	page, nextCursor, err = txIndexer.GetPageByAddress(address, nextCursor, 2, true)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	requireTxResultsEqual(t, received, page[0])
+	requireTxResultsEqual(t, sent, page[1])
+	require.Empty(t, nextCursor)
+}
+
+func TestRevertTo(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index a transaction before the checkpoint
+	txResult := NewTestingTransactionResult(t, 0, 0)
+	err = txIndexer.Index(txResult)
+	require.NoError(t, err)
+	checkpoint := txIndexer.Checkpoint()
+	// index a transaction after the checkpoint
+	txResult2 := NewTestingTransactionResult(t, 0, 1)
+	err = txIndexer.Index(txResult2)
+	require.NoError(t, err)
+	txResults, err := txIndexer.GetByHeight(0, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 2)
+	// revert the transaction indexed after the checkpoint
+	err = txIndexer.RevertTo(checkpoint)
+	require.NoError(t, err)
+	hash2, err := txResult2.Hash()
+	require.NoError(t, err)
+	_, err = txIndexer.GetByHash(hash2)
+	require.Error(t, err)
+	txResults, err = txIndexer.GetByHeight(0, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 1)
+	requireTxResultsEqual(t, txResult, txResults[0])
+}
+
+func TestNewFlushedReader(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index a transaction without flushing it
+	txResult := NewTestingTransactionResult(t, 0, 0)
+	err = txIndexer.Index(txResult)
+	require.NoError(t, err)
+	hash, err := txResult.Hash()
+	require.NoError(t, err)
+	// the pending transaction is only visible through the indexer
+	_, err = txIndexer.GetByHash(hash)
+	require.NoError(t, err)
+	reader, release := txIndexer.NewFlushedReader()
+	_, err = reader.GetByHash(hash)
+	require.Error(t, err)
+	txResults, err := reader.GetByHeight(0, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 0)
+	// the reader is a snapshot, so it is not affected by the flush either
+	err = txIndexer.Flush()
+	require.NoError(t, err)
+	_, err = reader.GetByHash(hash)
+	require.Error(t, err)
+	release()
+	// a new reader sees the flushed transaction
+	reader, release = txIndexer.NewFlushedReader()
+	defer release()
+	txResultFromHash, err := reader.GetByHash(hash)
+	require.NoError(t, err)
+	requireTxResultsEqual(t, txResult, txResultFromHash)
+	page, nextCursor, err := reader.GetPageByAddress(txResult.GetSignerAddr(), "", 10, false)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Empty(t, nextCursor)
+}
+
+func TestPruneHeight(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer closeIndexer(t, txIndexer)
+	// index a transaction at height 0 and another one, by the same sender, at height 1
+	txResult := NewTestingTransactionResult(t, 0, 0)
+	err = txIndexer.Index(txResult)
+	require.NoError(t, err)
+	txResult2 := NewTestingTransactionResult(t, 1, 0).(*TxRes)
+	txResult2.SignerAddr = txResult.GetSignerAddr()
+	err = txIndexer.Index(txResult2)
+	require.NoError(t, err)
+	txResult3 := NewTestingTransactionResult(t, 1, 1)
+	err = txIndexer.Index(txResult3)
+	require.NoError(t, err)
+	// prune height 0
+	err = txIndexer.PruneHeight(0)
+	require.NoError(t, err)
+	hash, err := txResult.Hash()
+	require.NoError(t, err)
+	_, err = txIndexer.GetByHash(hash)
+	require.Error(t, err)
+	txResults, err := txIndexer.GetByHeight(0, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 0)
+	txResults, err = txIndexer.GetByRecipient(txResult.GetRecipientAddr(), false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 0)
+	// the transactions at height 1 are untouched, including the sender's latest transaction
+	txResults, err = txIndexer.GetByHeight(1, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 2)
+	txResults, err = txIndexer.GetBySender(txResult.GetSignerAddr(), false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 1)
+	requireTxResultsEqual(t, txResult2, txResults[0])
+}
+
 func requireTxResultsEqual(t *testing.T, txR1, txR2 shared.TxResult) {
 	bz, err := txR1.Bytes()
 	require.NoError(t, err)
@@ -0,0 +1,121 @@
+package kvstore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	badger "github.com/dgraph-io/badger/v3"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+const (
+	BadgerBackend  = "badger"
+	PebbleBackend  = "pebble"
+	LevelDBBackend = "goleveldb"
+	MapBackend     = "map" // In-memory only; the path of the store is ignored and nothing survives a restart
+
+	DefaultBackend = BadgerBackend
+)
+
+// ErrKeyNotFound is returned by every backend when getting a key that does not exist
+var ErrKeyNotFound = badger.ErrKeyNotFound
+
+// BackendOptions configure how a backend opens a store
+type BackendOptions struct {
+	// SyncWrites syncs every write to disk before it returns
+	SyncWrites bool
+	// Badger tunes the stores opened by the Badger backend; the defaults are used if nil
+	Badger *configs.BadgerConfig
+}
+
+// BackendConstructor opens a store at `path`, or an in-memory store if `path` is empty
+type BackendConstructor func(path string, opts BackendOptions) (KVStore, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendConstructor{
+		BadgerBackend:  newBadgerBackendStore,
+		PebbleBackend:  newPebbleKVStore,
+		LevelDBBackend: newLevelDBKVStore,
+		MapBackend:     newMapBackendStore,
+	}
+)
+
+// RegisterBackend makes a backend available to `OpenKVStore` under the given name, replacing any backend
+// previously registered under it
+func RegisterBackend(name string, constructor BackendConstructor) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = constructor
+}
+
+// Backends returns the names of all the registered backends, sorted
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenKVStore opens a store at `path` (or in memory if empty) with the given backend, which defaults to Badger
+func OpenKVStore(backend, path string, opts BackendOptions) (KVStore, error) {
+	if backend == "" {
+		backend = DefaultBackend
+	}
+	backendsMu.RLock()
+	constructor, ok := backends[backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key-value store backend %q; available backends: %v", backend, Backends())
+	}
+	return constructor(path, opts)
+}
+
+func newBadgerBackendStore(path string, opts BackendOptions) (KVStore, error) {
+	badgerOpts := badgerOptions(path).WithSyncWrites(opts.SyncWrites)
+	if path == "" {
+		badgerOpts = badgerOpts.WithInMemory(true)
+	}
+	db, err := badger.Open(applyBadgerConfig(badgerOpts, opts.Badger))
+	if err != nil {
+		return nil, err
+	}
+	return &badgerKVStore{db: db}, nil
+}
+
+func newMapBackendStore(_ string, _ BackendOptions) (KVStore, error) {
+	return NewMapKVStore(), nil
+}
+
+func applyBadgerConfig(opts badger.Options, cfg *configs.BadgerConfig) badger.Options {
+	if cfg == nil {
+		return opts
+	}
+	if cfg.MemTableSize > 0 {
+		opts = opts.WithMemTableSize(cfg.MemTableSize)
+	}
+	if cfg.ValueLogFileSize > 0 {
+		opts = opts.WithValueLogFileSize(cfg.ValueLogFileSize)
+	}
+	if cfg.ValueThreshold > 0 {
+		opts = opts.WithValueThreshold(cfg.ValueThreshold)
+	}
+	if cfg.BlockCacheSize > 0 {
+		opts = opts.WithBlockCacheSize(cfg.BlockCacheSize)
+	}
+	if cfg.IndexCacheSize > 0 {
+		opts = opts.WithIndexCacheSize(cfg.IndexCacheSize)
+	}
+	if cfg.NumCompactors > 0 {
+		opts = opts.WithNumCompactors(int(cfg.NumCompactors))
+	}
+	if cfg.NumVersionsToKeep > 0 {
+		opts = opts.WithNumVersionsToKeep(int(cfg.NumVersionsToKeep))
+	}
+	return opts
+}
@@ -0,0 +1,89 @@
+package kvstore
+
+import (
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// Batch groups writes so they are applied to a store all at once rather than one transaction per write
+type Batch interface {
+	Set(key, value []byte) error
+	Delete(key []byte) error
+	// Write applies all the writes of the batch to the store. The batch cannot be reused afterwards.
+	Write() error
+	// Cancel discards the writes of the batch. It is a no-op once the batch is written, so it can be deferred.
+	Cancel()
+}
+
+// KVReader provides read access to the key-value pairs of a store
+type KVReader interface {
+	Get(key []byte) ([]byte, error)
+	Exists(key []byte) (bool, error)
+	Iterator(prefix, start, end []byte, reverse bool) (Iterator, error)
+}
+
+// ReadSnapshot is a read-only, point-in-time view of a store that is not affected by the writes made after it
+// was created
+type ReadSnapshot interface {
+	KVReader
+	// Release frees the resources held by the snapshot. It must always be called.
+	Release()
+}
+
+var (
+	_ Batch        = &badgerBatch{}
+	_ ReadSnapshot = &badgerReadSnapshot{}
+)
+
+type badgerBatch struct {
+	wb   *badger.WriteBatch
+	done bool
+}
+
+func (batch *badgerBatch) Set(key, value []byte) error {
+	return batch.wb.Set(key, value)
+}
+
+func (batch *badgerBatch) Delete(key []byte) error {
+	return batch.wb.Delete(key)
+}
+
+func (batch *badgerBatch) Write() error {
+	batch.done = true
+	return batch.wb.Flush()
+}
+
+func (batch *badgerBatch) Cancel() {
+	if batch.done {
+		return
+	}
+	batch.done = true
+	batch.wb.Cancel()
+}
+
+type badgerReadSnapshot struct {
+	txn *badger.Txn
+}
+
+func (snapshot *badgerReadSnapshot) Get(key []byte) ([]byte, error) {
+	item, err := snapshot.txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (snapshot *badgerReadSnapshot) Exists(key []byte) (bool, error) {
+	val, err := snapshot.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (snapshot *badgerReadSnapshot) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newBadgerIterator(snapshot.txn, false, prefix, start, end, reverse), nil
+}
+
+func (snapshot *badgerReadSnapshot) Release() {
+	snapshot.txn.Discard()
+}
@@ -0,0 +1,450 @@
+package kvstore
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// Journal keeps track of the writes made to a store so they can be reverted back to a checkpoint
+type Journal interface {
+	// Checkpoint starts journaling writes (if it was not already doing so) and returns a checkpoint
+	// that the store can be reverted to via `RevertTo`
+	Checkpoint() int
+	// RevertTo undoes all the writes made since the given checkpoint
+	RevertTo(checkpoint int) error
+	// ResetJournal discards the journal and stops journaling writes until the next checkpoint
+	ResetJournal()
+}
+
+// Buffer keeps the writes made to a store in memory until they are flushed to the underlying store
+type Buffer interface {
+	// PendingWrites returns the writes that have not been flushed yet, ordered by key
+	PendingWrites() []KVWrite
+	// Flush writes all the pending writes to the underlying store
+	Flush() error
+	// Discard drops all the pending writes
+	Discard()
+	// AddPendingWrites adds writes that were previously returned by `PendingWrites` back to the buffer
+	// (e.g. when replaying them after a crash)
+	AddPendingWrites(writes []KVWrite)
+}
+
+// BufferedKVStore is a `KVStore` whose writes are only persisted once they are flushed. Reads see the pending
+// writes, and the pending writes made since a checkpoint can be reverted without touching the underlying store.
+// It is safe for concurrent use, e.g. by a writer applying a block while other goroutines read the flushed writes.
+type BufferedKVStore interface {
+	KVStore
+	Journal
+	Buffer
+
+	// NewFlushedReadSnapshot returns a view of the underlying store at this point in time, i.e. of the writes that
+	// were flushed, ignoring the pending writes
+	NewFlushedReadSnapshot() ReadSnapshot
+}
+
+// KVWrite is a single write made to a `KVStore`
+type KVWrite struct {
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+var _ BufferedKVStore = &bufferedKVStore{}
+
+// journalEntry captures the pending write of a key before it was overwritten; `write` is nil if there was none
+type journalEntry struct {
+	key   string
+	write *KVWrite
+}
+
+type bufferedKVStore struct {
+	store KVStore
+
+	// mu guards the pending writes & the journal
+	mu      sync.RWMutex
+	pending map[string]*KVWrite
+
+	journaling bool
+	journal    []journalEntry
+}
+
+func NewBufferedKVStore(store KVStore) BufferedKVStore {
+	return &bufferedKVStore{
+		store:   store,
+		pending: make(map[string]*KVWrite),
+	}
+}
+
+func (store *bufferedKVStore) Set(key, value []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.write(&KVWrite{
+		Key:   append([]byte(nil), key...),
+		Value: append([]byte(nil), value...),
+	})
+	return nil
+}
+
+func (store *bufferedKVStore) Delete(key []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.write(&KVWrite{
+		Key:     append([]byte(nil), key...),
+		Deleted: true,
+	})
+	return nil
+}
+
+func (store *bufferedKVStore) Get(key []byte) ([]byte, error) {
+	// The lock is held while reading the underlying store so a concurrent flush is either fully seen or not at all
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	if write, ok := store.pending[string(key)]; ok {
+		if write.Deleted {
+			return nil, badger.ErrKeyNotFound
+		}
+		return append([]byte(nil), write.Value...), nil
+	}
+	return store.store.Get(key)
+}
+
+func (store *bufferedKVStore) Exists(key []byte) (bool, error) {
+	val, err := store.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+// Iterator merges the pending writes in the range into the iteration over the underlying store
+func (store *bufferedKVStore) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	it, err := store.store.Iterator(prefix, start, end, reverse)
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedIterator(it, store.pending, prefix, start, end, reverse), nil
+}
+
+func (store *bufferedKVStore) GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	return ReadPage(store, prefix, cursor, limit, descending)
+}
+
+func (store *bufferedKVStore) GetAll(prefix []byte, descending bool) (keys, values [][]byte, err error) {
+	return ReadAll(store, prefix, descending)
+}
+
+// NewBatch returns a batch whose writes are added to the pending writes, and journaled, when it is written
+func (store *bufferedKVStore) NewBatch() Batch {
+	return &bufferedBatch{store: store}
+}
+
+// NewReadSnapshot returns a view of the pending writes and the underlying store at this point in time
+func (store *bufferedKVStore) NewReadSnapshot() ReadSnapshot {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	pending := make(map[string]*KVWrite, len(store.pending))
+	for key, write := range store.pending {
+		pending[key] = write // Pending writes are never mutated, only replaced
+	}
+	return &bufferedReadSnapshot{
+		snapshot: store.store.NewReadSnapshot(),
+		pending:  pending,
+	}
+}
+
+func (store *bufferedKVStore) NewFlushedReadSnapshot() ReadSnapshot {
+	// Flushes hold the write lock, so the snapshot never sees a partially flushed buffer
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.store.NewReadSnapshot()
+}
+
+func (store *bufferedKVStore) ClearAll() error {
+	store.Discard()
+	return store.store.ClearAll()
+}
+
+func (store *bufferedKVStore) Stop() error {
+	return store.store.Stop()
+}
+
+func (store *bufferedKVStore) Checkpoint() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.journaling = true
+	return len(store.journal)
+}
+
+func (store *bufferedKVStore) RevertTo(checkpoint int) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if checkpoint < 0 || checkpoint > len(store.journal) {
+		return errors.New("invalid journal checkpoint")
+	}
+	// Writes are undone in reverse order so every key ends up with the pending write it had at the checkpoint
+	for i := len(store.journal) - 1; i >= checkpoint; i-- {
+		entry := store.journal[i]
+		if entry.write == nil {
+			delete(store.pending, entry.key)
+		} else {
+			store.pending[entry.key] = entry.write
+		}
+	}
+	store.journal = store.journal[:checkpoint]
+	return nil
+}
+
+func (store *bufferedKVStore) ResetJournal() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.resetJournal()
+}
+
+func (store *bufferedKVStore) PendingWrites() []KVWrite {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	writes := make([]KVWrite, 0, len(store.pending))
+	for _, write := range store.pending {
+		writes = append(writes, *write)
+	}
+	sort.Slice(writes, func(i, j int) bool {
+		return bytes.Compare(writes[i].Key, writes[j].Key) < 0
+	})
+	return writes
+}
+
+// Flush writes all the pending writes to the underlying store in a single batch
+func (store *bufferedKVStore) Flush() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	batch := store.store.NewBatch()
+	defer batch.Cancel()
+	for _, write := range store.pending {
+		var err error
+		if write.Deleted {
+			err = batch.Delete(write.Key)
+		} else {
+			err = batch.Set(write.Key, write.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	store.discard()
+	return nil
+}
+
+func (store *bufferedKVStore) Discard() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.discard()
+}
+
+func (store *bufferedKVStore) AddPendingWrites(writes []KVWrite) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for i := range writes {
+		write := writes[i]
+		store.write(&write)
+	}
+}
+
+// The helpers below must be called while holding the write lock
+
+func (store *bufferedKVStore) discard() {
+	store.pending = make(map[string]*KVWrite)
+	store.resetJournal()
+}
+
+func (store *bufferedKVStore) resetJournal() {
+	store.journaling = false
+	store.journal = nil
+}
+
+func (store *bufferedKVStore) write(write *KVWrite) {
+	key := string(write.Key)
+	if store.journaling {
+		store.journal = append(store.journal, journalEntry{
+			key:   key,
+			write: store.pending[key],
+		})
+	}
+	store.pending[key] = write
+}
+
+var (
+	_ Iterator     = &bufferedIterator{}
+	_ Batch        = &bufferedBatch{}
+	_ ReadSnapshot = &bufferedReadSnapshot{}
+)
+
+type bufferedBatch struct {
+	store  *bufferedKVStore
+	writes []*KVWrite
+}
+
+func (batch *bufferedBatch) Set(key, value []byte) error {
+	batch.writes = append(batch.writes, &KVWrite{
+		Key:   append([]byte(nil), key...),
+		Value: append([]byte(nil), value...),
+	})
+	return nil
+}
+
+func (batch *bufferedBatch) Delete(key []byte) error {
+	batch.writes = append(batch.writes, &KVWrite{
+		Key:     append([]byte(nil), key...),
+		Deleted: true,
+	})
+	return nil
+}
+
+// Write adds all the writes of the batch to the pending writes at once, so they are never partially seen
+func (batch *bufferedBatch) Write() error {
+	batch.store.mu.Lock()
+	defer batch.store.mu.Unlock()
+	for _, write := range batch.writes {
+		batch.store.write(write)
+	}
+	batch.writes = nil
+	return nil
+}
+
+func (batch *bufferedBatch) Cancel() {
+	batch.writes = nil
+}
+
+type bufferedReadSnapshot struct {
+	snapshot ReadSnapshot
+	pending  map[string]*KVWrite
+}
+
+func (snapshot *bufferedReadSnapshot) Get(key []byte) ([]byte, error) {
+	if write, ok := snapshot.pending[string(key)]; ok {
+		if write.Deleted {
+			return nil, badger.ErrKeyNotFound
+		}
+		return append([]byte(nil), write.Value...), nil
+	}
+	return snapshot.snapshot.Get(key)
+}
+
+func (snapshot *bufferedReadSnapshot) Exists(key []byte) (bool, error) {
+	val, err := snapshot.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (snapshot *bufferedReadSnapshot) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	it, err := snapshot.snapshot.Iterator(prefix, start, end, reverse)
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedIterator(it, snapshot.pending, prefix, start, end, reverse), nil
+}
+
+func (snapshot *bufferedReadSnapshot) Release() {
+	snapshot.snapshot.Release()
+}
+
+// bufferedIterator merges the pending writes, sorted in the order of the iteration, into the iteration over
+// the underlying store. Pending writes take precedence over the persisted values of the same keys.
+type bufferedIterator struct {
+	it            Iterator
+	pendingWrites []*KVWrite
+	reverse       bool
+
+	// Whether the underlying iterator has been advanced to a pair that has not been consumed yet
+	itLoaded, itValid bool
+
+	key, value []byte
+}
+
+func newBufferedIterator(it Iterator, pending map[string]*KVWrite, prefix, start, end []byte, reverse bool) *bufferedIterator {
+	pendingWrites := make([]*KVWrite, 0)
+	for _, write := range pending {
+		if !bytes.HasPrefix(write.Key, prefix) ||
+			(start != nil && bytes.Compare(write.Key, start) < 0) ||
+			(end != nil && bytes.Compare(write.Key, end) >= 0) {
+			continue
+		}
+		pendingWrites = append(pendingWrites, write)
+	}
+	sort.Slice(pendingWrites, func(i, j int) bool {
+		if reverse {
+			return bytes.Compare(pendingWrites[i].Key, pendingWrites[j].Key) > 0
+		}
+		return bytes.Compare(pendingWrites[i].Key, pendingWrites[j].Key) < 0
+	})
+
+	return &bufferedIterator{
+		it:            it,
+		pendingWrites: pendingWrites,
+		reverse:       reverse,
+	}
+}
+
+func (i *bufferedIterator) Next() bool {
+	for {
+		if !i.itLoaded {
+			i.itValid = i.it.Next()
+			i.itLoaded = true
+		}
+		hasPending := len(i.pendingWrites) > 0
+		if !i.itValid && !hasPending {
+			return false
+		}
+
+		cmp := 1 // Positive if the next pending write comes before the underlying pair (or there is no such pair)
+		if i.itValid && hasPending {
+			cmp = bytes.Compare(i.it.Key(), i.pendingWrites[0].Key)
+			if i.reverse {
+				cmp = -cmp
+			}
+		}
+
+		if !hasPending || cmp < 0 {
+			i.key, i.value = i.it.Key(), i.it.Value()
+			i.itLoaded = false
+			return true
+		}
+
+		write := i.pendingWrites[0]
+		i.pendingWrites = i.pendingWrites[1:]
+		if i.itValid && cmp == 0 {
+			i.itLoaded = false // The persisted value is overridden by the pending write
+		}
+		if write.Deleted {
+			continue
+		}
+		i.key, i.value = write.Key, write.Value
+		return true
+	}
+}
+
+func (i *bufferedIterator) Key() []byte {
+	return i.key
+}
+
+func (i *bufferedIterator) Value() []byte {
+	return i.value
+}
+
+func (i *bufferedIterator) Error() error {
+	return i.it.Error()
+}
+
+func (i *bufferedIterator) Close() {
+	i.it.Close()
+}
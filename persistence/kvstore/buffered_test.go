@@ -0,0 +1,184 @@
+package kvstore
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferedKVStore_RevertTo(t *testing.T) {
+	store := NewBufferedKVStore(NewMemKVStore())
+	defer store.Stop()
+
+	// Writes made before the first checkpoint are not journaled
+	require.NoError(t, store.Set([]byte("key1"), []byte("value1")))
+	require.NoError(t, store.Set([]byte("key2"), []byte("value2")))
+
+	checkpoint := store.Checkpoint()
+	require.NoError(t, store.Set([]byte("key1"), []byte("value1_updated")))
+	require.NoError(t, store.Delete([]byte("key2")))
+	require.NoError(t, store.Set([]byte("key3"), []byte("value3")))
+
+	nestedCheckpoint := store.Checkpoint()
+	require.NoError(t, store.Set([]byte("key3"), []byte("value3_updated")))
+
+	// Reverting to the nested checkpoint only undoes the writes made after it
+	require.NoError(t, store.RevertTo(nestedCheckpoint))
+	value, err := store.Get([]byte("key3"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), value)
+
+	// Reverting to the first checkpoint undoes all the writes made after it
+	require.NoError(t, store.RevertTo(checkpoint))
+	value, err = store.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+	value, err = store.Get([]byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+	_, err = store.Get([]byte("key3"))
+	require.Error(t, err)
+
+	require.Error(t, store.RevertTo(checkpoint+1))
+}
+
+func TestBufferedKVStore_ResetJournal(t *testing.T) {
+	store := NewBufferedKVStore(NewMemKVStore())
+	defer store.Stop()
+
+	checkpoint := store.Checkpoint()
+	require.NoError(t, store.Set([]byte("key"), []byte("value")))
+
+	// Once the journal is reset, the writes made since the checkpoint can no longer be reverted
+	store.ResetJournal()
+	require.Error(t, store.RevertTo(checkpoint+1))
+	require.NoError(t, store.RevertTo(checkpoint))
+	value, err := store.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestBufferedKVStore_FlushAndDiscard(t *testing.T) {
+	underlying := NewMemKVStore()
+	store := NewBufferedKVStore(underlying)
+	defer store.Stop()
+
+	require.NoError(t, underlying.Set([]byte("key1"), []byte("value1")))
+	require.NoError(t, underlying.Set([]byte("key2"), []byte("value2")))
+
+	require.NoError(t, store.Set([]byte("key3"), []byte("value3")))
+	require.NoError(t, store.Delete([]byte("key1")))
+
+	// Pending writes are visible through the buffered store but not the underlying one
+	_, err := store.Get([]byte("key1"))
+	require.Error(t, err)
+	_, err = underlying.Get([]byte("key3"))
+	require.Error(t, err)
+	require.Equal(t, []KVWrite{
+		{Key: []byte("key1"), Deleted: true},
+		{Key: []byte("key3"), Value: []byte("value3")},
+	}, store.PendingWrites())
+
+	// A flushed read snapshot ignores the pending writes
+	snapshot := store.NewFlushedReadSnapshot()
+	value, err := snapshot.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+	_, err = snapshot.Get([]byte("key3"))
+	require.Error(t, err)
+	snapshot.Release()
+
+	// Discarding drops the pending writes
+	store.Discard()
+	require.Empty(t, store.PendingWrites())
+	value, err = store.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// Flushing persists the pending writes to the underlying store
+	store.AddPendingWrites([]KVWrite{
+		{Key: []byte("key1"), Deleted: true},
+		{Key: []byte("key3"), Value: []byte("value3")},
+	})
+	require.NoError(t, store.Flush())
+	require.Empty(t, store.PendingWrites())
+	_, err = underlying.Get([]byte("key1"))
+	require.Error(t, err)
+	value, err = underlying.Get([]byte("key3"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), value)
+}
+
+func TestBufferedKVStore_GetAll(t *testing.T) {
+	underlying := NewMemKVStore()
+	store := NewBufferedKVStore(underlying)
+	defer store.Stop()
+
+	require.NoError(t, underlying.Set([]byte("a/1"), []byte("1")))
+	require.NoError(t, underlying.Set([]byte("a/2"), []byte("2")))
+	require.NoError(t, underlying.Set([]byte("b/1"), []byte("1")))
+
+	require.NoError(t, store.Set([]byte("a/3"), []byte("3")))
+	require.NoError(t, store.Set([]byte("a/2"), []byte("2_updated")))
+	require.NoError(t, store.Delete([]byte("a/1")))
+	require.NoError(t, store.Set([]byte("b/2"), []byte("2")))
+
+	keys, values, err := store.GetAll([]byte("a/"), false)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("a/2"), []byte("a/3")}, keys)
+	require.Equal(t, [][]byte{[]byte("2_updated"), []byte("3")}, values)
+
+	keys, values, err = store.GetAll([]byte("a/"), true)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("a/3"), []byte("a/2")}, keys)
+	require.Equal(t, [][]byte{[]byte("3"), []byte("2_updated")}, values)
+}
+
+func TestBufferedKVStore_ConcurrentReadsAndWrites(t *testing.T) {
+	store := NewBufferedKVStore(NewMemKVStore())
+	defer store.Stop()
+
+	// The writes of a block are flushed or discarded while other goroutines read the store
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _ = store.Get([]byte("a/1"))
+				_, _, err := store.GetAll([]byte("a/"), false)
+				require.NoError(t, err)
+				snapshot := store.NewFlushedReadSnapshot()
+				_, _, err = ReadAll(snapshot, []byte("a/"), false)
+				require.NoError(t, err)
+				snapshot.Release()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		checkpoint := store.Checkpoint()
+		require.NoError(t, store.Set([]byte(fmt.Sprintf("a/%d", i)), []byte("value")))
+		require.NoError(t, store.RevertTo(checkpoint))
+		require.NoError(t, store.Set([]byte(fmt.Sprintf("a/%d", i)), []byte("value")))
+		if i%2 == 0 {
+			require.NoError(t, store.Flush())
+		} else {
+			store.Discard()
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	keys, _, err := store.GetAll([]byte("a/"), false)
+	require.NoError(t, err)
+	require.Len(t, keys, 50)
+}
@@ -0,0 +1,300 @@
+package kvstore
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suite runs against every registered backend, both directly and wrapped in a
+// `BufferedKVStore`, so that all the stores behave the same regardless of what they are backed by.
+
+type storeFactory struct {
+	name string
+	// Opens a store at the given path, or in memory if the path is empty
+	open func(t *testing.T, path string) KVStore
+	// Whether the data written survives the store being stopped and reopened at the same path
+	durable bool
+}
+
+func conformanceStoreFactories() []storeFactory {
+	factories := make([]storeFactory, 0)
+	for _, backend := range Backends() {
+		backend := backend
+		open := func(t *testing.T, path string) KVStore {
+			store, err := OpenKVStore(backend, path, BackendOptions{})
+			require.NoError(t, err)
+			return store
+		}
+		durable := backend != MapBackend
+		factories = append(factories,
+			storeFactory{name: backend, open: open, durable: durable},
+			storeFactory{
+				name: fmt.Sprintf("buffered_%s", backend),
+				open: func(t *testing.T, path string) KVStore {
+					return NewBufferedKVStore(open(t, path))
+				},
+			},
+		)
+	}
+	return factories
+}
+
+func runConformanceTest(t *testing.T, test func(t *testing.T, store KVStore)) {
+	for _, factory := range conformanceStoreFactories() {
+		factory := factory
+		t.Run(factory.name, func(t *testing.T) {
+			store := factory.open(t, "")
+			defer store.Stop()
+			test(t, store)
+		})
+	}
+}
+
+func setKeys(t *testing.T, store KVStore, keys ...string) {
+	for _, key := range keys {
+		require.NoError(t, store.Set([]byte(key), []byte("value_"+key)))
+	}
+}
+
+func TestConformance_SetGetDelete(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		_, err := store.Get([]byte("key"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		exists, err := store.Exists([]byte("key"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		require.False(t, exists)
+
+		require.NoError(t, store.Set([]byte("key"), []byte("value")))
+		value, err := store.Get([]byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+		exists, err = store.Exists([]byte("key"))
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		// The value returned must not be affected by later writes
+		require.NoError(t, store.Set([]byte("key"), []byte("value_updated")))
+		require.Equal(t, []byte("value"), value)
+		value, err = store.Get([]byte("key"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value_updated"), value)
+
+		// Regression test: the badger `Delete` used to discard its transaction rather than committing it
+		require.NoError(t, store.Delete([]byte("key")))
+		_, err = store.Get([]byte("key"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		// Deleting a key that does not exist is not an error
+		require.NoError(t, store.Delete([]byte("missing")))
+	})
+}
+
+func TestConformance_Iterator(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		start, end []byte
+		reverse    bool
+		want       []string
+	}{
+		{name: "everything", want: []string{"a", "a/1", "a/2", "a/3", "a/4", "b", "b/1"}},
+		{name: "everything in reverse", reverse: true, want: []string{"b/1", "b", "a/4", "a/3", "a/2", "a/1", "a"}},
+		{name: "prefix", prefix: "a/", want: []string{"a/1", "a/2", "a/3", "a/4"}},
+		// The key right after the prefix (i.e. "b") must not stop a reverse iteration
+		{name: "prefix in reverse", prefix: "a", reverse: true, want: []string{"a/4", "a/3", "a/2", "a/1", "a"}},
+		{name: "range", prefix: "a/", start: []byte("a/2"), end: []byte("a/4"), want: []string{"a/2", "a/3"}},
+		{name: "range in reverse", prefix: "a/", start: []byte("a/2"), end: []byte("a/4"), reverse: true, want: []string{"a/3", "a/2"}},
+		{name: "range outside of the prefix", prefix: "a/", start: []byte("0"), end: []byte("z"), want: []string{"a/1", "a/2", "a/3", "a/4"}},
+		{name: "range outside of the prefix in reverse", prefix: "a/", start: []byte("0"), end: []byte("z"), reverse: true, want: []string{"a/4", "a/3", "a/2", "a/1"}},
+		{name: "empty range", prefix: "a/", start: []byte("a/3"), end: []byte("a/3"), want: []string{}},
+		{name: "no match", prefix: "c", want: []string{}},
+	}
+
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		setKeys(t, store, "a", "a/1", "a/2", "a/3", "a/4", "b", "b/1")
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				it, err := store.Iterator([]byte(tt.prefix), tt.start, tt.end, tt.reverse)
+				require.NoError(t, err)
+				defer it.Close()
+
+				keys := make([]string, 0)
+				for it.Next() {
+					keys = append(keys, string(it.Key()))
+					require.Equal(t, "value_"+string(it.Key()), string(it.Value()))
+				}
+				require.NoError(t, it.Error())
+				require.Equal(t, tt.want, keys)
+				require.False(t, it.Next())
+			})
+		}
+	})
+}
+
+func TestConformance_GetPage(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		setKeys(t, store, "a/1", "a/2", "a/3", "a/4", "a/5", "b/1")
+
+		readAllPages := func(descending bool) [][]string {
+			pages := make([][]string, 0)
+			var cursor []byte
+			for {
+				keys, values, nextCursor, err := store.GetPage([]byte("a/"), cursor, 2, descending)
+				require.NoError(t, err)
+				page := make([]string, 0, len(keys))
+				for i, key := range keys {
+					require.Equal(t, "value_"+string(key), string(values[i]))
+					page = append(page, string(key))
+				}
+				pages = append(pages, page)
+				if nextCursor == nil {
+					return pages
+				}
+				cursor = nextCursor
+			}
+		}
+
+		require.Equal(t, [][]string{{"a/1", "a/2"}, {"a/3", "a/4"}, {"a/5"}}, readAllPages(false))
+		require.Equal(t, [][]string{{"a/5", "a/4"}, {"a/3", "a/2"}, {"a/1"}}, readAllPages(true))
+
+		_, _, _, err := store.GetPage([]byte("a/"), nil, 0, false)
+		require.ErrorIs(t, err, ErrInvalidPageLimit)
+	})
+}
+
+func TestConformance_GetAll(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		setKeys(t, store, "a/1", "a/2", "b/1")
+
+		keys, values, err := store.GetAll([]byte("a/"), false)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("a/1"), []byte("a/2")}, keys)
+		require.Equal(t, [][]byte{[]byte("value_a/1"), []byte("value_a/2")}, values)
+
+		keys, _, err = store.GetAll([]byte("a/"), true)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("a/2"), []byte("a/1")}, keys)
+
+		keys, _, err = store.GetAll([]byte("c/"), false)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+}
+
+func TestConformance_Batch(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		require.NoError(t, store.Set([]byte("key1"), []byte("value1")))
+
+		batch := store.NewBatch()
+		require.NoError(t, batch.Set([]byte("key2"), []byte("value2")))
+		require.NoError(t, batch.Delete([]byte("key1")))
+
+		// Nothing is written until the batch is
+		value, err := store.Get([]byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), value)
+		_, err = store.Get([]byte("key2"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		require.NoError(t, batch.Write())
+		batch.Cancel() // No-op once written
+
+		_, err = store.Get([]byte("key1"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		value, err = store.Get([]byte("key2"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value2"), value)
+
+		// A cancelled batch writes nothing
+		batch = store.NewBatch()
+		require.NoError(t, batch.Set([]byte("key3"), []byte("value3")))
+		batch.Cancel()
+		_, err = store.Get([]byte("key3"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestConformance_ReadSnapshot(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		require.NoError(t, store.Set([]byte("key1"), []byte("value1")))
+		require.NoError(t, store.Set([]byte("key2"), []byte("value2")))
+
+		snapshot := store.NewReadSnapshot()
+		defer snapshot.Release()
+
+		// Writes made after the snapshot was created are not visible through it
+		require.NoError(t, store.Set([]byte("key1"), []byte("value1_updated")))
+		require.NoError(t, store.Delete([]byte("key2")))
+		require.NoError(t, store.Set([]byte("key3"), []byte("value3")))
+
+		value, err := snapshot.Get([]byte("key1"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), value)
+		exists, err := snapshot.Exists([]byte("key2"))
+		require.NoError(t, err)
+		require.True(t, exists)
+		_, err = snapshot.Get([]byte("key3"))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		it, err := snapshot.Iterator(nil, nil, nil, false)
+		require.NoError(t, err)
+		defer it.Close()
+		keys := make([]string, 0)
+		for it.Next() {
+			keys = append(keys, string(it.Key()))
+		}
+		require.NoError(t, it.Error())
+		require.Equal(t, []string{"key1", "key2"}, keys)
+	})
+}
+
+func TestConformance_ClearAll(t *testing.T) {
+	runConformanceTest(t, func(t *testing.T, store KVStore) {
+		setKeys(t, store, "a/1", "a/2", "b/1")
+
+		require.NoError(t, store.ClearAll())
+
+		keys, _, err := store.GetAll(nil, false)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		// The store can still be written to once cleared
+		setKeys(t, store, "c/1")
+		keys, _, err = store.GetAll(nil, false)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("c/1")}, keys)
+	})
+}
+
+func TestConformance_Reopen(t *testing.T) {
+	for _, factory := range conformanceStoreFactories() {
+		if !factory.durable {
+			continue
+		}
+		factory := factory
+		t.Run(factory.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "store")
+
+			store := factory.open(t, path)
+			setKeys(t, store, "a/1", "a/2")
+			require.NoError(t, store.Delete([]byte("a/2")))
+			require.NoError(t, store.Stop())
+
+			store = factory.open(t, path)
+			defer store.Stop()
+			keys, values, err := store.GetAll(nil, false)
+			require.NoError(t, err)
+			require.Equal(t, [][]byte{[]byte("a/1")}, keys)
+			require.Equal(t, [][]byte{[]byte("value_a/1")}, values)
+		})
+	}
+}
+
+func TestOpenKVStore_UnknownBackend(t *testing.T) {
+	_, err := OpenKVStore("unknown", "", BackendOptions{})
+	require.Error(t, err)
+}
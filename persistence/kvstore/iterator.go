@@ -0,0 +1,286 @@
+package kvstore
+
+import (
+	"bytes"
+
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// Iterator streams the key-value pairs of a store in key order, without loading them into memory.
+//
+// Usage:
+//
+//	it, err := store.Iterator(prefix, nil, nil, false)
+//	if err != nil { ... }
+//	defer it.Close()
+//	for it.Next() {
+//		key, value := it.Key(), it.Value()
+//	}
+//	if err := it.Error(); err != nil { ... }
+type Iterator interface {
+	// Next advances the iterator to the next key-value pair and returns false once there are none left or an
+	// error occurred. It must be called before the first pair is accessed.
+	Next() bool
+	// Key returns the key of the current pair; it is only valid until the next call to `Next`
+	Key() []byte
+	// Value returns the value of the current pair; it is only valid until the next call to `Next`
+	Value() []byte
+	// Error returns the error that stopped the iteration, if any
+	Error() error
+	// Close releases the resources held by the iterator. It must always be called.
+	Close()
+}
+
+var _ Iterator = &badgerIterator{}
+
+// badgerIterator iterates over the keys with a given prefix in the `[start, end)` range
+type badgerIterator struct {
+	txn     *badger.Txn
+	ownsTxn bool // Whether the transaction is discarded when the iterator is closed
+	it      *badger.Iterator
+
+	prefix     []byte
+	start, end []byte // `nil` if unbounded
+	reverse    bool
+
+	started, done bool
+	key, value    []byte
+	err           error
+}
+
+func newBadgerIterator(txn *badger.Txn, ownsTxn bool, prefix, start, end []byte, reverse bool) *badgerIterator {
+	opt := badger.DefaultIteratorOptions
+	opt.Reverse = reverse
+	// The prefix is checked by the iterator itself when iterating in reverse, since the key the iteration starts
+	// from (i.e. the end of the range) may be outside of the prefix, which would stop a badger prefix iterator
+	if !reverse {
+		opt.Prefix = prefix
+	}
+
+	return &badgerIterator{
+		txn:     txn,
+		ownsTxn: ownsTxn,
+		it:      txn.NewIterator(opt),
+		prefix:  prefix,
+		start:   start,
+		end:     end,
+		reverse: reverse,
+	}
+}
+
+func (i *badgerIterator) Next() bool {
+	if i.done {
+		return false
+	}
+	if !i.started {
+		i.started = true
+		i.seek()
+	} else {
+		i.it.Next()
+	}
+
+	for ; i.it.Valid(); i.it.Next() {
+		item := i.it.Item()
+		key := item.Key()
+		if i.reverse {
+			if i.end != nil && bytes.Compare(key, i.end) >= 0 {
+				continue
+			}
+			if !bytes.HasPrefix(key, i.prefix) || (i.start != nil && bytes.Compare(key, i.start) < 0) {
+				break
+			}
+		} else if i.end != nil && bytes.Compare(key, i.end) >= 0 {
+			break
+		}
+
+		value, err := item.ValueCopy(i.value[:0])
+		if err != nil {
+			i.err = err
+			break
+		}
+		i.key = item.KeyCopy(i.key[:0])
+		i.value = value
+		return true
+	}
+	i.done = true
+	return false
+}
+
+func (i *badgerIterator) Key() []byte {
+	return i.key
+}
+
+func (i *badgerIterator) Value() []byte {
+	return i.value
+}
+
+func (i *badgerIterator) Error() error {
+	return i.err
+}
+
+func (i *badgerIterator) Close() {
+	i.it.Close()
+	if i.ownsTxn {
+		i.txn.Discard()
+	}
+}
+
+func (i *badgerIterator) seek() {
+	if !i.reverse {
+		// Seek to whichever of the prefix and the start of the range comes last
+		if i.start != nil && bytes.Compare(i.start, i.prefix) > 0 {
+			i.it.Seek(i.start)
+		} else {
+			i.it.Seek(i.prefix)
+		}
+		return
+	}
+
+	// Seek to whichever of the end of the prefix and the end of the range comes first. A reverse seek lands on the
+	// largest key that is lower than or equal to the key sought, so keys equal to it are skipped by `Next`.
+	if prefixEnd := prefixEndBytes(i.prefix); prefixEnd != nil && (i.end == nil || bytes.Compare(prefixEnd, i.end) < 0) {
+		i.end = prefixEnd
+	}
+	if i.end != nil {
+		i.it.Seek(i.end)
+	} else {
+		i.it.Rewind()
+	}
+}
+
+// ReadPage reads up to `limit` key-value pairs with the given prefix, starting from the `cursor` key (included),
+// and returns the cursor of the next page or nil if there are no more pairs. A nil cursor starts from the first
+// (or last if descending) key.
+func ReadPage(store KVReader, prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	if limit <= 0 {
+		return nil, nil, nil, ErrInvalidPageLimit
+	}
+
+	var start, end []byte
+	if cursor != nil {
+		if descending {
+			end = keyAfter(cursor)
+		} else {
+			start = cursor
+		}
+	}
+
+	it, err := store.Iterator(prefix, start, end, descending)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer it.Close()
+
+	keys = make([][]byte, 0, limit)
+	values = make([][]byte, 0, limit)
+	for it.Next() {
+		if len(keys) == limit {
+			nextCursor = append([]byte(nil), it.Key()...)
+			break
+		}
+		keys = append(keys, append([]byte(nil), it.Key()...))
+		values = append(values, append([]byte(nil), it.Value()...))
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, nil, err
+	}
+	return keys, values, nextCursor, nil
+}
+
+// Returns the smallest key that is greater than the given key
+func keyAfter(key []byte) []byte {
+	return append(append(make([]byte, 0, len(key)+1), key...), 0)
+}
+
+// ReadAll loads all the key-value pairs with the given prefix into memory
+func ReadAll(store KVReader, prefix []byte, descending bool) (keys, values [][]byte, err error) {
+	it, err := store.Iterator(prefix, nil, nil, descending)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	keys = make([][]byte, 0)
+	values = make([][]byte, 0)
+	for it.Next() {
+		keys = append(keys, append([]byte(nil), it.Key()...))
+		values = append(values, append([]byte(nil), it.Value()...))
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// Returns the bounds of the keys with the given prefix in the `[start, end)` range, where a nil upper bound is
+// unbounded
+func rangeBounds(prefix, start, end []byte) (lower, upper []byte) {
+	lower = prefix
+	if start != nil && bytes.Compare(start, lower) > 0 {
+		lower = start
+	}
+	upper = prefixEndBytes(prefix)
+	if end != nil && (upper == nil || bytes.Compare(end, upper) < 0) {
+		upper = end
+	}
+	return lower, upper
+}
+
+// cursorIterator is implemented by the native iterators of the backends that position a cursor within bounds
+type cursorIterator interface {
+	First() bool
+	Last() bool
+	Next() bool
+	Prev() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+}
+
+var _ Iterator = &boundedIterator{}
+
+// boundedIterator adapts a `cursorIterator`, which is already restricted to the range being iterated, to an
+// `Iterator`
+type boundedIterator struct {
+	it      cursorIterator
+	reverse bool
+	close   func()
+
+	started, done bool
+}
+
+func (i *boundedIterator) Next() bool {
+	if i.done {
+		return false
+	}
+	var valid bool
+	switch {
+	case !i.started && i.reverse:
+		valid = i.it.Last()
+	case !i.started:
+		valid = i.it.First()
+	case i.reverse:
+		valid = i.it.Prev()
+	default:
+		valid = i.it.Next()
+	}
+	i.started = true
+	i.done = !valid
+	return valid
+}
+
+func (i *boundedIterator) Key() []byte {
+	return i.it.Key()
+}
+
+func (i *boundedIterator) Value() []byte {
+	return i.it.Value()
+}
+
+func (i *boundedIterator) Error() error {
+	return i.it.Error()
+}
+
+func (i *boundedIterator) Close() {
+	i.close()
+}
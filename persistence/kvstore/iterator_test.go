@@ -0,0 +1,49 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferedKVStore_Iterator(t *testing.T) {
+	underlying := NewMemKVStore()
+	store := NewBufferedKVStore(underlying)
+	defer store.Stop()
+
+	for _, key := range []string{"a/1", "a/3", "a/5", "b/1"} {
+		require.NoError(t, underlying.Set([]byte(key), []byte(key)))
+	}
+	require.NoError(t, store.Set([]byte("a/2"), []byte("a/2")))
+	require.NoError(t, store.Set([]byte("a/3"), []byte("a/3_updated")))
+	require.NoError(t, store.Delete([]byte("a/5")))
+	require.NoError(t, store.Set([]byte("a/6"), []byte("a/6")))
+	require.NoError(t, store.Set([]byte("b/2"), []byte("b/2")))
+
+	collect := func(it Iterator) []string {
+		defer it.Close()
+		pairs := make([]string, 0)
+		for it.Next() {
+			pairs = append(pairs, string(it.Key())+"="+string(it.Value()))
+		}
+		require.NoError(t, it.Error())
+		return pairs
+	}
+
+	it, err := store.Iterator([]byte("a/"), nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/1=a/1", "a/2=a/2", "a/3=a/3_updated", "a/6=a/6"}, collect(it))
+
+	it, err = store.Iterator([]byte("a/"), nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/6=a/6", "a/3=a/3_updated", "a/2=a/2", "a/1=a/1"}, collect(it))
+
+	it, err = store.Iterator([]byte("a/"), []byte("a/2"), []byte("a/6"), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/2=a/2", "a/3=a/3_updated"}, collect(it))
+
+	keys, _, nextCursor, err := store.GetPage([]byte("a/"), nil, 3, false)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("a/1"), []byte("a/2"), []byte("a/3")}, keys)
+	require.Equal(t, []byte("a/6"), nextCursor)
+}
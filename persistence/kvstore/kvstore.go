@@ -17,11 +17,21 @@ type KVStore interface {
 	Stop() error
 
 	// Accessors
-	// TODO: Add a proper iterator interface
-	// TODO: Add pagination for `GetAll`
+	// Iterator streams the keys with the given prefix in the `[start, end)` range, where a nil `start` or `end`
+	// leaves the range unbounded on that side
+	Iterator(prefix, start, end []byte, reverse bool) (Iterator, error)
+	// GetPage returns up to `limit` keys with the given prefix, starting from the `cursor` key, along with the
+	// cursor of the next page, which is nil once there are no more keys. A nil `cursor` starts from the beginning.
+	GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error)
+	// GetAll loads all the keys with the given prefix into memory; prefer `Iterator` or `GetPage` for large ranges
 	GetAll(prefixKey []byte, descending bool) (keys, values [][]byte, err error)
 	Exists(key []byte) (bool, error)
 	ClearAll() error
+
+	// NewBatch returns a batch whose writes are applied to the store at once when it is written
+	NewBatch() Batch
+	// NewReadSnapshot returns a read-only view of the store at this point in time
+	NewReadSnapshot() ReadSnapshot
 }
 
 const (
@@ -36,6 +46,7 @@ var (
 var (
 	ErrKVStoreExists    = errors.New("kvstore already exists")
 	ErrKVStoreNotExists = errors.New("kvstore does not exist")
+	ErrInvalidPageLimit = errors.New("the page limit must be positive")
 )
 
 type badgerKVStore struct {
@@ -50,6 +61,16 @@ func NewKVStore(path string) (KVStore, error) {
 	return &badgerKVStore{db: db}, nil
 }
 
+// NewDurableKVStore opens a store whose writes are synced to disk before they return, which is needed
+// for stores that are used as a write-ahead log
+func NewDurableKVStore(path string) (KVStore, error) {
+	db, err := badger.Open(badgerOptions(path).WithSyncWrites(true))
+	if err != nil {
+		return nil, err
+	}
+	return &badgerKVStore{db: db}, nil
+}
+
 func NewMemKVStore() KVStore {
 	db, err := badger.Open(badgerOptions("").WithInMemory(true))
 	if err != nil {
@@ -95,41 +116,31 @@ func (store *badgerKVStore) Delete(key []byte) error {
 	tx := store.db.NewTransaction(true)
 	defer tx.Discard()
 
-	return tx.Delete(key)
+	if err := tx.Delete(key); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (store *badgerKVStore) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newBadgerIterator(store.db.NewTransaction(false), true, prefix, start, end, reverse), nil
+}
+
+func (store *badgerKVStore) GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	return ReadPage(store, prefix, cursor, limit, descending)
 }
 
 func (store *badgerKVStore) GetAll(prefix []byte, descending bool) (keys, values [][]byte, err error) {
-	// INVESTIGATE: research `badger.views` for further improvements and optimizations
-	// Reference https://pkg.go.dev/github.com/dgraph-io/badger#readme-prefix-scans
-	txn := store.db.NewTransaction(false)
-	defer txn.Discard()
-
-	opt := badger.DefaultIteratorOptions
-	opt.Prefix = prefix
-	opt.Reverse = descending
-	if descending {
-		prefix = prefixEndBytes(prefix)
-	}
-	it := txn.NewIterator(opt)
-	defer it.Close()
-
-	keys = make([][]byte, 0)
-	values = make([][]byte, 0)
-
-	for it.Seek(prefix); it.Valid(); it.Next() {
-		item := it.Item()
-		err = item.Value(func(v []byte) error {
-			b := make([]byte, len(v))
-			copy(b, v)
-			keys = append(keys, item.Key())
-			values = append(values, b)
-			return nil
-		})
-		if err != nil {
-			return
-		}
-	}
-	return
+	return ReadAll(store, prefix, descending)
+}
+
+func (store *badgerKVStore) NewBatch() Batch {
+	return &badgerBatch{wb: store.db.NewWriteBatch()}
+}
+
+func (store *badgerKVStore) NewReadSnapshot() ReadSnapshot {
+	return &badgerReadSnapshot{txn: store.db.NewTransaction(false)}
 }
 
 func (store *badgerKVStore) Exists(key []byte) (bool, error) {
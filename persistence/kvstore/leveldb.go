@@ -0,0 +1,178 @@
+package kvstore
+
+import (
+	"errors"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	_ KVStore      = &levelDBKVStore{}
+	_ Batch        = &levelDBBatch{}
+	_ ReadSnapshot = &levelDBReadSnapshot{}
+)
+
+type levelDBKVStore struct {
+	db           *leveldb.DB
+	writeOptions *opt.WriteOptions
+}
+
+func newLevelDBKVStore(path string, opts BackendOptions) (KVStore, error) {
+	var db *leveldb.DB
+	var err error
+	if path == "" {
+		db, err = leveldb.Open(storage.NewMemStorage(), nil)
+	} else {
+		db, err = leveldb.OpenFile(path, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBKVStore{
+		db:           db,
+		writeOptions: &opt.WriteOptions{Sync: opts.SyncWrites},
+	}, nil
+}
+
+func (store *levelDBKVStore) Set(key, value []byte) error {
+	return store.db.Put(key, value, store.writeOptions)
+}
+
+func (store *levelDBKVStore) Get(key []byte) ([]byte, error) {
+	return levelDBGet(store.db.Get(key, nil))
+}
+
+func (store *levelDBKVStore) Delete(key []byte) error {
+	return store.db.Delete(key, store.writeOptions)
+}
+
+func (store *levelDBKVStore) Exists(key []byte) (bool, error) {
+	val, err := store.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (store *levelDBKVStore) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newLevelDBIterator(store.db.NewIterator, prefix, start, end, reverse), nil
+}
+
+func (store *levelDBKVStore) GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	return ReadPage(store, prefix, cursor, limit, descending)
+}
+
+func (store *levelDBKVStore) GetAll(prefix []byte, descending bool) (keys, values [][]byte, err error) {
+	return ReadAll(store, prefix, descending)
+}
+
+func (store *levelDBKVStore) ClearAll() error {
+	batch := new(leveldb.Batch)
+	it := store.db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		batch.Delete(it.Key())
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return store.db.Write(batch, store.writeOptions)
+}
+
+func (store *levelDBKVStore) Stop() error {
+	return store.db.Close()
+}
+
+func (store *levelDBKVStore) NewBatch() Batch {
+	return &levelDBBatch{
+		db:           store.db,
+		batch:        new(leveldb.Batch),
+		writeOptions: store.writeOptions,
+	}
+}
+
+func (store *levelDBKVStore) NewReadSnapshot() ReadSnapshot {
+	snapshot, err := store.db.GetSnapshot()
+	return &levelDBReadSnapshot{snapshot: snapshot, err: err}
+}
+
+type levelDBBatch struct {
+	db           *leveldb.DB
+	batch        *leveldb.Batch
+	writeOptions *opt.WriteOptions
+}
+
+func (batch *levelDBBatch) Set(key, value []byte) error {
+	batch.batch.Put(key, value)
+	return nil
+}
+
+func (batch *levelDBBatch) Delete(key []byte) error {
+	batch.batch.Delete(key)
+	return nil
+}
+
+func (batch *levelDBBatch) Write() error {
+	defer batch.batch.Reset()
+	return batch.db.Write(batch.batch, batch.writeOptions)
+}
+
+func (batch *levelDBBatch) Cancel() {
+	batch.batch.Reset()
+}
+
+// The error of `GetSnapshot`, which only fails if the DB is closed, is returned by every read of the snapshot
+type levelDBReadSnapshot struct {
+	snapshot *leveldb.Snapshot
+	err      error
+}
+
+func (snapshot *levelDBReadSnapshot) Get(key []byte) ([]byte, error) {
+	if snapshot.err != nil {
+		return nil, snapshot.err
+	}
+	return levelDBGet(snapshot.snapshot.Get(key, nil))
+}
+
+func (snapshot *levelDBReadSnapshot) Exists(key []byte) (bool, error) {
+	val, err := snapshot.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (snapshot *levelDBReadSnapshot) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	if snapshot.err != nil {
+		return nil, snapshot.err
+	}
+	return newLevelDBIterator(snapshot.snapshot.NewIterator, prefix, start, end, reverse), nil
+}
+
+func (snapshot *levelDBReadSnapshot) Release() {
+	if snapshot.snapshot != nil {
+		snapshot.snapshot.Release()
+	}
+}
+
+func levelDBGet(value []byte, err error) ([]byte, error) {
+	if errors.Is(err, leveldbErrors.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+func newLevelDBIterator(newIter func(*util.Range, *opt.ReadOptions) iterator.Iterator, prefix, start, end []byte, reverse bool) Iterator {
+	lower, upper := rangeBounds(prefix, start, end)
+	it := newIter(&util.Range{Start: lower, Limit: upper}, nil)
+	return &boundedIterator{
+		it:      it,
+		reverse: reverse,
+		close:   it.Release,
+	}
+}
@@ -0,0 +1,211 @@
+package kvstore
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+var (
+	_ KVStore      = &mapKVStore{}
+	_ Batch        = &mapBatch{}
+	_ ReadSnapshot = &mapReadSnapshot{}
+)
+
+// mapKVStore is a pure Go, in-memory store. It is meant for testing and benchmarking since nothing it holds
+// survives a restart.
+type mapKVStore struct {
+	mu sync.RWMutex
+	m  map[string][]byte
+}
+
+func NewMapKVStore() KVStore {
+	return &mapKVStore{m: make(map[string][]byte)}
+}
+
+func (store *mapKVStore) Set(key, value []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.m[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+func (store *mapKVStore) Get(key []byte) ([]byte, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return mapGet(store.m, key)
+}
+
+func (store *mapKVStore) Delete(key []byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.m, string(key))
+	return nil
+}
+
+func (store *mapKVStore) Exists(key []byte) (bool, error) {
+	val, err := store.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+// The keys in the range are copied when the iterator is created, so it is not affected by later writes
+func (store *mapKVStore) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return newMapIterator(store.m, prefix, start, end, reverse), nil
+}
+
+func (store *mapKVStore) GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	return ReadPage(store, prefix, cursor, limit, descending)
+}
+
+func (store *mapKVStore) GetAll(prefix []byte, descending bool) (keys, values [][]byte, err error) {
+	return ReadAll(store, prefix, descending)
+}
+
+func (store *mapKVStore) ClearAll() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.m = make(map[string][]byte)
+	return nil
+}
+
+func (store *mapKVStore) Stop() error {
+	return nil
+}
+
+func (store *mapKVStore) NewBatch() Batch {
+	return &mapBatch{store: store}
+}
+
+func (store *mapKVStore) NewReadSnapshot() ReadSnapshot {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	m := make(map[string][]byte, len(store.m))
+	for key, value := range store.m {
+		m[key] = value // Values are never mutated, only replaced
+	}
+	return &mapReadSnapshot{m: m}
+}
+
+type mapBatch struct {
+	store  *mapKVStore
+	writes []KVWrite
+}
+
+func (batch *mapBatch) Set(key, value []byte) error {
+	batch.writes = append(batch.writes, KVWrite{
+		Key:   append([]byte(nil), key...),
+		Value: append([]byte(nil), value...),
+	})
+	return nil
+}
+
+func (batch *mapBatch) Delete(key []byte) error {
+	batch.writes = append(batch.writes, KVWrite{
+		Key:     append([]byte(nil), key...),
+		Deleted: true,
+	})
+	return nil
+}
+
+func (batch *mapBatch) Write() error {
+	batch.store.mu.Lock()
+	defer batch.store.mu.Unlock()
+	for _, write := range batch.writes {
+		if write.Deleted {
+			delete(batch.store.m, string(write.Key))
+		} else {
+			batch.store.m[string(write.Key)] = write.Value
+		}
+	}
+	batch.writes = nil
+	return nil
+}
+
+func (batch *mapBatch) Cancel() {
+	batch.writes = nil
+}
+
+type mapReadSnapshot struct {
+	m map[string][]byte
+}
+
+func (snapshot *mapReadSnapshot) Get(key []byte) ([]byte, error) {
+	return mapGet(snapshot.m, key)
+}
+
+func (snapshot *mapReadSnapshot) Exists(key []byte) (bool, error) {
+	val, err := snapshot.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (snapshot *mapReadSnapshot) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newMapIterator(snapshot.m, prefix, start, end, reverse), nil
+}
+
+func (snapshot *mapReadSnapshot) Release() {}
+
+func mapGet(m map[string][]byte, key []byte) ([]byte, error) {
+	value, ok := m[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+var _ Iterator = &mapIterator{}
+
+type mapIterator struct {
+	keys   []string
+	values [][]byte
+	pos    int
+}
+
+func newMapIterator(m map[string][]byte, prefix, start, end []byte, reverse bool) *mapIterator {
+	lower, upper := rangeBounds(prefix, start, end)
+	keys := make([]string, 0)
+	for key := range m {
+		if bytes.Compare([]byte(key), lower) >= 0 && (upper == nil || bytes.Compare([]byte(key), upper) < 0) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if reverse {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = m[key]
+	}
+	return &mapIterator{keys: keys, values: values, pos: -1}
+}
+
+func (i *mapIterator) Next() bool {
+	if i.pos < len(i.keys) {
+		i.pos++
+	}
+	return i.pos < len(i.keys)
+}
+
+func (i *mapIterator) Key() []byte {
+	return []byte(i.keys[i.pos])
+}
+
+func (i *mapIterator) Value() []byte {
+	return i.values[i.pos]
+}
+
+func (i *mapIterator) Error() error {
+	return nil
+}
+
+func (i *mapIterator) Close() {}
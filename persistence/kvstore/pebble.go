@@ -0,0 +1,177 @@
+package kvstore
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+var (
+	_ KVStore      = &pebbleKVStore{}
+	_ Batch        = &pebbleBatch{}
+	_ ReadSnapshot = &pebbleReadSnapshot{}
+)
+
+type pebbleKVStore struct {
+	db           *pebble.DB
+	writeOptions *pebble.WriteOptions
+}
+
+func newPebbleKVStore(path string, opts BackendOptions) (KVStore, error) {
+	pebbleOpts := &pebble.Options{}
+	if path == "" {
+		pebbleOpts.FS = vfs.NewMem()
+	}
+	db, err := pebble.Open(path, pebbleOpts)
+	if err != nil {
+		return nil, err
+	}
+	writeOptions := pebble.NoSync
+	if opts.SyncWrites {
+		writeOptions = pebble.Sync
+	}
+	return &pebbleKVStore{db: db, writeOptions: writeOptions}, nil
+}
+
+func (store *pebbleKVStore) Set(key, value []byte) error {
+	return store.db.Set(key, value, store.writeOptions)
+}
+
+func (store *pebbleKVStore) Get(key []byte) ([]byte, error) {
+	return pebbleGet(store.db.Get(key))
+}
+
+func (store *pebbleKVStore) Delete(key []byte) error {
+	return store.db.Delete(key, store.writeOptions)
+}
+
+func (store *pebbleKVStore) Exists(key []byte) (bool, error) {
+	val, err := store.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (store *pebbleKVStore) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newPebbleIterator(store.db.NewIter, prefix, start, end, reverse), nil
+}
+
+func (store *pebbleKVStore) GetPage(prefix, cursor []byte, limit int, descending bool) (keys, values [][]byte, nextCursor []byte, err error) {
+	return ReadPage(store, prefix, cursor, limit, descending)
+}
+
+func (store *pebbleKVStore) GetAll(prefix []byte, descending bool) (keys, values [][]byte, err error) {
+	return ReadAll(store, prefix, descending)
+}
+
+func (store *pebbleKVStore) ClearAll() error {
+	// Keys are unbounded so there is no range covering all of them; they are deleted one by one instead
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	it := store.db.NewIter(nil)
+	defer it.Close()
+	for valid := it.First(); valid; valid = it.Next() {
+		if err := batch.Delete(it.Key(), nil); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Commit(store.writeOptions)
+}
+
+func (store *pebbleKVStore) Stop() error {
+	return store.db.Close()
+}
+
+func (store *pebbleKVStore) NewBatch() Batch {
+	return &pebbleBatch{
+		batch:        store.db.NewBatch(),
+		writeOptions: store.writeOptions,
+	}
+}
+
+func (store *pebbleKVStore) NewReadSnapshot() ReadSnapshot {
+	return &pebbleReadSnapshot{snapshot: store.db.NewSnapshot()}
+}
+
+type pebbleBatch struct {
+	batch        *pebble.Batch
+	writeOptions *pebble.WriteOptions
+	done         bool
+}
+
+func (batch *pebbleBatch) Set(key, value []byte) error {
+	return batch.batch.Set(key, value, nil)
+}
+
+func (batch *pebbleBatch) Delete(key []byte) error {
+	return batch.batch.Delete(key, nil)
+}
+
+func (batch *pebbleBatch) Write() error {
+	batch.done = true
+	defer batch.batch.Close()
+	return batch.batch.Commit(batch.writeOptions)
+}
+
+func (batch *pebbleBatch) Cancel() {
+	if batch.done {
+		return
+	}
+	batch.done = true
+	batch.batch.Close()
+}
+
+type pebbleReadSnapshot struct {
+	snapshot *pebble.Snapshot
+}
+
+func (snapshot *pebbleReadSnapshot) Get(key []byte) ([]byte, error) {
+	return pebbleGet(snapshot.snapshot.Get(key))
+}
+
+func (snapshot *pebbleReadSnapshot) Exists(key []byte) (bool, error) {
+	val, err := snapshot.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (snapshot *pebbleReadSnapshot) Iterator(prefix, start, end []byte, reverse bool) (Iterator, error) {
+	return newPebbleIterator(snapshot.snapshot.NewIter, prefix, start, end, reverse), nil
+}
+
+func (snapshot *pebbleReadSnapshot) Release() {
+	snapshot.snapshot.Close()
+}
+
+// Copies the value returned by a pebble `Get`, which is only valid until its closer is closed
+func pebbleGet(value []byte, closer io.Closer, err error) ([]byte, error) {
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return append([]byte(nil), value...), nil
+}
+
+func newPebbleIterator(newIter func(*pebble.IterOptions) *pebble.Iterator, prefix, start, end []byte, reverse bool) Iterator {
+	lower, upper := rangeBounds(prefix, start, end)
+	it := newIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	return &boundedIterator{
+		it:      it,
+		reverse: reverse,
+		close:   func() { it.Close() },
+	}
+}
@@ -39,9 +39,15 @@ type persistenceModule struct {
 	// tx merkle tree.
 	txIndexer indexer.TxIndexer
 
+	// A write-ahead log used to make the commit of a block atomic across all the stores above
+	commitLog kvstore.KVStore
+
 	// A list of all the merkle trees maintained by the persistence module that roll up into the state commitment.
 	stateTrees *stateTrees
 
+	// Determines which heights are pruned when a block is committed; nil if nothing is pruned
+	pruningPolicy *pruningPolicy
+
 	// TECHDEBT: Need to implement context pooling (for writes), timeouts (for read & writes), etc...
 	// only one write context is allowed at a time
 	writeContext *PostgresContext
@@ -82,18 +88,26 @@ func (*persistenceModule) Create(bus modules.Bus, options ...modules.ModuleOptio
 	}
 	conn.Close(context.TODO())
 
-	// TODO: Follow the same pattern as txIndexer below for initializing the blockStore
-	blockStore, err := initializeBlockStore(persistenceCfg.BlockStorePath)
+	// Each store is opened with the backend configured for it, and in memory if it has no path
+	backendOpts := kvstore.BackendOptions{Badger: persistenceCfg.GetBadger()}
+
+	blockStore, err := kvstore.OpenKVStore(persistenceCfg.GetBlockStoreBackend(), persistenceCfg.GetBlockStorePath(), backendOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	txIndexer, err := indexer.NewTxIndexer(persistenceCfg.TxIndexerPath)
+	txIndexerStore, err := kvstore.OpenKVStore(persistenceCfg.GetTxIndexerBackend(), persistenceCfg.GetTxIndexerPath(), backendOpts)
 	if err != nil {
 		return nil, err
 	}
+	txIndexer := indexer.NewTxIndexerWithStore(txIndexerStore)
 
-	stateTrees, err := newStateTrees(persistenceCfg.TreesStoreDir)
+	stateTrees, err := newStateTrees(persistenceCfg.GetTreesStoreDir(), persistenceCfg.GetTreesStoreBackend(), backendOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	commitLog, err := initializeCommitLog(persistenceCfg.TreesStoreDir)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +118,8 @@ func (*persistenceModule) Create(bus modules.Bus, options ...modules.ModuleOptio
 	m.blockStore = blockStore
 	m.txIndexer = txIndexer
 	m.stateTrees = stateTrees
+	m.commitLog = commitLog
+	m.pruningPolicy = newPruningPolicy(persistenceCfg)
 
 	// TECHDEBT: reconsider if this is the best place to call `populateGenesisState`. Note that
 	// 		     this forces the genesis state to be reloaded on every node startup until state
@@ -117,6 +133,9 @@ func (*persistenceModule) Create(bus modules.Bus, options ...modules.ModuleOptio
 		// This configurations will connect to the SQL database and key-value stores specified
 		// in the configurations and connected to those.
 		logger.Global.Info().Msg("Loading state from disk...")
+		if err := m.recoverFromCommitLog(); err != nil {
+			return nil, err
+		}
 	}
 
 	return m, nil
@@ -127,7 +146,21 @@ func (m *persistenceModule) Start() error {
 }
 
 func (m *persistenceModule) Stop() error {
-	return m.blockStore.Stop()
+	if err := m.blockStore.Stop(); err != nil {
+		return err
+	}
+	if err := m.txIndexer.Close(); err != nil {
+		return err
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		if err := m.stateTrees.nodeStores[tree].Stop(); err != nil {
+			return err
+		}
+		if err := m.stateTrees.valueStores[tree].Stop(); err != nil {
+			return err
+		}
+	}
+	return m.commitLog.Stop()
 }
 
 func (m *persistenceModule) GetModuleName() string {
@@ -163,11 +196,50 @@ func (m *persistenceModule) NewRWContext(height int64) (modules.PersistenceRWCon
 		blockStore: m.blockStore,
 		txIndexer:  m.txIndexer,
 		stateTrees: m.stateTrees,
+		commitLog:  m.commitLog,
+
+		pruningPolicy: m.pruningPolicy,
 	}
 
 	return m.writeContext, nil
 }
 
+// NewSimulationContext opens a read-write context that is isolated from the write context and whose changes are always
+// discarded on release. It reads a snapshot of the SQL state committed before `height` and writes to temporary tables,
+// so it never blocks, nor is blocked by, the write context. It only has access to the SQL state, so it cannot be
+// committed, nor can it use save points, compute the state hash or index transactions.
+func (m *persistenceModule) NewSimulationContext(height int64) (modules.PersistenceRWContext, error) {
+	conn, err := connectToDatabase(m.config)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := conn.BeginTx(context.TODO(), pgx.TxOptions{
+		IsoLevel:       pgx.RepeatableRead,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.NotDeferrable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	simulationTx, err := newSimulationTx(context.TODO(), tx, m.config.GetNodeSchema(), height)
+	if err != nil {
+		if er := conn.Close(context.TODO()); er != nil {
+			m.logger.Error().Err(er).Msg("Error closing the connection of the simulation context")
+		}
+		return nil, err
+	}
+
+	return &PostgresContext{
+		Height: height,
+		conn:   conn,
+		tx:     simulationTx,
+
+		logger: m.logger,
+
+		simulation: true,
+	}, nil
+}
+
 func (m *persistenceModule) NewReadContext(height int64) (modules.PersistenceReadContext, error) {
 	conn, err := connectToDatabase(m.config)
 	if err != nil {
@@ -194,6 +266,9 @@ func (m *persistenceModule) NewReadContext(height int64) (modules.PersistenceRea
 		blockStore: m.blockStore,
 		txIndexer:  m.txIndexer,
 		stateTrees: m.stateTrees,
+		commitLog:  m.commitLog,
+
+		pruningPolicy: m.pruningPolicy,
 	}, nil
 }
 
@@ -215,13 +290,6 @@ func (m *persistenceModule) NewWriteContext() modules.PersistenceRWContext {
 	return m.writeContext
 }
 
-func initializeBlockStore(blockStorePath string) (kvstore.KVStore, error) {
-	if blockStorePath == "" {
-		return kvstore.NewMemKVStore(), nil
-	}
-	return kvstore.NewKVStore(blockStorePath)
-}
-
 // HACK(olshansky): Simplify and externalize the logic for whether genesis should be populated and
 //
 //	move the if logic out of this file.
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+)
+
+// State proofs are generated against the committed state of the trees, so they are not affected by a block that
+// is being applied concurrently, at any height the trees are retained at (see `tree_versions.go`).
+//
+// TECHDEBT: The keys of the params & flags trees are the hashes of their values, which means the values a param or
+// flag was previously set to remain in the tree. A proof for a param or flag therefore shows it was set to its value
+// at the height included in the value, but not that it was not set again since.
+
+var errReadOnlyTreeStore = errors.New("the store of a state tree opened at a committed height is read-only")
+
+func (p *PostgresContext) GetAccountProof(address []byte, height int64) (*coreTypes.StateProof, error) {
+	return p.getStateProof(accountMerkleTree, address, height)
+}
+
+func (p *PostgresContext) GetPoolProof(name string, height int64) (*coreTypes.StateProof, error) {
+	return p.getStateProof(poolMerkleTree, []byte(name), height)
+}
+
+func (p *PostgresContext) GetActorProof(actorType coreTypes.ActorType, address []byte, height int64) (*coreTypes.StateProof, error) {
+	tree, ok := actorTypeToMerkleTreeName[actorType]
+	if !ok {
+		return nil, fmt.Errorf("no merkle tree found for actor type: %s", actorType)
+	}
+	return p.getStateProof(tree, address, height)
+}
+
+func (p *PostgresContext) GetReportCardProof(servicerAddr []byte, height int64) (*coreTypes.StateProof, error) {
+	return p.getStateProof(reportCardsMerkleTree, servicerAddr, height)
+}
+
+func (p *PostgresContext) GetParamProof(paramName string, height int64) (*coreTypes.StateProof, error) {
+	ctx, tx := p.getCtxAndTx()
+	param := new(coreTypes.Param)
+	row := tx.QueryRow(ctx, types.GetParamOrFlagWithHeightQuery(types.ParamsTableName, paramName, height))
+	if err := row.Scan(&param.Name, &param.Value, &param.Height); err != nil {
+		return nil, err
+	}
+	// The param is hashed into its key the same way it is when the params tree is updated
+	paramBz, err := codec.GetCodec().Marshal(param)
+	if err != nil {
+		return nil, err
+	}
+	return p.getStateProof(paramsMerkleTree, crypto.SHA3Hash(paramBz), height)
+}
+
+func (p *PostgresContext) GetFlagProof(flagName string, height int64) (*coreTypes.StateProof, error) {
+	ctx, tx := p.getCtxAndTx()
+	flag := new(coreTypes.Flag)
+	row := tx.QueryRow(ctx, types.GetParamOrFlagWithHeightQuery(types.FlagsTableName, flagName, height))
+	if err := row.Scan(&flag.Name, &flag.Value, &flag.Enabled, &flag.Height); err != nil {
+		return nil, err
+	}
+	// The flag is hashed into its key the same way it is when the flags tree is updated
+	flagBz, err := codec.GetCodec().Marshal(flag)
+	if err != nil {
+		return nil, err
+	}
+	return p.getStateProof(flagsMerkleTree, crypto.SHA3Hash(flagBz), height)
+}
+
+// getStateProof returns the value of the key in the given tree along with a proof of its membership, or of its
+// non-membership if the key is not in the tree
+func (p *PostgresContext) getStateProof(tree merkleTree, key []byte, height int64) (*coreTypes.StateProof, error) {
+	if height < 0 {
+		return nil, fmt.Errorf("invalid height for a state proof: %d", height)
+	}
+	committedTree, roots, release, err := p.openTreeAtHeight(tree, uint64(height))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	value, err := committedTree.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := committedTree.Prove(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove key %s in the %s tree: %w", hex.EncodeToString(key), merkleTreeToString[tree], err)
+	}
+
+	return &coreTypes.StateProof{
+		Height:    uint64(height),
+		Tree:      tree,
+		Key:       key,
+		Value:     value,
+		Proof:     proof,
+		TreeRoots: roots,
+	}, nil
+}
+
+var _ smt.MapStore = &readOnlyTreeStore{}
+
+// readOnlyTreeStore adapts a read snapshot of the node or value store of a state tree to the store of an SMT
+type readOnlyTreeStore struct {
+	snapshot kvstore.ReadSnapshot
+}
+
+func (store *readOnlyTreeStore) Get(key []byte) ([]byte, error) {
+	value, err := store.snapshot.Get(key)
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		// The SMT only treats a missing key as such if it gets this error
+		return nil, &smt.InvalidKeyError{Key: key}
+	}
+	return value, err
+}
+
+func (store *readOnlyTreeStore) Set(_, _ []byte) error {
+	return errReadOnlyTreeStore
+}
+
+func (store *readOnlyTreeStore) Delete(_ []byte) error {
+	return errReadOnlyTreeStore
+}
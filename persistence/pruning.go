@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+// Limits the number of heights whose blocks are pruned by a single commit (e.g. when pruning is enabled on a
+// node that has been running for a long time) so the commit record stays small. The remaining heights are
+// pruned by the following commits.
+const maxHeightsPrunedPerCommit = 100
+
+// pruningPolicy determines which heights are retained when a block is committed:
+//   - The blocks and transactions of the `keepRecent` most recent heights
+//   - The state at the `keepRecent` most recent heights and at every height that is a multiple of `keepEvery`
+//
+// The state trees are only retained at the `keepRecent` most recent heights, since the nodes orphaned by the
+// updates of the trees are deleted once the heights that still need them are pruned.
+type pruningPolicy struct {
+	keepRecent uint64
+	keepEvery  uint64
+}
+
+// Returns nil if nothing should be pruned
+func newPruningPolicy(cfg *configs.PersistenceConfig) *pruningPolicy {
+	if cfg.GetArchive() || cfg.GetKeepRecent() == 0 {
+		return nil
+	}
+	return &pruningPolicy{
+		keepRecent: cfg.GetKeepRecent(),
+		keepEvery:  cfg.GetKeepEvery(),
+	}
+}
+
+// Returns the lowest height that is retained once the block at `height` is committed
+func (policy *pruningPolicy) minRetainedHeight(height uint64) uint64 {
+	if height+1 <= policy.keepRecent {
+		return 0
+	}
+	return height + 1 - policy.keepRecent
+}
+
+// ensureHeightRetained returns an error wrapping `types.ErrHeightPruned` if the state at `height` was pruned, rather
+// than letting the rows kept at an earlier height silently answer for it. The state is retained at and above the
+// lowest height whose block was not pruned, and at every multiple of `keepEvery` below it.
+func (p *PostgresContext) ensureHeightRetained(height int64) error {
+	if p.pruningPolicy == nil {
+		return nil
+	}
+	minHeight, keepEvery, err := p.GetRetainedHeights()
+	if err != nil {
+		return err
+	}
+	if height >= minHeight || (keepEvery > 0 && uint64(height)%keepEvery == 0) {
+		return nil
+	}
+	if keepEvery > 0 {
+		return fmt.Errorf("%w: height %d is below the minimum retained height %d and is not a multiple of %d", types.ErrHeightPruned, height, minHeight, keepEvery)
+	}
+	return fmt.Errorf("%w: height %d is below the minimum retained height %d", types.ErrHeightPruned, height, minHeight)
+}
+
+// GetRetainedHeights returns the lowest height whose state is fully retained, i.e. the lowest height whose block was
+// not pruned, and the interval at which the state of the heights below it is retained (0 if it is not). The lowest
+// height is looked up once per context.
+func (p *PostgresContext) GetRetainedHeights() (minHeight int64, keepEvery uint64, err error) {
+	if p.pruningPolicy == nil {
+		return 0, 0, nil
+	}
+	if p.minRetainedHeight == nil {
+		ctx, tx := p.getCtxAndTx()
+		var minRetainedHeight int64
+		if err := tx.QueryRow(ctx, types.GetMinimumRetainedHeightQuery()).Scan(&minRetainedHeight); err != nil {
+			return 0, 0, err
+		}
+		p.minRetainedHeight = &minRetainedHeight
+	}
+	return *p.minRetainedHeight, p.pruningPolicy.keepEvery, nil
+}
+
+// prune removes everything that is no longer retained once the block at the height of the context is committed.
+// The SQL rows, the transactions and the orphaned nodes of the state trees are pruned within the context, while
+// the heights whose blocks & tree roots need to be removed are returned so they are removed along with the rest
+// of the commit.
+func (p *PostgresContext) prune() (prunedHeights []uint64, err error) {
+	if p.pruningPolicy == nil {
+		return nil, nil
+	}
+	minRetainedHeight := p.pruningPolicy.minRetainedHeight(uint64(p.Height))
+	if minRetainedHeight == 0 {
+		return nil, nil
+	}
+
+	minHeight, err := p.GetMinimumBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if minHeight+maxHeightsPrunedPerCommit < minRetainedHeight {
+		minRetainedHeight = minHeight + maxHeightsPrunedPerCommit
+	}
+	for height := minHeight; height < minRetainedHeight; height++ {
+		if err := p.txIndexer.PruneHeight(int64(height)); err != nil {
+			return nil, err
+		}
+		prunedHeights = append(prunedHeights, height)
+	}
+
+	if err := p.pruneSQLRows(int64(minRetainedHeight), int64(p.pruningPolicy.keepEvery)); err != nil {
+		return nil, err
+	}
+	if err := p.pruneStateTrees(minRetainedHeight); err != nil {
+		return nil, err
+	}
+
+	if len(prunedHeights) > 0 {
+		p.logger.Debug().Uint64("min_height", minRetainedHeight).Int("num_heights", len(prunedHeights)).Msg("Pruned heights")
+	}
+	return prunedHeights, nil
+}
+
+func (p *PostgresContext) pruneSQLRows(minRetainedHeight, keepEvery int64) error {
+	ctx, tx := p.getCtxAndTx()
+
+	queries := []string{
+		types.PruneBlocksQuery(minRetainedHeight),
+		types.PruneVersionedRowsQuery(types.Account.GetTableName(), types.Account.GetAccountSpecificColName(), minRetainedHeight, keepEvery),
+		types.PruneVersionedRowsQuery(types.Pool.GetTableName(), types.Pool.GetAccountSpecificColName(), minRetainedHeight, keepEvery),
+		types.PruneVersionedRowsQuery(types.ParamsTableName, types.NameCol, minRetainedHeight, keepEvery),
+		types.PruneVersionedRowsQuery(types.FlagsTableName, types.NameCol, minRetainedHeight, keepEvery),
+		types.PruneVersionedRowsQuery(types.ReportCardTableName, types.AddressCol, minRetainedHeight, keepEvery),
+	}
+	for _, actor := range protocolActorSchemas {
+		queries = append(queries, types.PruneVersionedRowsQuery(actor.GetTableName(), types.AddressCol, minRetainedHeight, keepEvery))
+		if actor.GetChainsTableName() != "" {
+			queries = append(queries, types.PruneActorChainsQuery(actor.GetChainsTableName(), actor.GetTableName(), minRetainedHeight))
+		}
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
+// --- Report Card Functions ---
+
+func (p *PostgresContext) GetReportCard(servicerAddr []byte, height int64) (*coreTypes.ReportCard, error) {
+	if err := p.ensureHeightRetained(height); err != nil {
+		return nil, err
+	}
+	ctx, tx := p.getCtxAndTx()
+	var reportCardHex string
+	err := tx.QueryRow(ctx, types.GetReportCardQuery(hex.EncodeToString(servicerAddr), height)).Scan(&reportCardHex)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeReportCard(reportCardHex)
+}
+
+func (p *PostgresContext) SetReportCard(reportCard *coreTypes.ReportCard) error {
+	ctx, tx := p.getCtxAndTx()
+	reportCardBz, err := codec.GetCodec().Marshal(reportCard)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, types.InsertReportCardQuery(reportCard.GetServicerAddress(), hex.EncodeToString(reportCardBz), p.Height))
+	return err
+}
+
+func (p *PostgresContext) getReportCardsUpdated(height int64) ([]*coreTypes.ReportCard, error) {
+	ctx, tx := p.getCtxAndTx()
+	rows, err := tx.Query(ctx, types.GetReportCardsUpdatedAtHeightQuery(height))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reportCards []*coreTypes.ReportCard
+	for rows.Next() {
+		var reportCardHex string
+		if err := rows.Scan(&reportCardHex); err != nil {
+			return nil, err
+		}
+		reportCard, err := decodeReportCard(reportCardHex)
+		if err != nil {
+			return nil, err
+		}
+		reportCards = append(reportCards, reportCard)
+	}
+	return reportCards, rows.Err()
+}
+
+func decodeReportCard(reportCardHex string) (*coreTypes.ReportCard, error) {
+	reportCardBz, err := hex.DecodeString(reportCardHex)
+	if err != nil {
+		return nil, err
+	}
+	reportCard := new(coreTypes.ReportCard)
+	if err := codec.GetCodec().Unmarshal(reportCardBz, reportCard); err != nil {
+		return nil, err
+	}
+	return reportCard, nil
+}
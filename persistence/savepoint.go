@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence/types"
+)
+
+// savePoint captures everything needed to revert the changes made to a `PostgresContext` since it was created:
+// a named SQL save point, plus checkpoints of the journals of the state trees and the tx indexer since those
+// are not part of the SQL transaction.
+type savePoint struct {
+	id   []byte
+	name string
+
+	stateHash           string
+	treeRoots           map[merkleTree][]byte
+	nodeCheckpoints     map[merkleTree]int
+	valueCheckpoints    map[merkleTree]int
+	txIndexerCheckpoint int
+}
+
+func (p *PostgresContext) newSavePoint(id []byte) (*savePoint, error) {
+	sp := &savePoint{
+		id:   id,
+		name: fmt.Sprintf("save_point_%d", len(p.savePoints)),
+
+		stateHash:           p.stateHash,
+		treeRoots:           make(map[merkleTree][]byte, int(numMerkleTrees)),
+		nodeCheckpoints:     make(map[merkleTree]int, int(numMerkleTrees)),
+		valueCheckpoints:    make(map[merkleTree]int, int(numMerkleTrees)),
+		txIndexerCheckpoint: p.txIndexer.Checkpoint(),
+	}
+
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		sp.treeRoots[tree] = p.stateTrees.merkleTrees[tree].Root()
+		sp.nodeCheckpoints[tree] = p.stateTrees.nodeStores[tree].Checkpoint()
+		sp.valueCheckpoints[tree] = p.stateTrees.valueStores[tree].Checkpoint()
+	}
+
+	ctx, tx := p.getCtxAndTx()
+	if _, err := tx.Exec(ctx, types.SavePointQuery(sp.name)); err != nil {
+		return nil, err
+	}
+
+	return sp, nil
+}
+
+// rollbackToSavePoint reverts all the changes made since the save point was created. The save point, and every
+// save point created after it, is discarded.
+func (p *PostgresContext) rollbackToSavePoint(sp *savePoint) error {
+	ctx, tx := p.getCtxAndTx()
+	if _, err := tx.Exec(ctx, types.RollbackToSavePointQuery(sp.name)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, types.ReleaseSavePointQuery(sp.name)); err != nil {
+		return err
+	}
+
+	if err := p.txIndexer.RevertTo(sp.txIndexerCheckpoint); err != nil {
+		return err
+	}
+
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		if err := p.stateTrees.nodeStores[tree].RevertTo(sp.nodeCheckpoints[tree]); err != nil {
+			return err
+		}
+		if err := p.stateTrees.valueStores[tree].RevertTo(sp.valueCheckpoints[tree]); err != nil {
+			return err
+		}
+		p.stateTrees.merkleTrees[tree].SetRoot(sp.treeRoots[tree])
+	}
+	p.stateHash = sp.stateHash
+
+	return nil
+}
+
+// Returns the index of the latest save point with the given id, or -1 if there is none
+func (p *PostgresContext) findSavePoint(id []byte) int {
+	for i := len(p.savePoints) - 1; i >= 0; i-- {
+		if bytes.Equal(p.savePoints[i].id, id) {
+			return i
+		}
+	}
+	return -1
+}
+
+// resetSavePoints discards all the save points once the context is committed or released since the
+// changes can no longer be reverted.
+func (p *PostgresContext) resetSavePoints() {
+	p.savePoints = nil
+	p.txIndexer.ResetJournal()
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		p.stateTrees.nodeStores[tree].ResetJournal()
+		p.stateTrees.valueStores[tree].ResetJournal()
+	}
+}
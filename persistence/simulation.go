@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pokt-network/pocket/persistence/types"
+)
+
+var (
+	_ pgx.Tx           = &simulationTx{}
+	_ pgx.Row          = &errRow{}
+	_ pgx.BatchResults = &errBatchResults{}
+)
+
+// simulationTx redirects the writes of a simulation context to its temporary tables, and rejects the ones it cannot
+// redirect. Every method that runs SQL goes through `types.SimulationWriteQuery`; `Conn` is only meant to close the
+// connection of the context.
+type simulationTx struct {
+	pgx.Tx
+	tableNames map[string]bool
+}
+
+func (tx *simulationTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	nestedTx, err := tx.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &simulationTx{Tx: nestedTx, tableNames: tx.tableNames}, nil
+}
+
+func (tx *simulationTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	simulationSQL, err := types.SimulationWriteQuery(sql, tx.tableNames)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return tx.Tx.Exec(ctx, simulationSQL, arguments...)
+}
+
+func (tx *simulationTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	simulationSQL, err := types.SimulationWriteQuery(sql, tx.tableNames)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Tx.Query(ctx, simulationSQL, args...)
+}
+
+func (tx *simulationTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	simulationSQL, err := types.SimulationWriteQuery(sql, tx.tableNames)
+	if err != nil {
+		return &errRow{err: err}
+	}
+	return tx.Tx.QueryRow(ctx, simulationSQL, args...)
+}
+
+func (tx *simulationTx) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	simulationSQL, err := types.SimulationWriteQuery(sql, tx.tableNames)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Tx.Prepare(ctx, name, simulationSQL)
+}
+
+func (tx *simulationTx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if len(tableName) != 1 || !tx.tableNames[tableName[0]] {
+		return 0, fmt.Errorf("%w: COPY %s", types.ErrSimulationWrite, tableName.Sanitize())
+	}
+	simulationTableName := pgx.Identifier{"pg_temp", types.SimulationTableName(tableName[0])}
+	return tx.Tx.CopyFrom(ctx, simulationTableName, columnNames, rowSrc)
+}
+
+// The queries of a batch cannot be rewritten, so batches are not supported
+func (tx *simulationTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return &errBatchResults{err: fmt.Errorf("%w: batch", types.ErrSimulationWrite)}
+}
+
+type errRow struct {
+	err error
+}
+
+func (r *errRow) Scan(...any) error { return r.err }
+
+type errBatchResults struct {
+	err error
+}
+
+func (r *errBatchResults) Exec() (pgconn.CommandTag, error) { return pgconn.CommandTag{}, r.err }
+func (r *errBatchResults) Query() (pgx.Rows, error)         { return nil, r.err }
+func (r *errBatchResults) QueryRow() pgx.Row                { return &errRow{err: r.err} }
+func (r *errBatchResults) Close() error                     { return r.err }
+
+// newSimulationTx shadows all the state tables of `tx` by temporary views that only see the rows committed before
+// `height`, and the ones written through the returned transaction
+func newSimulationTx(ctx context.Context, tx pgx.Tx, nodeSchema string, height int64) (*simulationTx, error) {
+	tableNames := make(map[string]bool)
+	for _, tableName := range simulationTableNames() {
+		if _, err := tx.Exec(ctx, types.CreateSimulationTableQuery(nodeSchema, tableName)); err != nil {
+			return nil, err
+		}
+		if err := addSimulationTableConstraints(ctx, tx, nodeSchema, tableName); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, types.CreateSimulationViewQuery(nodeSchema, tableName, height)); err != nil {
+			return nil, err
+		}
+		tableNames[tableName] = true
+	}
+	return &simulationTx{Tx: tx, tableNames: tableNames}, nil
+}
+
+func addSimulationTableConstraints(ctx context.Context, tx pgx.Tx, nodeSchema, tableName string) error {
+	rows, err := tx.Query(ctx, types.GetUniqueConstraintsQuery(nodeSchema, tableName))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	constraints := make(map[string]string)
+	for rows.Next() {
+		var name, def string
+		if err := rows.Scan(&name, &def); err != nil {
+			return err
+		}
+		constraints[name] = def
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for name, def := range constraints {
+		if _, err := tx.Exec(ctx, types.AddSimulationTableConstraintQuery(tableName, name, def)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the names of all the SQL tables a simulation can write to, i.e. all the tables of a snapshot but the blocks
+func simulationTableNames() []string {
+	tableNames := make([]string, 0)
+	for _, tableName := range snapshotTableNames() {
+		if tableName != types.BlockTableName {
+			tableNames = append(tableNames, tableName)
+		}
+	}
+	return tableNames
+}
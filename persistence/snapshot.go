@@ -0,0 +1,738 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/utils"
+)
+
+// A snapshot is a gzipped archive of the entire state of the node at the latest committed height. It contains
+// the SQL tables, the node & value stores of every state tree along with the roots of their retained heights,
+// the block store and the tx indexer.
+//
+// The archive starts with `snapshotMagic` followed by a list of sections. Every section starts with a frame
+// containing its name, followed by its data frames, and ends with an empty frame. A frame is a uvarint length
+// prefix followed by that many bytes. The manifest is always the first section of the archive.
+
+const (
+	snapshotMagic         = "POKTSNAP"
+	snapshotFormatVersion = uint32(2)
+
+	snapshotManifestSection   = "manifest"
+	snapshotBlockStoreSection = "block_store"
+	snapshotTxIndexerSection  = "tx_indexer"
+	snapshotSQLSectionPrefix  = "sql/"
+	snapshotTreeSectionPrefix = "trees/"
+	snapshotTreeRootsSection  = "tree_roots"
+	snapshotNodesSuffix       = "_nodes"
+	snapshotValuesSuffix      = "_values"
+
+	// The maximum size of the frames raw data streams (i.e. SQL tables) are split into
+	snapshotDataFrameSize = 64 * 1024 // 64KiB
+	// The maximum size of any frame accepted when reading a snapshot; guards against allocating arbitrary amounts of memory
+	snapshotMaxFrameSize = 64 * 1024 * 1024 // 64MiB
+)
+
+type snapshotManifest struct {
+	Version   uint32            `json:"version"`
+	Height    uint64            `json:"height"`
+	StateHash string            `json:"state_hash"`
+	TreeRoots map[string]string `json:"tree_roots"` // The hex encoded root of each state tree keyed by the tree's name
+}
+
+// CreateSnapshot writes a snapshot of the state at the latest height the state trees are committed at to `w` and
+// returns that height
+func (m *persistenceModule) CreateSnapshot(w io.Writer) (uint64, error) {
+	readCtx, err := m.NewReadContext(-1) // Unknown height
+	if err != nil {
+		return 0, err
+	}
+	p := readCtx.(*PostgresContext)
+	defer p.Close()
+
+	// Take point-in-time views of the key-value stores so they are not affected by the blocks committed while
+	// the snapshot is being created. The committed state of the trees is used since the trees may be ahead of
+	// the latest committed block (e.g. while a proposal block is being applied).
+	blockStoreSnapshot := m.blockStore.NewReadSnapshot()
+	defer blockStoreSnapshot.Release()
+	treeSnapshots := newStateTreesReadSnapshots(m.stateTrees, m.commitLog)
+	defer treeSnapshots.release()
+
+	// The SQL transaction of a block is committed before the state trees, so it contains the block the trees
+	// are committed at
+	height := treeSnapshots.height
+	stateHash, err := p.GetBlockHash(int64(height))
+	if err != nil {
+		return 0, err
+	}
+	if treesStateHash := coreTypes.ComputeStateHash(treeSnapshots.roots); treesStateHash != stateHash {
+		return 0, fmt.Errorf("state trees do not match the block at height %d: %s != %s", height, treesStateHash, stateHash)
+	}
+
+	manifest := &snapshotManifest{
+		Version:   snapshotFormatVersion,
+		Height:    height,
+		StateHash: stateHash,
+		TreeRoots: make(map[string]string, int(numMerkleTrees)),
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		manifest.TreeRoots[merkleTreeToString[tree]] = hex.EncodeToString(treeSnapshots.roots[int(tree)])
+	}
+
+	sw := newSnapshotWriter(w)
+	if err := sw.writeManifest(manifest); err != nil {
+		return 0, err
+	}
+	if err := p.exportSQLTables(sw, int64(height)); err != nil {
+		return 0, err
+	}
+	if err := exportBlockStore(sw, blockStoreSnapshot, height); err != nil {
+		return 0, err
+	}
+	if err := exportTxIndexer(sw, m.txIndexer, height); err != nil {
+		return 0, err
+	}
+	if err := exportStateTrees(sw, treeSnapshots); err != nil {
+		return 0, err
+	}
+	if err := sw.Close(); err != nil {
+		return 0, err
+	}
+
+	m.logger.Info().Uint64("height", height).Str("state_hash", stateHash).Msg("Created state snapshot")
+	return height, nil
+}
+
+// RestoreSnapshot replaces the entire state of the node with the snapshot read from `r` and returns its height.
+// The snapshot is verified against the state hash of the block it was taken at before being committed.
+func (m *persistenceModule) RestoreSnapshot(r io.Reader) (uint64, error) {
+	sr, err := newSnapshotReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	manifest, err := sr.readManifest()
+	if err != nil {
+		return 0, err
+	}
+	treeRoots, err := manifest.validate()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.clearAllState(nil); err != nil {
+		return 0, err
+	}
+
+	if err := m.restoreSnapshot(sr, manifest, treeRoots); err != nil {
+		// Do not leave the node with a partially restored state
+		if releaseErr := m.ReleaseWriteContext(); releaseErr != nil {
+			m.logger.Error().Err(releaseErr).Msg("Error releasing write context after failing to restore snapshot")
+		}
+		if clearErr := m.clearAllState(nil); clearErr != nil {
+			m.logger.Error().Err(clearErr).Msg("Error clearing state after failing to restore snapshot")
+		}
+		return 0, err
+	}
+
+	m.logger.Info().Uint64("height", manifest.Height).Str("state_hash", manifest.StateHash).Msg("Restored state snapshot")
+	return manifest.Height, nil
+}
+
+func (m *persistenceModule) restoreSnapshot(sr *snapshotReader, manifest *snapshotManifest, treeRoots map[merkleTree][]byte) error {
+	rwCtx, err := m.NewRWContext(int64(manifest.Height))
+	if err != nil {
+		return err
+	}
+	p := rwCtx.(*PostgresContext)
+
+	tableNames := make(map[string]bool)
+	for _, tableName := range snapshotTableNames() {
+		tableNames[tableName] = true
+	}
+	treeStores := make(map[string]kvstore.KVStore, 2*int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		// The stores are written to as they are in the snapshot, including the orphaned nodes & values it contains
+		treeStores[snapshotTreeSectionPrefix+merkleTreeToString[tree]+snapshotNodesSuffix] = m.stateTrees.nodeStores[tree].BufferedKVStore
+		treeStores[snapshotTreeSectionPrefix+merkleTreeToString[tree]+snapshotValuesSuffix] = m.stateTrees.valueStores[tree].BufferedKVStore
+	}
+
+	for {
+		section, err := sr.nextSection()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.HasPrefix(section, snapshotSQLSectionPrefix):
+			tableName := strings.TrimPrefix(section, snapshotSQLSectionPrefix)
+			if !tableNames[tableName] {
+				return fmt.Errorf("unknown table in snapshot: %s", tableName)
+			}
+			ctx, tx := p.getCtxAndTx()
+			if _, err := tx.Conn().PgConn().CopyFrom(ctx, sr, types.ImportTableQuery(tableName)); err != nil {
+				return err
+			}
+		case section == snapshotBlockStoreSection:
+			if err := importKVStore(sr, m.blockStore); err != nil {
+				return err
+			}
+		case section == snapshotTxIndexerSection:
+			if err := importTxIndexer(sr, m.txIndexer); err != nil {
+				return err
+			}
+		case treeStores[section] != nil:
+			if err := importKVStore(sr, treeStores[section]); err != nil {
+				return err
+			}
+		case section == snapshotTreeRootsSection:
+			if err := importTreeRoots(sr, m.commitLog, manifest.Height); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown section in snapshot: %s", section)
+		}
+	}
+
+	// Verify the state being restored against the block it was taken at
+	blockHash, err := p.GetBlockHash(int64(manifest.Height))
+	if err != nil {
+		return err
+	}
+	if blockHash != manifest.StateHash {
+		return fmt.Errorf("snapshot state hash does not match the hash of block %d: %s != %s", manifest.Height, manifest.StateHash, blockHash)
+	}
+	if err := verifyBlockInStore(m.blockStore, manifest.Height, manifest.StateHash); err != nil {
+		return err
+	}
+
+	emptyRoot := make([]byte, sha256.Size)
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		root := treeRoots[tree]
+		nodeStore, valueStore := m.stateTrees.nodeStores[tree], m.stateTrees.valueStores[tree]
+		// IMPROVE: Walk the entire tree to verify the integrity of every node rather than the root only
+		if !bytes.Equal(root, emptyRoot) {
+			if _, err := nodeStore.Get(root); err != nil {
+				return fmt.Errorf("root of the %s tree is missing from the snapshot: %w", merkleTreeToString[tree], err)
+			}
+		}
+		m.stateTrees.merkleTrees[tree] = smt.ImportSparseMerkleTree(nodeStore, valueStore, sha256.New(), root)
+	}
+	if stateHash := p.getStateHash(); stateHash != manifest.StateHash {
+		return fmt.Errorf("restored state trees do not match the snapshot state hash: %s != %s", stateHash, manifest.StateHash)
+	}
+
+	ctx, tx := p.getCtxAndTx()
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	// The key-value stores buffer their writes until they are flushed
+	roots := make([][]byte, 0, int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		roots = append(roots, treeRoots[tree])
+	}
+	if err := p.flushCommittedState(manifest.Height, roots, nil); err != nil {
+		return err
+	}
+
+	return m.ReleaseWriteContext()
+}
+
+// Returns the names of all the SQL tables included in a snapshot
+func snapshotTableNames() []string {
+	tableNames := []string{
+		types.AccountTableName,
+		types.PoolTableName,
+		types.ParamsTableName,
+		types.FlagsTableName,
+		types.ReportCardTableName,
+		types.DoubleSignEvidenceTableName,
+		types.BlockTableName,
+	}
+	for _, actor := range protocolActorSchemas {
+		tableNames = append(tableNames, actor.GetTableName())
+		if actor.GetChainsTableName() != "" {
+			tableNames = append(tableNames, actor.GetChainsTableName())
+		}
+	}
+	return tableNames
+}
+
+func (p *PostgresContext) exportSQLTables(sw *snapshotWriter, height int64) error {
+	ctx, tx := p.getCtxAndTx()
+	for _, tableName := range snapshotTableNames() {
+		if err := sw.beginSection(snapshotSQLSectionPrefix + tableName); err != nil {
+			return err
+		}
+		if _, err := tx.Conn().PgConn().CopyTo(ctx, sw, types.ExportTableQuery(tableName, height)); err != nil {
+			return err
+		}
+		if err := sw.endSection(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportBlockStore(sw *snapshotWriter, blockStore kvstore.KVReader, height uint64) error {
+	it, err := blockStore.Iterator(nil, nil, nil, false)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	if err := sw.beginSection(snapshotBlockStoreSection); err != nil {
+		return err
+	}
+	for it.Next() {
+		// Blocks above the snapshot height may be present if a block was committed while the snapshot was being created
+		if utils.HeightFromBytes(it.Key()) > height {
+			continue
+		}
+		if err := sw.writeKV(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return sw.endSection()
+}
+
+func exportTxIndexer(sw *snapshotWriter, txIndexer indexer.TxIndexer, height uint64) error {
+	if err := sw.beginSection(snapshotTxIndexerSection); err != nil {
+		return err
+	}
+	for h := int64(0); h <= int64(height); h++ {
+		txResults, err := txIndexer.GetByHeight(h, false)
+		if err != nil {
+			return err
+		}
+		for _, txResult := range txResults {
+			txResultBz, err := txResult.Bytes()
+			if err != nil {
+				return err
+			}
+			if err := sw.writeFrame(txResultBz); err != nil {
+				return err
+			}
+		}
+	}
+	return sw.endSection()
+}
+
+// stateTreesReadSnapshots are point-in-time views of the committed state of the trees: their node & value stores
+// along with the roots of every retained height
+type stateTreesReadSnapshots struct {
+	height      uint64
+	roots       [][]byte               // The roots at `height`, ordered by `merkleTree`
+	nodeStores  []kvstore.ReadSnapshot // Ordered by `merkleTree`
+	valueStores []kvstore.ReadSnapshot // Ordered by `merkleTree`
+	commitLog   kvstore.ReadSnapshot
+}
+
+func newStateTreesReadSnapshots(trees *stateTrees, commitLog kvstore.KVStore) *stateTreesReadSnapshots {
+	trees.committedMu.RLock()
+	defer trees.committedMu.RUnlock()
+
+	snapshots := &stateTreesReadSnapshots{
+		height:      trees.committedHeight,
+		roots:       make([][]byte, 0, int(numMerkleTrees)),
+		nodeStores:  make([]kvstore.ReadSnapshot, 0, int(numMerkleTrees)),
+		valueStores: make([]kvstore.ReadSnapshot, 0, int(numMerkleTrees)),
+		commitLog:   commitLog.NewReadSnapshot(),
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		snapshots.roots = append(snapshots.roots, trees.committedRoots[tree])
+		snapshots.nodeStores = append(snapshots.nodeStores, trees.nodeStores[tree].NewFlushedReadSnapshot())
+		snapshots.valueStores = append(snapshots.valueStores, trees.valueStores[tree].NewFlushedReadSnapshot())
+	}
+	return snapshots
+}
+
+func (snapshots *stateTreesReadSnapshots) release() {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		snapshots.nodeStores[int(tree)].Release()
+		snapshots.valueStores[int(tree)].Release()
+	}
+	snapshots.commitLog.Release()
+}
+
+func exportStateTrees(sw *snapshotWriter, trees *stateTreesReadSnapshots) error {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		treeName := merkleTreeToString[tree]
+		if err := exportKVStore(sw, snapshotTreeSectionPrefix+treeName+snapshotNodesSuffix, trees.nodeStores[int(tree)]); err != nil {
+			return err
+		}
+		if err := exportKVStore(sw, snapshotTreeSectionPrefix+treeName+snapshotValuesSuffix, trees.valueStores[int(tree)]); err != nil {
+			return err
+		}
+	}
+	return exportKVRange(sw, snapshotTreeRootsSection, trees.commitLog, treeRootsKeyPrefix, treeRootsKey(trees.height+1))
+}
+
+func exportKVStore(sw *snapshotWriter, section string, store kvstore.KVReader) error {
+	return exportKVRange(sw, section, store, nil, nil)
+}
+
+// Exports the keys with the given prefix up to `end`, exclusive, where a nil `end` leaves the range unbounded
+func exportKVRange(sw *snapshotWriter, section string, store kvstore.KVReader, prefix, end []byte) error {
+	it, err := store.Iterator(prefix, nil, end, false)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	if err := sw.beginSection(section); err != nil {
+		return err
+	}
+	for it.Next() {
+		if err := sw.writeKV(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return sw.endSection()
+}
+
+func importKVStore(sr *snapshotReader, store kvstore.KVStore) error {
+	for {
+		key, value, err := sr.readKV()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := store.Set(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+// Imports the roots of the heights of the state trees retained by the snapshot into the commit log
+func importTreeRoots(sr *snapshotReader, commitLog kvstore.KVStore, height uint64) error {
+	for {
+		key, rootsBz, err := sr.readKV()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		roots := new(committedRoots)
+		if err := json.Unmarshal(rootsBz, roots); err != nil {
+			return err
+		}
+		if roots.Height > height || !bytes.Equal(key, treeRootsKey(roots.Height)) {
+			return fmt.Errorf("invalid state tree roots in snapshot at height %d", roots.Height)
+		}
+		if len(roots.TreeRoots) != int(numMerkleTrees) {
+			return fmt.Errorf("expected %d state tree roots at height %d but got %d", int(numMerkleTrees), roots.Height, len(roots.TreeRoots))
+		}
+		if err := commitLog.Set(key, rootsBz); err != nil {
+			return err
+		}
+	}
+}
+
+func importTxIndexer(sr *snapshotReader, txIndexer indexer.TxIndexer) error {
+	for {
+		txResultBz, err := sr.readDataFrame()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		txResult, err := new(indexer.TxRes).FromBytes(txResultBz)
+		if err != nil {
+			return err
+		}
+		if err := txIndexer.Index(txResult); err != nil {
+			return err
+		}
+	}
+}
+
+func verifyBlockInStore(blockStore kvstore.KVStore, height uint64, stateHash string) error {
+	blockBz, err := blockStore.Get(utils.HeightToBytes(height))
+	if err != nil {
+		return fmt.Errorf("block %d is missing from the snapshot: %w", height, err)
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return err
+	}
+	if block.BlockHeader.GetStateHash() != stateHash {
+		return fmt.Errorf("snapshot state hash does not match the block %d in the block store: %s != %s", height, stateHash, block.BlockHeader.GetStateHash())
+	}
+	return nil
+}
+
+// Validates the manifest and returns the decoded root of each state tree
+func (manifest *snapshotManifest) validate() (map[merkleTree][]byte, error) {
+	if manifest.Version != snapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version: %d", manifest.Version)
+	}
+
+	treeRoots := make(map[merkleTree][]byte, int(numMerkleTrees))
+	roots := make([][]byte, 0, int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		root, err := hex.DecodeString(manifest.TreeRoots[merkleTreeToString[tree]])
+		if err != nil {
+			return nil, err
+		}
+		if len(root) != sha256.Size {
+			return nil, fmt.Errorf("invalid root for the %s tree in the snapshot manifest", merkleTreeToString[tree])
+		}
+		treeRoots[tree] = root
+		roots = append(roots, root)
+	}
+
+	// Same computation as `getStateHash`
+	stateHash := sha256.Sum256(bytes.Join(roots, []byte{}))
+	if hex.EncodeToString(stateHash[:]) != manifest.StateHash {
+		return nil, fmt.Errorf("snapshot tree roots do not match the snapshot state hash %s", manifest.StateHash)
+	}
+
+	return treeRoots, nil
+}
+
+// snapshotWriter writes the sections of a snapshot archive; it implements `io.Writer` so raw data
+// streams can be written directly into the current section.
+type snapshotWriter struct {
+	gz *gzip.Writer
+	w  *bufio.Writer
+}
+
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	gz := gzip.NewWriter(w)
+	return &snapshotWriter{
+		gz: gz,
+		w:  bufio.NewWriter(gz),
+	}
+}
+
+func (sw *snapshotWriter) writeManifest(manifest *snapshotManifest) error {
+	if _, err := sw.w.WriteString(snapshotMagic); err != nil {
+		return err
+	}
+	manifestBz, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := sw.beginSection(snapshotManifestSection); err != nil {
+		return err
+	}
+	if err := sw.writeFrame(manifestBz); err != nil {
+		return err
+	}
+	return sw.endSection()
+}
+
+func (sw *snapshotWriter) beginSection(name string) error {
+	return sw.writeFrame([]byte(name))
+}
+
+func (sw *snapshotWriter) endSection() error {
+	return sw.writeFrame(nil)
+}
+
+func (sw *snapshotWriter) writeFrame(bz []byte) error {
+	var lenBz [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBz[:], uint64(len(bz)))
+	if _, err := sw.w.Write(lenBz[:n]); err != nil {
+		return err
+	}
+	_, err := sw.w.Write(bz)
+	return err
+}
+
+// Each key-value pair is written as a single frame: the uvarint length of the key, the key and the value
+func (sw *snapshotWriter) writeKV(key, value []byte) error {
+	var lenBz [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBz[:], uint64(len(key)))
+	frame := make([]byte, 0, n+len(key)+len(value))
+	frame = append(frame, lenBz[:n]...)
+	frame = append(frame, key...)
+	frame = append(frame, value...)
+	return sw.writeFrame(frame)
+}
+
+// Write splits `p` into data frames of the current section
+func (sw *snapshotWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		frameSize := len(p)
+		if frameSize > snapshotDataFrameSize {
+			frameSize = snapshotDataFrameSize
+		}
+		if err := sw.writeFrame(p[:frameSize]); err != nil {
+			return written, err
+		}
+		written += frameSize
+		p = p[frameSize:]
+	}
+	return written, nil
+}
+
+func (sw *snapshotWriter) Close() error {
+	if err := sw.w.Flush(); err != nil {
+		return err
+	}
+	return sw.gz.Close()
+}
+
+// snapshotReader reads the sections of a snapshot archive; it implements `io.Reader` over the data
+// frames of the current section so raw data streams can be read directly from it.
+type snapshotReader struct {
+	r *bufio.Reader
+	// Data of the current frame that was not consumed by `Read` yet
+	pending []byte
+	// Whether the end of the current section was reached
+	sectionDone bool
+}
+
+func newSnapshotReader(r io.Reader) (*snapshotReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sr := &snapshotReader{
+		r:           bufio.NewReader(gz),
+		sectionDone: true,
+	}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(sr.r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != snapshotMagic {
+		return nil, fmt.Errorf("not a snapshot archive")
+	}
+
+	return sr, nil
+}
+
+func (sr *snapshotReader) readManifest() (*snapshotManifest, error) {
+	section, err := sr.nextSection()
+	if err != nil {
+		return nil, err
+	}
+	if section != snapshotManifestSection {
+		return nil, fmt.Errorf("expected the snapshot manifest but got section %s", section)
+	}
+	manifestBz, err := sr.readDataFrame()
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(snapshotManifest)
+	if err := json.Unmarshal(manifestBz, manifest); err != nil {
+		return nil, err
+	}
+	if _, err := sr.readDataFrame(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after the snapshot manifest")
+	}
+	return manifest, nil
+}
+
+// nextSection skips whatever is left of the current section and returns the name of the next one,
+// or `io.EOF` if there are no sections left
+func (sr *snapshotReader) nextSection() (string, error) {
+	for !sr.sectionDone {
+		if _, err := sr.readDataFrame(); err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+	}
+	sr.pending = nil
+
+	name, err := sr.readFrame()
+	if err != nil {
+		return "", err
+	}
+	if len(name) == 0 {
+		return "", fmt.Errorf("snapshot section without a name")
+	}
+	sr.sectionDone = false
+	return string(name), nil
+}
+
+// readDataFrame returns the next data frame of the current section, or `io.EOF` at the end of the section
+func (sr *snapshotReader) readDataFrame() ([]byte, error) {
+	if sr.sectionDone {
+		return nil, io.EOF
+	}
+	frame, err := sr.readFrame()
+	if err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if len(frame) == 0 {
+		sr.sectionDone = true
+		return nil, io.EOF
+	}
+	return frame, nil
+}
+
+func (sr *snapshotReader) readKV() (key, value []byte, err error) {
+	frame, err := sr.readDataFrame()
+	if err != nil {
+		return nil, nil, err
+	}
+	keyLen, n := binary.Uvarint(frame)
+	if n <= 0 || uint64(len(frame)-n) < keyLen {
+		return nil, nil, fmt.Errorf("malformed key-value pair in snapshot")
+	}
+	return frame[n : n+int(keyLen)], frame[n+int(keyLen):], nil
+}
+
+func (sr *snapshotReader) readFrame() ([]byte, error) {
+	frameLen, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return nil, err
+	}
+	if frameLen > snapshotMaxFrameSize {
+		return nil, fmt.Errorf("snapshot frame of %d bytes exceeds the maximum of %d bytes", frameLen, snapshotMaxFrameSize)
+	}
+	frame := make([]byte, frameLen)
+	if _, err := io.ReadFull(sr.r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// Read reads the data frames of the current section as a continuous stream
+func (sr *snapshotReader) Read(p []byte) (int, error) {
+	for len(sr.pending) == 0 {
+		frame, err := sr.readDataFrame()
+		if err != nil {
+			return 0, err
+		}
+		sr.pending = frame
+	}
+	n := copy(p, sr.pending)
+	sr.pending = sr.pending[n:]
+	return n, nil
+}
@@ -1,10 +1,11 @@
 package persistence
 
 import (
-	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"sync"
 
 	"github.com/celestiaorg/smt"
 	"github.com/pokt-network/pocket/persistence/kvstore"
@@ -14,40 +15,48 @@ import (
 	"github.com/pokt-network/pocket/shared/crypto"
 )
 
-type merkleTree float64
+// A list of Merkle Trees used to maintain the state hash. The order of the trees, which defines the order in which
+// their roots are concatenated together to generate the state hash, is defined by `coreTypes.StateTree` since light
+// clients need it to verify state proofs.
+type merkleTree = coreTypes.StateTree
 
 type stateTrees struct {
 	merkleTrees map[merkleTree]*smt.SparseMerkleTree
 
 	// nodeStores & valueStore are part of the SMT, but references are kept below for convenience
-	// and debugging purposes
-	nodeStores  map[merkleTree]kvstore.KVStore
-	valueStores map[merkleTree]kvstore.KVStore
+	// and debugging purposes. They are journaled so the trees can be reverted to a save point, and
+	// versioned so the trees can be read at previous heights (see `tree_versions.go`).
+	nodeStores  map[merkleTree]*treeNodeStore
+	valueStores map[merkleTree]*treeValueStore
+
+	// committedMu guards the flushed contents of the stores along with the committed roots & height, so they are
+	// always read consistently with each other (e.g. to generate state proofs)
+	committedMu sync.RWMutex
+	// The roots of the trees as of the last committed block; uncommitted changes are reverted to these roots
+	committedRoots  map[merkleTree][]byte
+	committedHeight uint64
 }
 
-// A list of Merkle Trees used to maintain the state hash.
 const (
-	// IMPORTANT: The order in which these trees are defined is important and strict. It implicitly
-	// defines the index of the root hash each independent as they are concatenated together
-	// to generate the state hash.
-
 	// Actor Merkle Trees
-	appMerkleTree merkleTree = iota
-	valMerkleTree
-	fishMerkleTree
-	servicerMerkleTree
+	appMerkleTree      = coreTypes.StateTreeApp
+	valMerkleTree      = coreTypes.StateTreeValidator
+	fishMerkleTree     = coreTypes.StateTreeFisherman
+	servicerMerkleTree = coreTypes.StateTreeServicer
 
 	// Account Merkle Trees
-	accountMerkleTree
-	poolMerkleTree
+	accountMerkleTree = coreTypes.StateTreeAccount
+	poolMerkleTree    = coreTypes.StateTreePool
 
 	// Data Merkle Trees
-	transactionsMerkleTree
-	paramsMerkleTree
-	flagsMerkleTree
-
-	// Used for iteration purposes only; see https://stackoverflow.com/a/64178235/768439 as a reference
-	numMerkleTrees
+	transactionsMerkleTree       = coreTypes.StateTreeTransactions
+	paramsMerkleTree             = coreTypes.StateTreeParams
+	flagsMerkleTree              = coreTypes.StateTreeFlags
+	reportCardsMerkleTree        = coreTypes.StateTreeReportCards
+	doubleSignEvidenceMerkleTree = coreTypes.StateTreeDoubleSignEvidence
+
+	// Used for iteration purposes only
+	numMerkleTrees = coreTypes.NumStateTrees
 )
 
 var merkleTreeToString = map[merkleTree]string{
@@ -59,9 +68,11 @@ var merkleTreeToString = map[merkleTree]string{
 	accountMerkleTree: "account",
 	poolMerkleTree:    "pool",
 
-	transactionsMerkleTree: "transactions",
-	paramsMerkleTree:       "params",
-	flagsMerkleTree:        "flags",
+	transactionsMerkleTree:       "transactions",
+	paramsMerkleTree:             "params",
+	flagsMerkleTree:              "flags",
+	reportCardsMerkleTree:        "report_cards",
+	doubleSignEvidenceMerkleTree: "double_sign_evidence",
 }
 
 var actorTypeToMerkleTreeName = map[coreTypes.ActorType]merkleTree{
@@ -85,50 +96,52 @@ var merkleTreeToActorTypeName = map[merkleTree]coreTypes.ActorType{
 	servicerMerkleTree: coreTypes.ActorType_ACTOR_TYPE_SERVICER,
 }
 
-func newStateTrees(treesStoreDir string) (*stateTrees, error) {
-	if treesStoreDir == "" {
-		return newMemStateTrees()
-	}
-
+// newStateTrees opens the node & value stores of every state tree in `treesStoreDir` with the given backend, or
+// in memory if `treesStoreDir` is empty
+func newStateTrees(treesStoreDir, backend string, opts kvstore.BackendOptions) (*stateTrees, error) {
 	stateTrees := &stateTrees{
 		merkleTrees: make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
-		nodeStores:  make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-		valueStores: make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
+		nodeStores:  make(map[merkleTree]*treeNodeStore, int(numMerkleTrees)),
+		valueStores: make(map[merkleTree]*treeValueStore, int(numMerkleTrees)),
+
+		committedRoots: emptyTreeRoots(),
+	}
+
+	storePath := func(tree merkleTree, suffix string) string {
+		if treesStoreDir == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s/%s_%s", treesStoreDir, merkleTreeToString[tree], suffix)
 	}
 
 	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
-		nodeStore, err := kvstore.NewKVStore(fmt.Sprintf("%s/%s_nodes", treesStoreDir, merkleTreeToString[tree]))
+		nodeStore, err := kvstore.OpenKVStore(backend, storePath(tree, "nodes"), opts)
 		if err != nil {
 			return nil, err
 		}
-		valueStore, err := kvstore.NewKVStore(fmt.Sprintf("%s/%s_values", treesStoreDir, merkleTreeToString[tree]))
+		valueStore, err := kvstore.OpenKVStore(backend, storePath(tree, "values"), opts)
 		if err != nil {
 			return nil, err
 		}
-		stateTrees.nodeStores[tree] = nodeStore
-		stateTrees.valueStores[tree] = valueStore
-		stateTrees.merkleTrees[tree] = smt.NewSparseMerkleTree(nodeStore, valueStore, sha256.New())
-	}
-	return stateTrees, nil
-}
-
-func newMemStateTrees() (*stateTrees, error) {
-	stateTrees := &stateTrees{
-		merkleTrees: make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
-		nodeStores:  make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-		valueStores: make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-	}
-	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
-		nodeStore := kvstore.NewMemKVStore() // For testing, `smt.NewSimpleMap()` can be used as well
-		valueStore := kvstore.NewMemKVStore()
-		stateTrees.nodeStores[tree] = nodeStore
-		stateTrees.valueStores[tree] = valueStore
-		stateTrees.merkleTrees[tree] = smt.NewSparseMerkleTree(nodeStore, valueStore, sha256.New())
+		stateTrees.nodeStores[tree] = &treeNodeStore{
+			BufferedKVStore: kvstore.NewBufferedKVStore(nodeStore),
+			flushed:         nodeStore,
+		}
+		stateTrees.valueStores[tree] = &treeValueStore{
+			BufferedKVStore: kvstore.NewBufferedKVStore(valueStore),
+		}
+		stateTrees.merkleTrees[tree] = smt.NewSparseMerkleTree(stateTrees.nodeStores[tree], stateTrees.valueStores[tree], sha256.New())
 	}
 	return stateTrees, nil
 }
 
+// updateMerkleTrees applies the state changes made at the height of the context to the state trees. The trees write
+// to buffered stores, so none of their writes hit Badger until the block is committed, at which point the writes of
+// each store are applied in a single write batch.
 func (p *PostgresContext) updateMerkleTrees() (string, error) {
+	// The nodes orphaned by the updates are retained as of this height
+	p.stateTrees.setUpdateHeight(uint64(p.Height))
+
 	// Update all the merkle trees
 	for treeType := merkleTree(0); treeType < numMerkleTrees; treeType++ {
 		switch treeType {
@@ -165,6 +178,14 @@ func (p *PostgresContext) updateMerkleTrees() (string, error) {
 			if err := p.updateFlagsTree(); err != nil {
 				return "", err
 			}
+		case reportCardsMerkleTree:
+			if err := p.updateReportCardsTree(); err != nil {
+				return "", err
+			}
+		case doubleSignEvidenceMerkleTree:
+			if err := p.updateDoubleSignEvidenceTree(); err != nil {
+				return "", err
+			}
 
 		// Default
 		default:
@@ -175,6 +196,49 @@ func (p *PostgresContext) updateMerkleTrees() (string, error) {
 	return p.getStateHash(), nil
 }
 
+// GetStateChanges returns the values of the state written at the height of the context, i.e. the values that
+// `updateMerkleTrees` applies to the state trees
+func (p *PostgresContext) GetStateChanges() (*coreTypes.StateChanges, error) {
+	accounts, err := p.GetAccountsUpdated(p.Height)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := p.GetPoolsUpdated(p.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	actors := make([]*coreTypes.Actor, 0)
+	for treeType := merkleTree(0); treeType < numMerkleTrees; treeType++ {
+		actorType, ok := merkleTreeToActorTypeName[treeType]
+		if !ok {
+			continue
+		}
+		updatedActors, err := p.getActorsUpdatedAtHeight(actorType, p.Height)
+		if err != nil {
+			return nil, err
+		}
+		actors = append(actors, updatedActors...)
+	}
+
+	params, err := p.getParamsUpdated(p.Height)
+	if err != nil {
+		return nil, err
+	}
+	flags, err := p.getFlagsUpdated(p.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreTypes.StateChanges{
+		Accounts: accounts,
+		Pools:    pools,
+		Actors:   actors,
+		Params:   params,
+		Flags:    flags,
+	}, nil
+}
+
 func (p *PostgresContext) getStateHash() string {
 	// Get the root of each Merkle Tree
 	roots := make([][]byte, 0)
@@ -183,11 +247,7 @@ func (p *PostgresContext) getStateHash() string {
 	}
 
 	// Get the state hash
-	rootsConcat := bytes.Join(roots, []byte{})
-	stateHash := sha256.Sum256(rootsConcat)
-
-	// Convert the array to a slice and return it
-	return hex.EncodeToString(stateHash[:])
+	return coreTypes.ComputeStateHash(roots)
 }
 
 // Actor Tree Helpers
@@ -358,3 +418,46 @@ func (p *PostgresContext) updateFlagsTree() error {
 
 	return nil
 }
+
+func (p *PostgresContext) updateReportCardsTree() error {
+	reportCards, err := p.getReportCardsUpdated(p.Height)
+	if err != nil {
+		return err
+	}
+
+	for _, reportCard := range reportCards {
+		bzAddr, err := hex.DecodeString(reportCard.GetServicerAddress())
+		if err != nil {
+			return err
+		}
+
+		reportCardBz, err := codec.GetCodec().Marshal(reportCard)
+		if err != nil {
+			return err
+		}
+
+		if _, err := p.stateTrees.merkleTrees[reportCardsMerkleTree].Update(bzAddr, reportCardBz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PostgresContext) updateDoubleSignEvidenceTree() error {
+	keys, err := p.getDoubleSignEvidenceKeysUpdated(p.Height)
+	if err != nil {
+		return err
+	}
+
+	// The value is the height the evidence was handled at
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, uint64(p.Height))
+	for _, key := range keys {
+		if _, err := p.stateTrees.merkleTrees[doubleSignEvidenceMerkleTree].Update(key, heightBz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/pokt-network/pocket/shared/core/types"
@@ -36,3 +37,24 @@ func TestGetAllStakedActors(t *testing.T) {
 	require.Equal(t, genesisStateNumApplications, actualApplications)
 	require.Equal(t, genesisStateNumFishermen, actualFishermen)
 }
+
+func TestGetActor(t *testing.T) {
+	db := NewTestPostgresContext(t, 0)
+
+	servicer, err := createAndInsertDefaultTestServicer(db)
+	require.NoError(t, err)
+	addrBz, err := hex.DecodeString(servicer.Address)
+	require.NoError(t, err)
+
+	actor, err := db.GetActor(types.ActorType_ACTOR_TYPE_SERVICER, addrBz, db.Height)
+	require.NoError(t, err)
+	require.Equal(t, types.ActorType_ACTOR_TYPE_SERVICER, actor.ActorType)
+	require.Equal(t, servicer.Address, actor.Address)
+	require.Equal(t, servicer.StakedAmount, actor.StakedAmount)
+	require.Equal(t, servicer.Chains, actor.Chains)
+
+	// The servicer is not an actor of any other type
+	actor, err = db.GetActor(types.ActorType_ACTOR_TYPE_VAL, addrBz, db.Height)
+	require.NoError(t, err)
+	require.Nil(t, actor)
+}
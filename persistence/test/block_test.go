@@ -1,8 +1,10 @@
 package test
 
 import (
+	"encoding/hex"
 	"testing"
 
+	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,3 +26,34 @@ func TestGetBlockBlockHash(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, blockHash, "")
 }
+
+func TestGetTransactionByHash_PendingTransactionIsInvisibleUntilCommit(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	txResult := getRandomTxResult(1)
+	require.NoError(t, db.IndexTransaction(modules.TxResult(txResult)))
+	txHash, err := txResult.Hash()
+	require.NoError(t, err)
+
+	// The transactions of the block being applied are not visible to the queries served outside of consensus
+	requireTxIndexed(t, txResult, false)
+	indexedTxResult, err := testPersistenceMod.GetTransactionByHash(hex.EncodeToString(txHash))
+	require.NoError(t, err)
+	require.Nil(t, indexedTxResult)
+	txResults, _, err := testPersistenceMod.GetTransactionsByHeight(1, "", 10, false)
+	require.NoError(t, err)
+	require.Empty(t, txResults)
+
+	// They become visible once the block is committed
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+
+	requireTxIndexed(t, txResult, true)
+	indexedTxResult, err = testPersistenceMod.GetTransactionByHash(hex.EncodeToString(txHash))
+	require.NoError(t, err)
+	require.Equal(t, txResult.GetTx(), indexedTxResult.GetTx())
+	txResults, _, err = testPersistenceMod.GetTransactionsByHeight(1, "", 10, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 1)
+}
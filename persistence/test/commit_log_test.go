@@ -0,0 +1,82 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+// TECHDEBT: Simulate a node stopping in the middle of a commit (i.e. after the commit record is logged but before
+// it is applied) once the test package can inject failures into the persistence module.
+
+func TestCommitLog_StateIsConsistentAcrossRestarts(t *testing.T) {
+	persistenceCfg := newOnDiskPersistenceConfig(t, "commit_log_test_schema")
+
+	persistenceMod := newTestPersistenceModuleWithConfig(persistenceCfg)
+	require.NotNil(t, persistenceMod)
+
+	var stateHash string
+	for height := int64(1); height <= 3; height++ {
+		rwCtx, err := persistenceMod.NewRWContext(height)
+		require.NoError(t, err)
+		db := rwCtx.(*persistence.PostgresContext)
+
+		_, _, err = callRandomDatabaseModifierFunc(db, true)
+		require.NoError(t, err)
+		require.NoError(t, db.IndexTransaction(modules.TxResult(getRandomTxResult(height))))
+
+		stateHash, err = db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+	}
+
+	// Apply a block that is never committed; none of its writes should survive the restart
+	rwCtx, err := persistenceMod.NewRWContext(4)
+	require.NoError(t, err)
+	_, _, err = callRandomDatabaseModifierFunc(rwCtx.(*persistence.PostgresContext), true)
+	require.NoError(t, err)
+	_, err = rwCtx.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, rwCtx.Release())
+
+	require.NoError(t, persistenceMod.Stop())
+
+	// Startup fails if the state trees reopened from disk do not match the latest block
+	persistenceMod = newTestPersistenceModuleWithConfig(persistenceCfg)
+	require.NotNil(t, persistenceMod)
+	t.Cleanup(func() {
+		require.NoError(t, persistenceMod.Stop())
+	})
+
+	readCtx, err := persistenceMod.NewReadContext(3)
+	require.NoError(t, err)
+	defer readCtx.Close()
+
+	latestHeight, err := readCtx.GetMaximumBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), latestHeight)
+
+	blockHash, err := readCtx.GetBlockHash(3)
+	require.NoError(t, err)
+	require.Equal(t, stateHash, blockHash)
+}
+
+func newOnDiskPersistenceConfig(t *testing.T, schema string) *configs.PersistenceConfig {
+	dataDir := t.TempDir()
+	return &configs.PersistenceConfig{
+		PostgresUrl:       testDatabaseUrl,
+		NodeSchema:        schema,
+		BlockStorePath:    filepath.Join(dataDir, "block_store"),
+		TxIndexerPath:     filepath.Join(dataDir, "tx_indexer"),
+		TreesStoreDir:     filepath.Join(dataDir, "trees"),
+		MaxConnsCount:     4,
+		MinConnsCount:     0,
+		MaxConnLifetime:   "1h",
+		MaxConnIdleTime:   "30m",
+		HealthCheckPeriod: "5m",
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSetDoubleSignEvidence(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	validatorAddr, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	exists, err := db.GetDoubleSignEvidenceExists(validatorAddr, 1, 2, 3, 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	stateHashBefore, err := db.ComputeStateHash()
+	require.NoError(t, err)
+
+	err = db.SetDoubleSignEvidence(validatorAddr, 1, 2, 3)
+	require.NoError(t, err)
+
+	exists, err = db.GetDoubleSignEvidenceExists(validatorAddr, 1, 2, 3, 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// The evidence is only handled from the height of the context onwards
+	exists, err = db.GetDoubleSignEvidenceExists(validatorAddr, 1, 2, 3, 0)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// The evidence of another round is distinct
+	exists, err = db.GetDoubleSignEvidenceExists(validatorAddr, 1, 3, 3, 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// The evidence is part of the state
+	stateHashAfter, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NotEqual(t, stateHashBefore, stateHashAfter)
+
+	// The same evidence cannot be stored twice
+	err = db.SetDoubleSignEvidence(validatorAddr, 1, 2, 3)
+	require.Error(t, err)
+}
@@ -109,6 +109,60 @@ func TestPersistenceContextMultipleParallelReads(t *testing.T) {
 	require.NoError(t, readContext3.Close())
 }
 
+func TestPersistenceSimulationContext(t *testing.T) {
+	prepareAndCleanContext(t)
+
+	poolName := "fake"
+	originalAmount := "15"
+	simulatedAmount := "10"
+	writtenAmount := "20"
+
+	// Commit a pool at height 0
+	context, err := testPersistenceMod.NewRWContext(0)
+	require.NoError(t, err)
+	require.NoError(t, context.InsertPool(poolName, originalAmount))
+	require.NoError(t, context.Commit([]byte("proposerAddr"), []byte("quorumCert")))
+
+	// A simulation context can be opened alongside the write context
+	writeContext, err := testPersistenceMod.NewRWContext(1)
+	require.NoError(t, err)
+	simulationContext, err := testPersistenceMod.NewSimulationContext(1)
+	require.NoError(t, err)
+
+	// The simulation context writes the row the write context has pending without waiting on it
+	require.NoError(t, writeContext.SetPoolAmount(poolName, writtenAmount))
+	require.NoError(t, simulationContext.SetPoolAmount(poolName, simulatedAmount))
+	simulationContextAmount, err := simulationContext.GetPoolAmount(poolName, 1)
+	require.NoError(t, err)
+	require.Equal(t, simulatedAmount, simulationContextAmount)
+	stateChanges, err := simulationContext.GetStateChanges()
+	require.NoError(t, err)
+	require.Len(t, stateChanges.Pools, 1)
+	require.Equal(t, poolName, stateChanges.Pools[0].Address)
+	require.Equal(t, simulatedAmount, stateChanges.Pools[0].Amount)
+	require.Empty(t, stateChanges.Accounts)
+
+	// The simulation context cannot be committed, nor touch the stores shared with the write context
+	require.Error(t, simulationContext.Commit([]byte("proposerAddr"), []byte("quorumCert")))
+	require.Error(t, simulationContext.NewSavePoint([]byte("savePoint")))
+	_, err = simulationContext.ComputeStateHash()
+	require.Error(t, err)
+
+	// The simulated changes are invisible to the write context and discarded on release
+	writeContextAmount, err := writeContext.GetPoolAmount(poolName, 1)
+	require.NoError(t, err)
+	require.Equal(t, writtenAmount, writeContextAmount)
+	require.NoError(t, simulationContext.Release())
+	require.NoError(t, writeContext.Release())
+
+	readContext, err := testPersistenceMod.NewReadContext(1)
+	require.NoError(t, err)
+	defer readContext.Close()
+	readContextAmount, err := readContext.GetPoolAmount(poolName, 1)
+	require.NoError(t, err)
+	require.Equal(t, originalAmount, readContextAmount)
+}
+
 func prepareAndCleanContext(t *testing.T) {
 	// Cleanup context after the test
 	t.Cleanup(clearAllState)
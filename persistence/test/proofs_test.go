@@ -0,0 +1,101 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateProofs(t *testing.T) {
+	persistenceMod := newTestPersistenceModuleWithConfig(&configs.PersistenceConfig{
+		PostgresUrl:       testDatabaseUrl,
+		NodeSchema:        "proofs_test_schema",
+		MaxConnsCount:     4,
+		MinConnsCount:     0,
+		MaxConnLifetime:   "1h",
+		MaxConnIdleTime:   "30m",
+		HealthCheckPeriod: "5m",
+	})
+	require.NotNil(t, persistenceMod)
+	t.Cleanup(func() {
+		require.NoError(t, persistenceMod.Stop())
+	})
+
+	account := newTestAccount(t)
+	addrBz, err := hex.DecodeString(account.Address)
+	require.NoError(t, err)
+
+	height := int64(1)
+	rwCtx, err := persistenceMod.NewRWContext(height)
+	require.NoError(t, err)
+	db := rwCtx.(*persistence.PostgresContext)
+	require.NoError(t, db.SetAccountAmount(addrBz, "1000"))
+	require.NoError(t, db.SetParam("app_max_chains", 42))
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+
+	// A block that is being applied does not affect the proofs of the committed state
+	rwCtx, err = persistenceMod.NewRWContext(height + 1)
+	require.NoError(t, err)
+	db = rwCtx.(*persistence.PostgresContext)
+	require.NoError(t, db.SetAccountAmount(addrBz, "2000"))
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, persistenceMod.ReleaseWriteContext())
+	})
+
+	readCtx, err := persistenceMod.NewReadContext(height)
+	require.NoError(t, err)
+	defer readCtx.Close()
+	stateHash, err := readCtx.GetBlockHash(height)
+	require.NoError(t, err)
+
+	// Membership proof of an account
+	proof, err := readCtx.GetAccountProof(addrBz, height)
+	require.NoError(t, err)
+	require.Equal(t, coreTypes.StateTreeAccount, proof.Tree)
+	require.True(t, proof.IsMembershipProof())
+	require.NoError(t, proof.Verify(stateHash))
+	provenAccount := new(coreTypes.Account)
+	require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, provenAccount))
+	require.Equal(t, "1000", provenAccount.Amount)
+
+	// Non-membership proof of an account
+	otherAddrBz, err := hex.DecodeString(newTestAccount(t).Address)
+	require.NoError(t, err)
+	proof, err = readCtx.GetAccountProof(otherAddrBz, height)
+	require.NoError(t, err)
+	require.False(t, proof.IsMembershipProof())
+	require.NoError(t, proof.Verify(stateHash))
+
+	// A proof does not verify against another state hash
+	require.Error(t, proof.Verify(hex.EncodeToString(getRandomBytes(32))))
+
+	// Membership proof of a param
+	proof, err = readCtx.GetParamProof("app_max_chains", height)
+	require.NoError(t, err)
+	require.True(t, proof.IsMembershipProof())
+	require.NoError(t, proof.Verify(stateHash))
+	provenParam := new(coreTypes.Param)
+	require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, provenParam))
+	require.Equal(t, "42", provenParam.Value)
+
+	// Proofs are available at the previous heights, where the account did not exist yet
+	proof, err = readCtx.GetAccountProof(addrBz, height-1)
+	require.NoError(t, err)
+	require.False(t, proof.IsMembershipProof())
+	prevStateHash, err := readCtx.GetBlockHash(height - 1)
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(prevStateHash))
+
+	// Proofs are not available above the latest committed height
+	_, err = readCtx.GetAccountProof(addrBz, height+1)
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/shared/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruning_KeepRecentAndKeepEvery(t *testing.T) {
+	persistenceMod := newTestPersistenceModuleWithConfig(&configs.PersistenceConfig{
+		PostgresUrl:       testDatabaseUrl,
+		NodeSchema:        "pruning_test_schema",
+		MaxConnsCount:     4,
+		MinConnsCount:     0,
+		MaxConnLifetime:   "1h",
+		MaxConnIdleTime:   "30m",
+		HealthCheckPeriod: "5m",
+		KeepRecent:        3,
+		KeepEvery:         4,
+	})
+	require.NotNil(t, persistenceMod)
+	t.Cleanup(func() {
+		require.NoError(t, persistenceMod.Stop())
+	})
+
+	account := newTestAccount(t)
+	addrBz, err := hex.DecodeString(account.Address)
+	require.NoError(t, err)
+
+	// The account's amount is set to the height at every height
+	latestHeight := int64(8)
+	txHashes := make(map[int64]string)
+	for height := int64(1); height <= latestHeight; height++ {
+		rwCtx, err := persistenceMod.NewRWContext(height)
+		require.NoError(t, err)
+		db := rwCtx.(*persistence.PostgresContext)
+
+		require.NoError(t, db.SetAccountAmount(addrBz, strconv.FormatInt(height, 10)))
+
+		txResult := getRandomTxResult(height)
+		require.NoError(t, db.IndexTransaction(modules.TxResult(txResult)))
+		txHash, err := txResult.Hash()
+		require.NoError(t, err)
+		txHashes[height] = hex.EncodeToString(txHash)
+
+		_, err = db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+	}
+
+	readCtx, err := persistenceMod.NewReadContext(latestHeight)
+	require.NoError(t, err)
+	defer readCtx.Close()
+
+	// Only the blocks & transactions of the 3 most recent heights are kept
+	minHeight, err := readCtx.GetMinimumBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), minHeight)
+	for height := int64(0); height <= latestHeight; height++ {
+		_, err := persistenceMod.GetBlockStore().Get(utils.HeightToBytes(uint64(height)))
+		require.Equal(t, height >= 6, err == nil, "unexpected block store state at height %d", height)
+
+		if height == 0 {
+			continue
+		}
+		exists, err := persistenceMod.TransactionExists(txHashes[height])
+		require.NoError(t, err)
+		require.Equal(t, height >= 6, exists, "unexpected tx indexer state at height %d", height)
+	}
+
+	// The state is kept at the 3 most recent heights and at every multiple of 4
+	for _, height := range []int64{4, 6, 7, 8} {
+		amount, err := readCtx.GetAccountAmount(addrBz, height)
+		require.NoError(t, err)
+		require.Equal(t, strconv.FormatInt(height, 10), amount)
+	}
+	// The state at the pruned heights cannot be read, rather than returning the state of an earlier height
+	for _, height := range []int64{1, 2, 3, 5} {
+		_, err := readCtx.GetAccountAmount(addrBz, height)
+		require.ErrorIs(t, err, types.ErrHeightPruned, "unexpected state at pruned height %d", height)
+		_, err = readCtx.GetAllAccounts(height)
+		require.ErrorIs(t, err, types.ErrHeightPruned, "unexpected state at pruned height %d", height)
+		_, err = readCtx.GetAllServicers(height)
+		require.ErrorIs(t, err, types.ErrHeightPruned, "unexpected state at pruned height %d", height)
+	}
+
+	// The state trees are only kept at the 3 most recent heights
+	for height := int64(1); height <= latestHeight; height++ {
+		proof, err := readCtx.GetAccountProof(addrBz, height)
+		if height < 6 {
+			require.Error(t, err, "unexpected state trees at pruned height %d", height)
+			continue
+		}
+		require.NoError(t, err)
+		stateHash, err := readCtx.GetBlockHash(height)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(stateHash))
+		provenAccount := new(coreTypes.Account)
+		require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, provenAccount))
+		require.Equal(t, strconv.FormatInt(height, 10), provenAccount.Amount)
+	}
+}
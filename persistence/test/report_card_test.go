@@ -0,0 +1,57 @@
+package test
+
+import (
+	"testing"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetSetReportCard(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	servicerAddr, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	reportCard, err := db.GetReportCard(servicerAddr, 1)
+	require.NoError(t, err)
+	require.Nil(t, reportCard, "the servicer was never tested")
+
+	reportCard = &coreTypes.ReportCard{
+		ServicerAddress:      servicerAddr.String(),
+		NumSamples:           4,
+		NumSuccessfulSamples: 3,
+		TotalLatencyMsec:     600,
+		LastSessionHeight:    0,
+		PendingTestScore: &coreTypes.TestScore{
+			SessionHeader:    &coreTypes.SessionHeader{RelayChain: "0001", SessionHeight: 4},
+			FishermanAddress: servicerAddr.String(),
+			NumSamples:       1,
+		},
+	}
+	err = db.SetReportCard(reportCard)
+	require.NoError(t, err)
+
+	gotReportCard, err := db.GetReportCard(servicerAddr, 1)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(reportCard, gotReportCard))
+
+	// The report card is versioned by height
+	gotReportCard, err = db.GetReportCard(servicerAddr, 0)
+	require.NoError(t, err)
+	require.Nil(t, gotReportCard)
+
+	// Updating the report card at the same height replaces it
+	reportCard.PendingTestScore = nil
+	err = db.SetReportCard(reportCard)
+	require.NoError(t, err)
+	gotReportCard, err = db.GetReportCard(servicerAddr, 1)
+	require.NoError(t, err)
+	require.Nil(t, gotReportCard.GetPendingTestScore())
+
+	// The report card is part of the state
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePoint_RollbackRevertsOnlyTheChangesAfterTheSavePoint(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	apps, err := db.GetAllApps(1)
+	require.NoError(t, err)
+	addrBz, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+
+	// The first tx is applied on top of its own save point and succeeds
+	txResult1 := getRandomTxResult(1)
+	require.NoError(t, db.NewSavePoint(txResult1.GetTx()))
+	require.NoError(t, db.SetAppStakeAmount(addrBz, "100"))
+	require.NoError(t, db.IndexTransaction(modules.TxResult(txResult1)))
+	stateHash1, err := db.ComputeStateHash()
+	require.NoError(t, err)
+
+	// The second tx is applied on top of its own save point and gets reverted
+	txResult2 := getRandomTxResult(1)
+	txResult2.Index = 1
+	require.NoError(t, db.NewSavePoint(txResult2.GetTx()))
+	require.NoError(t, db.SetAppStakeAmount(addrBz, "200"))
+	require.NoError(t, db.IndexTransaction(modules.TxResult(txResult2)))
+	stateHash2, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NotEqual(t, stateHash1, stateHash2)
+
+	require.NoError(t, db.RollbackToSavePoint(txResult2.GetTx()))
+
+	// The SQL changes of the first tx are kept while the ones of the second tx are reverted
+	stakeAmount, err := db.GetAppStakeAmount(1, addrBz)
+	require.NoError(t, err)
+	require.Equal(t, "100", stakeAmount)
+
+	// The state trees are reverted to the state they were in when the save point was created
+	stateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.Equal(t, stateHash1, stateHash)
+
+	// A save point can only be rolled back to once
+	require.Error(t, db.RollbackToSavePoint(txResult2.GetTx()))
+
+	// Once the block is committed, the first tx is indexed while the second tx is not
+	require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+	requireTxIndexed(t, txResult1, true)
+	requireTxIndexed(t, txResult2, false)
+}
+
+func TestSavePoint_RollbackDiscardsLaterSavePoints(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	txResult1 := getRandomTxResult(1)
+	txResult2 := getRandomTxResult(1)
+	txResult2.Index = 1
+
+	require.NoError(t, db.NewSavePoint(txResult1.GetTx()))
+	require.NoError(t, db.IndexTransaction(modules.TxResult(txResult1)))
+	require.NoError(t, db.NewSavePoint(txResult2.GetTx()))
+	require.NoError(t, db.IndexTransaction(modules.TxResult(txResult2)))
+
+	require.NoError(t, db.RollbackToSavePoint(txResult1.GetTx()))
+	require.Error(t, db.RollbackToSavePoint(txResult2.GetTx()))
+
+	_, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize)))
+	requireTxIndexed(t, txResult1, false)
+	requireTxIndexed(t, txResult2, false)
+}
+
+func requireTxIndexed(t *testing.T, txResult *indexer.TxRes, expectedIndexed bool) {
+	txHash, err := txResult.Hash()
+	require.NoError(t, err)
+	indexed, err := testPersistenceMod.TransactionExists(hex.EncodeToString(txHash))
+	require.NoError(t, err)
+	require.Equal(t, expectedIndexed, indexed)
+}
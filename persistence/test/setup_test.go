@@ -54,12 +54,16 @@ var (
 	genesisStateNumApplications = 1
 	genesisStateNumFishermen    = 1
 )
-var testPersistenceMod modules.PersistenceModule // initialized in TestMain
+var (
+	testPersistenceMod modules.PersistenceModule // initialized in TestMain
+	testDatabaseUrl    string                    // initialized in TestMain
+)
 
 // See https://github.com/ory/dockertest as reference for the template of this code
 // Postgres example can be found here: https://github.com/ory/dockertest/blob/v3/examples/PostgreSQL.md
 func TestMain(m *testing.M) {
 	pool, resource, dbUrl := test_artifacts.SetupPostgresDocker()
+	testDatabaseUrl = dbUrl
 	testPersistenceMod = newTestPersistenceModule(dbUrl)
 	if testPersistenceMod == nil {
 		log.Fatal("[ERROR] Unable to create new test persistence module")
@@ -90,22 +94,26 @@ func NewTestPostgresContext(t testing.TB, height int64) *persistence.PostgresCon
 
 // TODO(olshansky): Take in `t testing.T` as a parameter and error if there's an issue
 func newTestPersistenceModule(databaseUrl string) modules.PersistenceModule {
+	return newTestPersistenceModuleWithConfig(&configs.PersistenceConfig{
+		PostgresUrl:       databaseUrl,
+		NodeSchema:        testSchema,
+		BlockStorePath:    "",
+		TxIndexerPath:     "",
+		TreesStoreDir:     "",
+		MaxConnsCount:     4,
+		MinConnsCount:     0,
+		MaxConnLifetime:   "1h",
+		MaxConnIdleTime:   "30m",
+		HealthCheckPeriod: "5m",
+	})
+}
+
+func newTestPersistenceModuleWithConfig(persistenceCfg *configs.PersistenceConfig) modules.PersistenceModule {
 	teardownDeterministicKeygen := keygen.GetInstance().SetSeed(42)
 	defer teardownDeterministicKeygen()
 
 	cfg := &configs.Config{
-		Persistence: &configs.PersistenceConfig{
-			PostgresUrl:       databaseUrl,
-			NodeSchema:        testSchema,
-			BlockStorePath:    "",
-			TxIndexerPath:     "",
-			TreesStoreDir:     "",
-			MaxConnsCount:     4,
-			MinConnsCount:     0,
-			MaxConnLifetime:   "1h",
-			MaxConnIdleTime:   "30m",
-			HealthCheckPeriod: "5m",
-		},
+		Persistence: persistenceCfg,
 	}
 
 	genesisState, _ := test_artifacts.NewGenesisState(
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_CreateAndRestore(t *testing.T) {
+	t.Cleanup(clearAllState)
+	clearAllState()
+
+	stateHash := commitRandomBlocks(t, 5)
+	snapshotHeight := uint64(4)
+
+	readCtx, err := testPersistenceMod.NewReadContext(int64(snapshotHeight))
+	require.NoError(t, err)
+	expectedApps, err := readCtx.GetAllApps(int64(snapshotHeight))
+	require.NoError(t, err)
+	expectedValidators, err := readCtx.GetAllValidators(int64(snapshotHeight))
+	require.NoError(t, err)
+	require.NoError(t, readCtx.Close())
+
+	var snapshot bytes.Buffer
+	height, err := testPersistenceMod.CreateSnapshot(&snapshot)
+	require.NoError(t, err)
+	require.Equal(t, snapshotHeight, height)
+
+	// Restore the snapshot into a completely clear state
+	clearAllState()
+	height, err = testPersistenceMod.RestoreSnapshot(bytes.NewReader(snapshot.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, snapshotHeight, height)
+
+	readCtx, err = testPersistenceMod.NewReadContext(int64(snapshotHeight))
+	require.NoError(t, err)
+	defer readCtx.Close()
+
+	maxHeight, err := readCtx.GetMaximumBlockHeight()
+	require.NoError(t, err)
+	require.Equal(t, snapshotHeight, maxHeight)
+
+	blockHash, err := readCtx.GetBlockHash(int64(snapshotHeight))
+	require.NoError(t, err)
+	require.Equal(t, stateHash, blockHash)
+
+	apps, err := readCtx.GetAllApps(int64(snapshotHeight))
+	require.NoError(t, err)
+	require.ElementsMatch(t, expectedApps, apps)
+
+	validators, err := readCtx.GetAllValidators(int64(snapshotHeight))
+	require.NoError(t, err)
+	require.ElementsMatch(t, expectedValidators, validators)
+
+	// The state trees are restored along with their previous heights
+	for h := int64(0); h <= int64(snapshotHeight); h++ {
+		proof, err := readCtx.GetAccountProof(getRandomBytes(20), h)
+		require.NoError(t, err)
+		blockHash, err := readCtx.GetBlockHash(h)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(blockHash))
+	}
+
+	// The restored state trees must be able to compute the state hash of the next block
+	db := NewTestPostgresContext(t, int64(snapshotHeight)+1)
+	nextStateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.Equal(t, stateHash, nextStateHash)
+}
+
+func TestSnapshot_RestoreFailsWhenCorrupted(t *testing.T) {
+	t.Cleanup(clearAllState)
+	clearAllState()
+
+	commitRandomBlocks(t, 2)
+
+	var snapshot bytes.Buffer
+	_, err := testPersistenceMod.CreateSnapshot(&snapshot)
+	require.NoError(t, err)
+
+	truncatedSnapshot := snapshot.Bytes()[:snapshot.Len()/2]
+	_, err = testPersistenceMod.RestoreSnapshot(bytes.NewReader(truncatedSnapshot))
+	require.Error(t, err)
+
+	_, err = testPersistenceMod.RestoreSnapshot(bytes.NewReader([]byte("not a snapshot")))
+	require.Error(t, err)
+}
+
+// Commits `numHeights` blocks, starting at height 0, with random state changes and returns the state hash of the last one
+func commitRandomBlocks(t *testing.T, numHeights int64) (stateHash string) {
+	for height := int64(0); height < numHeights; height++ {
+		db := NewTestPostgresContext(t, height)
+
+		_, _, err := callRandomDatabaseModifierFunc(db, true)
+		require.NoError(t, err)
+
+		err = db.IndexTransaction(modules.TxResult(getRandomTxResult(height)))
+		require.NoError(t, err)
+
+		stateHash, err = db.ComputeStateHash()
+		require.NoError(t, err)
+
+		err = db.Commit(getRandomBytes(proposerBytesSize), getRandomBytes(quorumCertBytesSize))
+		require.NoError(t, err)
+	}
+	return stateHash
+}
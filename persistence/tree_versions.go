@@ -0,0 +1,310 @@
+package persistence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+)
+
+// The state trees are versioned so they can be read at any retained height rather than only at the latest one.
+//
+// When a tree is updated, the SMT deletes the nodes that are no longer part of it. The node stores keep the nodes
+// that are part of a committed tree instead, and record the height at which they were orphaned, so the tree of a
+// previous height remains intact as long as its root is known. The roots of every committed height are persisted
+// in the commit log. Since the value stores only map the path of each key to its latest value, they also keep every
+// value keyed by the hash of the leaf that commits to it, which is where a tree opened at a past root reads from.
+//
+// The nodes orphaned up to the lowest retained height, along with the values of the orphaned leaves, are deleted
+// when the heights below it are pruned (see `pruning.go`).
+
+var (
+	// Node store keys
+	orphanedNodeKeyPrefix   = []byte("orphaned_nodes/")   // orphaned_nodes/<height><node hash> -> <node hash>
+	orphanedHeightKeyPrefix = []byte("orphaned_heights/") // orphaned_heights/<node hash> -> <height>
+
+	// Value store keys
+	leafValueKeyPrefix = []byte("leaf_values/") // leaf_values/<leaf hash> -> <value>
+
+	// Commit log keys
+	treeRootsKeyPrefix = []byte("tree_roots/") // tree_roots/<height> -> committedRoots
+
+	errMalformedStateTree = errors.New("malformed state tree")
+)
+
+// Heights are encoded in big endian within the keys of the versioned trees so they are ordered by height
+func sortableHeightBytes(height uint64) []byte {
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, height)
+	return heightBz
+}
+
+func orphanedNodeKey(heightBz, nodeHash []byte) []byte {
+	return bytes.Join([][]byte{orphanedNodeKeyPrefix, heightBz, nodeHash}, nil)
+}
+
+func orphanedHeightKey(nodeHash []byte) []byte {
+	return append(append([]byte(nil), orphanedHeightKeyPrefix...), nodeHash...)
+}
+
+func leafValueKey(leafHash []byte) []byte {
+	return append(append([]byte(nil), leafValueKeyPrefix...), leafHash...)
+}
+
+func treeRootsKey(height uint64) []byte {
+	return append(append([]byte(nil), treeRootsKeyPrefix...), sortableHeightBytes(height)...)
+}
+
+// Same computation as the SMT: the hash of the leaf prefix, the path of the key & the hash of its value
+func digestLeaf(path, value []byte) []byte {
+	valueHash := sha256.Sum256(value)
+	leafHash := sha256.Sum256(bytes.Join([][]byte{{leafNodePrefix}, path, valueHash[:]}, nil))
+	return leafHash[:]
+}
+
+const (
+	leafNodePrefix  = byte(0)
+	innerNodePrefix = byte(1)
+)
+
+var _ smt.MapStore = &treeNodeStore{}
+
+// treeNodeStore is the node store of a state tree; it keeps the nodes orphaned by an update of the tree if they
+// are part of a committed tree
+type treeNodeStore struct {
+	kvstore.BufferedKVStore
+	// The store the buffered writes are flushed to, which contains the nodes of the committed trees
+	flushed kvstore.KVStore
+	// The height the tree is being updated at
+	height uint64
+}
+
+func (store *treeNodeStore) Set(key, value []byte) error {
+	// A node orphaned by a previous update may become part of the tree again
+	orphanedHeightBz, err := store.BufferedKVStore.Get(orphanedHeightKey(key))
+	switch {
+	case err == nil:
+		if err := store.BufferedKVStore.Delete(orphanedNodeKey(orphanedHeightBz, key)); err != nil {
+			return err
+		}
+		if err := store.BufferedKVStore.Delete(orphanedHeightKey(key)); err != nil {
+			return err
+		}
+	case !errors.Is(err, kvstore.ErrKeyNotFound):
+		return err
+	}
+	return store.BufferedKVStore.Set(key, value)
+}
+
+func (store *treeNodeStore) Delete(key []byte) error {
+	// Nodes created since the last commit are not part of any committed tree
+	if _, err := store.flushed.Get(key); errors.Is(err, kvstore.ErrKeyNotFound) {
+		return store.BufferedKVStore.Delete(key)
+	} else if err != nil {
+		return err
+	}
+	heightBz := sortableHeightBytes(store.height)
+	if err := store.BufferedKVStore.Set(orphanedNodeKey(heightBz, key), key); err != nil {
+		return err
+	}
+	return store.BufferedKVStore.Set(orphanedHeightKey(key), heightBz)
+}
+
+var _ smt.MapStore = &treeValueStore{}
+
+// treeValueStore is the value store of a state tree; it keeps every value it is set to by the hash of its leaf
+type treeValueStore struct {
+	kvstore.BufferedKVStore
+}
+
+func (store *treeValueStore) Set(path, value []byte) error {
+	if err := store.BufferedKVStore.Set(path, value); err != nil {
+		return err
+	}
+	return store.BufferedKVStore.Set(leafValueKey(digestLeaf(path, value)), value)
+}
+
+// setUpdateHeight sets the height the orphaned nodes are recorded at while the trees are being updated
+func (trees *stateTrees) setUpdateHeight(height uint64) {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		trees.nodeStores[tree].height = height
+	}
+}
+
+// openTreeAtHeight opens a read-only view of the committed state of a tree at any retained height, and returns the
+// roots of all the trees at that height. The view must be released once it is no longer needed.
+func (p *PostgresContext) openTreeAtHeight(tree merkleTree, height uint64) (view *smt.SparseMerkleTree, roots [][]byte, release func(), err error) {
+	p.stateTrees.committedMu.RLock()
+	roots, err = p.getCommittedTreeRoots(height)
+	if err != nil {
+		p.stateTrees.committedMu.RUnlock()
+		return nil, nil, nil, err
+	}
+	nodesSnapshot := p.stateTrees.nodeStores[tree].NewFlushedReadSnapshot()
+	valuesSnapshot := p.stateTrees.valueStores[tree].NewFlushedReadSnapshot()
+	p.stateTrees.committedMu.RUnlock()
+
+	root := roots[int(tree)]
+	values := &treeValuesAtRoot{
+		readOnlyTreeStore: readOnlyTreeStore{valuesSnapshot},
+		nodes:             nodesSnapshot,
+		root:              root,
+	}
+	view = smt.ImportSparseMerkleTree(&readOnlyTreeStore{nodesSnapshot}, values, sha256.New(), root)
+	release = func() {
+		nodesSnapshot.Release()
+		valuesSnapshot.Release()
+	}
+	return view, roots, release, nil
+}
+
+// getCommittedTreeRoots returns the roots of the state trees at a committed height that is retained. It must be
+// called while holding `committedMu`.
+func (p *PostgresContext) getCommittedTreeRoots(height uint64) ([][]byte, error) {
+	committedHeight := p.stateTrees.committedHeight
+	if height > committedHeight {
+		return nil, fmt.Errorf("the state trees are committed up to height %d, not height %d", committedHeight, height)
+	}
+	if height == committedHeight {
+		roots := make([][]byte, 0, int(numMerkleTrees))
+		for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+			roots = append(roots, p.stateTrees.committedRoots[tree])
+		}
+		return roots, nil
+	}
+
+	rootsBz, err := p.commitLog.Get(treeRootsKey(height))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, fmt.Errorf("the state trees are not retained at height %d", height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	roots := new(committedRoots)
+	if err := json.Unmarshal(rootsBz, roots); err != nil {
+		return nil, err
+	}
+	if len(roots.TreeRoots) != int(numMerkleTrees) {
+		return nil, fmt.Errorf("expected %d state tree roots at height %d but got %d", int(numMerkleTrees), height, len(roots.TreeRoots))
+	}
+	return roots.TreeRoots, nil
+}
+
+// pruneStateTrees deletes the nodes orphaned up to the lowest retained height, which are only part of the trees of
+// the heights below it, along with the values of the orphaned leaves
+func (p *PostgresContext) pruneStateTrees(minRetainedHeight uint64) error {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		nodeStore, valueStore := p.stateTrees.nodeStores[tree], p.stateTrees.valueStores[tree]
+
+		// Collect the orphaned nodes first since the store cannot be written to while it is being iterated
+		it, err := nodeStore.Iterator(orphanedNodeKeyPrefix, nil, orphanedNodeKey(sortableHeightBytes(minRetainedHeight+1), nil), false)
+		if err != nil {
+			return err
+		}
+		var recordKeys, nodeHashes [][]byte
+		for it.Next() {
+			recordKeys = append(recordKeys, append([]byte(nil), it.Key()...))
+			nodeHashes = append(nodeHashes, append([]byte(nil), it.Value()...))
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return err
+		}
+
+		// The underlying buffered stores are written to directly so the deletes are not recorded as orphans
+		for i, nodeHash := range nodeHashes {
+			nodeData, err := nodeStore.Get(nodeHash)
+			if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+				return err
+			}
+			if len(nodeData) > 0 && nodeData[0] == leafNodePrefix {
+				if err := valueStore.BufferedKVStore.Delete(leafValueKey(nodeHash)); err != nil {
+					return err
+				}
+			}
+			if err := nodeStore.BufferedKVStore.Delete(nodeHash); err != nil {
+				return err
+			}
+			if err := nodeStore.BufferedKVStore.Delete(recordKeys[i]); err != nil {
+				return err
+			}
+			if err := nodeStore.BufferedKVStore.Delete(orphanedHeightKey(nodeHash)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var _ smt.MapStore = &treeValuesAtRoot{}
+
+// treeValuesAtRoot reads the values of a state tree as of a given root from read snapshots of its stores
+type treeValuesAtRoot struct {
+	readOnlyTreeStore // The value store
+	nodes             kvstore.KVReader
+	root              []byte
+}
+
+func (store *treeValuesAtRoot) Get(path []byte) ([]byte, error) {
+	leafHash, err := findLeaf(store.nodes, store.root, path)
+	if err != nil {
+		return nil, err
+	}
+	if leafHash == nil {
+		return nil, &smt.InvalidKeyError{Key: path}
+	}
+
+	value, err := store.snapshot.Get(leafValueKey(leafHash))
+	if !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return value, err
+	}
+	// Values set before the trees were versioned are only keyed by their path, which is fine as long as the
+	// value still matches the leaf
+	value, err = store.snapshot.Get(path)
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, err
+	}
+	if err != nil || !bytes.Equal(digestLeaf(path, value), leafHash) {
+		return nil, fmt.Errorf("the value of leaf %x is missing from the state tree", leafHash)
+	}
+	return value, nil
+}
+
+// findLeaf walks a tree from the root down the path and returns the hash of the leaf of the path, or nil if the
+// path has no leaf
+func findLeaf(nodes kvstore.KVReader, root, path []byte) ([]byte, error) {
+	placeholder := make([]byte, sha256.Size)
+	current := root
+	for depth := 0; depth <= 8*len(path); depth++ {
+		if bytes.Equal(current, placeholder) {
+			return nil, nil
+		}
+		nodeData, err := nodes.Get(current)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(nodeData) == 1+2*sha256.Size && nodeData[0] == leafNodePrefix:
+			if !bytes.Equal(nodeData[1:1+sha256.Size], path) {
+				return nil, nil
+			}
+			return current, nil
+		case len(nodeData) == 1+2*sha256.Size && nodeData[0] == innerNodePrefix && depth < 8*len(path):
+			// Same bit ordering as the SMT: the most significant bit of the path is the one closest to the root
+			if path[depth/8]&(1<<(7-depth%8)) != 0 {
+				current = nodeData[1+sha256.Size:]
+			} else {
+				current = nodeData[1 : 1+sha256.Size]
+			}
+		default:
+			return nil, fmt.Errorf("%w: unexpected node %x", errMalformedStateTree, current)
+		}
+	}
+	return nil, fmt.Errorf("%w: the path %x is deeper than the tree", errMalformedStateTree, path)
+}
@@ -0,0 +1,45 @@
+package types
+
+import "fmt"
+
+const (
+	DoubleSignEvidenceTableName     = "double_sign_evidence"
+	DoubleSignEvidenceKeyConstraint = "double_sign_evidence_key"
+	VoteHeightCol                   = "vote_height"
+	VoteRoundCol                    = "vote_round"
+	VoteStepCol                     = "vote_step"
+)
+
+// The double sign evidence handled for a validator is keyed by the height, round & step of its conflicting votes, so
+// the same evidence is only ever handled once. The height is the one the evidence was handled at. The rows are never
+// updated, so they are not pruned like the other versioned tables.
+var DoubleSignEvidenceTableSchema = fmt.Sprintf(`(
+			%s TEXT NOT NULL,
+			%s BIGINT NOT NULL,
+			%s BIGINT NOT NULL,
+			%s INT NOT NULL,
+			%s BIGINT NOT NULL,
+
+		    CONSTRAINT %s UNIQUE (%s, %s, %s, %s)
+		)`, AddressCol, VoteHeightCol, VoteRoundCol, VoteStepCol, HeightCol,
+	DoubleSignEvidenceKeyConstraint, AddressCol, VoteHeightCol, VoteRoundCol, VoteStepCol)
+
+func DoubleSignEvidenceExistsQuery(address string, voteHeight, voteRound uint64, voteStep int32, height int64) string {
+	return fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s='%s' AND %s=%d AND %s=%d AND %s=%d AND %s<=%d)`,
+		DoubleSignEvidenceTableName, AddressCol, address, VoteHeightCol, voteHeight, VoteRoundCol, voteRound, VoteStepCol, voteStep,
+		HeightCol, height)
+}
+
+func GetDoubleSignEvidenceAtHeightQuery(height int64) string {
+	return SelectAtHeight(fmt.Sprintf("%s, %s, %s, %s", AddressCol, VoteHeightCol, VoteRoundCol, VoteStepCol), height, DoubleSignEvidenceTableName)
+}
+
+func InsertDoubleSignEvidenceQuery(address string, voteHeight, voteRound uint64, voteStep int32, height int64) string {
+	return fmt.Sprintf(`INSERT INTO %s (%s, %s, %s, %s, %s) VALUES ('%s',%d,%d,%d,%d)`,
+		DoubleSignEvidenceTableName, AddressCol, VoteHeightCol, VoteRoundCol, VoteStepCol, HeightCol,
+		address, voteHeight, voteRound, voteStep, height)
+}
+
+func ClearAllDoubleSignEvidenceQuery() string {
+	return fmt.Sprintf(`DELETE FROM %s`, DoubleSignEvidenceTableName)
+}
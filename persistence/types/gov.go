@@ -108,6 +108,17 @@ func GetParamOrFlagQuery(tableName, flagName string, height int64) string {
 	return fmt.Sprintf(`SELECT %s FROM %s WHERE name='%s' AND height<=%d ORDER BY height DESC LIMIT 1`, fields, tableName, flagName, height)
 }
 
+// GetParamOrFlagWithHeightQuery returns the latest row of a param/flag as of the given height, including the
+// height it was set at
+func GetParamOrFlagWithHeightQuery(tableName, name string, height int64) string {
+	fields := "name,value"
+	if tableName == FlagsTableName {
+		fields += ",enabled"
+	}
+	fields += ",height"
+	return fmt.Sprintf(`SELECT %s FROM %s WHERE name='%s' AND height<=%d ORDER BY height DESC LIMIT 1`, fields, tableName, name, height)
+}
+
 // SupportedParamTypes represents the types currently supported for the `value` property in params and flags
 type SupportedParamTypes interface {
 	int | int32 | int64 | []byte | string
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrHeightPruned is returned when reading the state at a height that is no longer retained by the pruning policy
+var ErrHeightPruned = errors.New("the state at the height was pruned")
+
+// Explainer:
+//
+//	A row of a table versioned by height is the value of its key for every height from its own height up to,
+//	but excluding, the height of the next row with the same key. The row can be pruned if none of those
+//	heights is retained, i.e. if the next row is at or below `minRetainedHeight` and there is no multiple
+//	of `keepEvery` in between. The smallest multiple of `keepEvery` at or above `height` is computed as
+//	`((height + keepEvery - 1) / keepEvery) * keepEvery`.
+func PruneVersionedRowsQuery(tableName, keyCol string, minRetainedHeight, keepEvery int64) string {
+	keepEveryCondition := ""
+	if keepEvery > 0 {
+		keepEveryCondition = fmt.Sprintf(" AND next.%s<=((pruned.%s+%d)/%d)*%d", HeightCol, HeightCol, keepEvery-1, keepEvery, keepEvery)
+	}
+	return fmt.Sprintf(`
+		DELETE FROM %s AS pruned
+		WHERE pruned.%s<%d AND EXISTS (
+			SELECT 1 FROM %s AS next
+			WHERE next.%s=pruned.%s AND next.%s>pruned.%s AND next.%s<=%d%s
+		)`,
+		tableName,
+		HeightCol, minRetainedHeight,
+		tableName,
+		keyCol, keyCol, HeightCol, HeightCol, HeightCol, minRetainedHeight, keepEveryCondition)
+}
+
+// Returns a query that prunes the chains of the actor rows that no longer exist, since the chains of an
+// actor are always read at the height of its row in the actor table
+func PruneActorChainsQuery(chainsTableName, actorTableName string, minRetainedHeight int64) string {
+	return fmt.Sprintf(`
+		DELETE FROM %s AS chains
+		WHERE chains.%s<%d AND NOT EXISTS (
+			SELECT 1 FROM %s AS actor
+			WHERE actor.%s=chains.%s AND actor.%s=chains.%s
+		)`,
+		chainsTableName,
+		HeightCol, minRetainedHeight,
+		actorTableName,
+		AddressCol, AddressCol, HeightCol, HeightCol)
+}
+
+// Returns a query that prunes all the blocks below the given height
+func PruneBlocksQuery(minRetainedHeight int64) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE %s<%d`, BlockTableName, HeightCol, minRetainedHeight)
+}
+
+// Returns a query that selects the lowest height whose block was not pruned, or 0 if there is no block yet
+func GetMinimumRetainedHeightQuery() string {
+	return fmt.Sprintf(`SELECT COALESCE(MIN(%s), 0) FROM %s`, HeightCol, BlockTableName)
+}
@@ -0,0 +1,42 @@
+package types
+
+import "fmt"
+
+const (
+	ReportCardTableName        = "report_card"
+	ReportCardHeightConstraint = "report_card_create_height"
+	ReportCardCol              = "report_card"
+)
+
+// The report card of a servicer is stored as its hex encoded protobuf, versioned by height like the accounts
+var ReportCardTableSchema = fmt.Sprintf(`(
+			%s TEXT NOT NULL,
+			%s TEXT NOT NULL,
+			%s BIGINT NOT NULL,
+
+		    CONSTRAINT %s UNIQUE (%s, %s)
+		)`, AddressCol, ReportCardCol, HeightCol, ReportCardHeightConstraint, AddressCol, HeightCol)
+
+func GetReportCardQuery(address string, height int64) string {
+	return Select(ReportCardCol, address, height, ReportCardTableName)
+}
+
+func GetReportCardsUpdatedAtHeightQuery(height int64) string {
+	return SelectAtHeight(ReportCardCol, height, ReportCardTableName)
+}
+
+func InsertReportCardQuery(address, reportCard string, height int64) string {
+	return fmt.Sprintf(`
+		INSERT INTO %s (%s, %s, %s)
+			VALUES ('%s','%s',%d)
+			ON CONFLICT ON CONSTRAINT %s
+			DO UPDATE SET %s=EXCLUDED.%s, %s=EXCLUDED.%s
+		`, ReportCardTableName, AddressCol, ReportCardCol, HeightCol,
+		address, reportCard, height,
+		ReportCardHeightConstraint,
+		ReportCardCol, ReportCardCol, HeightCol, HeightCol)
+}
+
+func ClearAllReportCardsQuery() string {
+	return fmt.Sprintf(`DELETE FROM %s`, ReportCardTableName)
+}
@@ -0,0 +1,18 @@
+package types
+
+import "fmt"
+
+// Save points are scoped to the SQL transaction of a `PostgresContext`, so their names only need to be
+// unique within it. Quoting the identifier allows any name to be used.
+
+func SavePointQuery(name string) string {
+	return fmt.Sprintf(`SAVEPOINT "%s"`, name)
+}
+
+func RollbackToSavePointQuery(name string) string {
+	return fmt.Sprintf(`ROLLBACK TO SAVEPOINT "%s"`, name)
+}
+
+func ReleaseSavePointQuery(name string) string {
+	return fmt.Sprintf(`RELEASE SAVEPOINT "%s"`, name)
+}
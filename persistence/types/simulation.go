@@ -0,0 +1,81 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A simulation context shadows every state table with a temporary view of the same name, which combines the rows
+// committed before the simulated height with the rows written by the simulation. The latter are kept in a temporary
+// table, so a simulation never writes to, nor waits on the row locks of, the tables shared with the write context.
+
+const simulationTableSuffix = "_simulation"
+
+// Matches the statements that write to a table (including the ones nested in a `WITH` clause) along with the table they
+// target, possibly qualified by a schema. `DO UPDATE` is matched so the `ON CONFLICT` clause of an `INSERT` is not
+// mistaken for an `UPDATE`.
+var writeTargetRegex = regexp.MustCompile(`(?i)\b(DO\s+UPDATE|INSERT\s+INTO|DELETE\s+FROM|UPDATE|TRUNCATE(?:\s+TABLE)?|MERGE\s+INTO|COPY)\s+([\w."]+)`)
+
+// ErrSimulationWrite is returned for the writes that cannot be redirected to the simulation tables, so they never reach
+// the tables shared with the write context
+var ErrSimulationWrite = errors.New("write not supported by a simulation")
+
+func SimulationTableName(tableName string) string {
+	return tableName + simulationTableSuffix
+}
+
+// Returns a query that creates the temporary table holding the rows written by a simulation to `tableName`.
+// Its unique constraints are added separately, using the same names as the ones of `tableName`, so the
+// `ON CONFLICT ON CONSTRAINT` clauses of the writes apply unchanged.
+func CreateSimulationTableQuery(nodeSchema, tableName string) string {
+	return fmt.Sprintf(`CREATE TEMP TABLE %s (LIKE %s.%s INCLUDING DEFAULTS)`, SimulationTableName(tableName), nodeSchema, tableName)
+}
+
+// Returns a query that selects the name & definition of the primary key and unique constraints of a table
+func GetUniqueConstraintsQuery(nodeSchema, tableName string) string {
+	return fmt.Sprintf(`SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint WHERE conrelid='%s.%s'::regclass AND contype IN ('p', 'u')`,
+		nodeSchema, tableName)
+}
+
+func AddSimulationTableConstraintQuery(tableName, constraintName, constraintDef string) string {
+	return fmt.Sprintf(`ALTER TABLE pg_temp.%s ADD CONSTRAINT %s %s`, SimulationTableName(tableName), constraintName, constraintDef)
+}
+
+// Returns a query that creates the temporary view shadowing `tableName`. The rows of `tableName` at `height` and above
+// are excluded, so that they never appear twice if a block at `height` was committed before the simulation started.
+func CreateSimulationViewQuery(nodeSchema, tableName string, height int64) string {
+	return fmt.Sprintf(`CREATE TEMP VIEW %s AS SELECT * FROM %s.%s WHERE %s<%d UNION ALL SELECT * FROM pg_temp.%s`,
+		tableName, nodeSchema, tableName, HeightCol, height, SimulationTableName(tableName))
+}
+
+// Returns `query` with the `INSERT`s & `DELETE`s targeting one of `tableNames` redirected to the corresponding
+// simulation table. The rows they read are still selected from the views shadowing the tables. Any other write (e.g. an
+// `UPDATE`, or a write to another table) returns an `ErrSimulationWrite`.
+func SimulationWriteQuery(query string, tableNames map[string]bool) (string, error) {
+	var err error
+	// The string literals are the odd parts of the query, which are left untouched
+	parts := strings.Split(query, "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = writeTargetRegex.ReplaceAllStringFunc(parts[i], func(match string) string {
+			submatches := writeTargetRegex.FindStringSubmatch(match)
+			statement, target := strings.ToUpper(strings.Join(strings.Fields(submatches[1]), " ")), submatches[2]
+			tableName := strings.Trim(target, `"`)
+			switch {
+			case statement == "DO UPDATE":
+				return match
+			case (statement == "INSERT INTO" || statement == "DELETE FROM") && !strings.Contains(target, ".") && tableNames[tableName]:
+				return fmt.Sprintf("%s pg_temp.%s", statement, SimulationTableName(tableName))
+			}
+			if err == nil {
+				err = fmt.Errorf("%w: %s", ErrSimulationWrite, match)
+			}
+			return match
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(parts, "'"), nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationWriteQuery(t *testing.T) {
+	tableNames := map[string]bool{PoolTableName: true, ServicerActor.GetTableName(): true, ServicerActor.GetChainsTableName(): true}
+
+	query := Pool.InsertAccountQuery("fake", "10", 1)
+	simulationQuery, err := SimulationWriteQuery(query, tableNames)
+	require.NoError(t, err)
+	require.Contains(t, simulationQuery, "INSERT INTO pg_temp.pool_simulation (")
+	require.Contains(t, simulationQuery, "DO UPDATE SET", "the ON CONFLICT clause is not an UPDATE")
+
+	// The rows written are still selected from the table name, i.e. from the view shadowing it
+	query = ServicerActor.UpdateQuery("address", "10", "https://foo.bar", 1)
+	simulationQuery, err = SimulationWriteQuery(query, tableNames)
+	require.NoError(t, err)
+	require.Contains(t, simulationQuery, "INSERT INTO pg_temp.servicer_simulation(")
+	require.Contains(t, simulationQuery, "FROM servicer WHERE")
+
+	query = NullifyChains("address", 1, ServicerActor.GetChainsTableName())
+	simulationQuery, err = SimulationWriteQuery(query, tableNames)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM pg_temp.servicer_chains_simulation WHERE address='address' AND height=1", simulationQuery)
+
+	// The string literals are not rewritten
+	query = `INSERT INTO pool (name) VALUES ('DELETE FROM account')`
+	simulationQuery, err = SimulationWriteQuery(query, tableNames)
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO pg_temp.pool_simulation (name) VALUES ('DELETE FROM account')`, simulationQuery)
+
+	// The reads are left untouched
+	query = `SELECT * FROM pool WHERE height<=1`
+	simulationQuery, err = SimulationWriteQuery(query, tableNames)
+	require.NoError(t, err)
+	require.Equal(t, query, simulationQuery)
+
+	// The writes that cannot be redirected are rejected
+	for _, query := range []string{
+		Account.InsertAccountQuery("address", "10", 1), // Not shadowed
+		`UPDATE pool SET balance='0'`,
+		`update pool set balance='0'`,
+		`INSERT INTO node1.pool (name) VALUES ('fake')`,
+		`DELETE FROM "node1"."pool"`,
+		`WITH deleted AS (DELETE FROM pool RETURNING *) UPDATE servicer SET height=1`,
+		`TRUNCATE pool`,
+		`COPY pool FROM STDIN`,
+	} {
+		_, err := SimulationWriteQuery(query, tableNames)
+		require.ErrorIs(t, err, ErrSimulationWrite, query)
+	}
+}
+
+// All the writes of the state tables can be redirected
+func TestSimulationWriteQuery_StateWrites(t *testing.T) {
+	tableNames := map[string]bool{
+		AccountTableName:            true,
+		PoolTableName:               true,
+		ParamsTableName:             true,
+		FlagsTableName:              true,
+		ReportCardTableName:         true,
+		DoubleSignEvidenceTableName: true,
+	}
+	queries := []string{
+		Account.InsertAccountQuery("address", "10", 1),
+		Pool.InsertAccountQuery("pool", "10", 1),
+		InsertParamOrFlag(ParamsTableName, "param", 1, 10, nil),
+		InsertReportCardQuery("address", "report_card", 1),
+		InsertDoubleSignEvidenceQuery("address", 1, 1, 1, 1),
+	}
+	for _, actor := range []ProtocolActorSchema{ApplicationActor, FishermanActor, ServicerActor, ValidatorActor} {
+		tableNames[actor.GetTableName()] = true
+		var chains []string
+		if actor.GetChainsTableName() != "" {
+			tableNames[actor.GetChainsTableName()] = true
+			chains = []string{"0001"}
+			queries = append(queries, actor.UpdateChainsQuery("address", chains, 1))
+		}
+		queries = append(queries,
+			actor.InsertQuery("address", "public_key", "10", "generic", "output", -1, -1, chains, 1),
+			actor.UpdateQuery("address", "10", "generic", 1),
+			actor.UpdateUnstakingHeightQuery("address", 10, 1),
+			actor.UpdatePausedHeightQuery("address", 10, 1),
+			actor.UpdateUnstakedHeightIfPausedBeforeQuery(1, 10, 1),
+			actor.SetStakeAmountQuery("address", "10", 1),
+		)
+	}
+	for _, query := range queries {
+		_, err := SimulationWriteQuery(query, tableNames)
+		require.NoError(t, err, query)
+	}
+}
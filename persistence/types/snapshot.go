@@ -0,0 +1,16 @@
+package types
+
+import "fmt"
+
+// Every table in the persistence schema is versioned by height, so a consistent view of the state at
+// a specific height can be exported by selecting all the rows up to, and including, that height.
+
+// Returns a query that streams all the rows of a table up to the given height in Postgres' text `COPY` format
+func ExportTableQuery(tableName string, height int64) string {
+	return fmt.Sprintf(`COPY (SELECT * FROM %s WHERE %s<=%d) TO STDOUT`, tableName, HeightCol, height)
+}
+
+// Returns a query that loads rows streamed in Postgres' text `COPY` format into a table
+func ImportTableQuery(tableName string) string {
+	return fmt.Sprintf(`COPY %s FROM STDIN`, tableName)
+}
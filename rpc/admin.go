@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+const (
+	adminPathPrefix    = "/v1/admin/"
+	bearerAuthScheme   = "Bearer"
+	errAdminDisabled   = "the admin endpoints are disabled; set a bearer token or a client CA in the admin RPC config to enable them"
+	errAdminOnMTLSPort = "the admin endpoints are served over mTLS on port %s"
+)
+
+// isAdminEnabled returns whether the admin endpoints are served. They give control over the node, so they are disabled
+// unless they are protected by a bearer token and/or mTLS.
+func isAdminEnabled(adminCfg *configs.RPCAdminConfig) bool {
+	return adminCfg.GetBearerToken() != "" || isAdminMTLSEnabled(adminCfg)
+}
+
+func isAdminMTLSEnabled(adminCfg *configs.RPCAdminConfig) bool {
+	return adminCfg.GetClientCaFile() != ""
+}
+
+// adminMiddleware restricts the access to the admin endpoints. When mTLS is enabled, the admin endpoints are only
+// served by the admin server (i.e. `isAdminServer`), which serves nothing else, and the TLS handshake authenticates the
+// clients. The bearer token, if any, is required in both cases.
+func adminMiddleware(adminCfg *configs.RPCAdminConfig, isAdminServer bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !strings.HasPrefix(ctx.Request().URL.Path, adminPathPrefix) {
+				if isAdminServer {
+					return ctx.String(http.StatusNotFound, "only the admin endpoints are served on this port")
+				}
+				return next(ctx)
+			}
+
+			if !isAdminEnabled(adminCfg) {
+				return ctx.String(http.StatusNotFound, errAdminDisabled)
+			}
+			if isAdminMTLSEnabled(adminCfg) && !isAdminServer {
+				return ctx.String(http.StatusNotFound, fmt.Sprintf(errAdminOnMTLSPort, adminCfg.GetPort()))
+			}
+			if token := adminCfg.GetBearerToken(); token != "" && !hasBearerToken(ctx.Request(), token) {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, bearerAuthScheme)
+				return ctx.String(http.StatusUnauthorized, "missing or invalid bearer token")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func hasBearerToken(req *http.Request, token string) bool {
+	scheme, reqToken, ok := strings.Cut(req.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, bearerAuthScheme) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) == 1
+}
+
+// newAdminTLSConfig returns the TLS config of the admin server, which only accepts the clients presenting a certificate
+// signed by the configured client CA
+func newAdminTLSConfig(adminCfg *configs.RPCAdminConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(adminCfg.GetCertFile(), adminCfg.GetKeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("loading the admin server certificate: %w", err)
+	}
+
+	clientCAPEM, err := os.ReadFile(adminCfg.GetClientCaFile())
+	if err != nil {
+		return nil, fmt.Errorf("reading the admin client CA: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(clientCAPEM) {
+		return nil, errors.New("the admin client CA file does not contain any PEM encoded certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func (s *rpcServer) PostV1AdminConsensusPause(ctx echo.Context) error {
+	consensus := s.GetBus().GetConsensusModule()
+	consensus.PauseConsensus()
+	return ctx.JSON(http.StatusOK, AdminConsensusStatus{Paused: consensus.IsConsensusPaused()})
+}
+
+func (s *rpcServer) PostV1AdminConsensusResume(ctx echo.Context) error {
+	consensus := s.GetBus().GetConsensusModule()
+	consensus.ResumeConsensus()
+	return ctx.JSON(http.StatusOK, AdminConsensusStatus{Paused: consensus.IsConsensusPaused()})
+}
+
+func (s *rpcServer) PostV1AdminMempoolFlush(ctx echo.Context) error {
+	mempool := s.GetBus().GetUtilityModule().GetMempool()
+	// Transactions added in the meantime are flushed as well, so the count is only indicative
+	txCount := mempool.TxCount()
+	mempool.Clear()
+	return ctx.JSON(http.StatusOK, AdminMempoolFlushResponse{FlushedTxCount: int64(txCount)})
+}
+
+func (s *rpcServer) PostV1AdminSnapshot(ctx echo.Context) error {
+	height, path, err := s.GetBus().GetConsensusModule().TriggerSnapshot()
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, AdminSnapshotResponse{Height: int64(height), Path: path})
+}
+
+func (s *rpcServer) GetV1AdminLogLevel(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, AdminLogLevel{Level: s.GetBus().GetLoggerModule().GetLogLevel()})
+}
+
+func (s *rpcServer) PutV1AdminLogLevel(ctx echo.Context) error {
+	var logLevel AdminLogLevel
+	if err := ctx.Bind(&logLevel); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+
+	loggerModule := s.GetBus().GetLoggerModule()
+	if err := loggerModule.SetLogLevel(logLevel.Level); err != nil {
+		return ctx.String(http.StatusBadRequest, err.Error())
+	}
+	s.logger.Info().Str("level", logLevel.Level).Msg("Log level changed through the admin RPC")
+
+	return ctx.JSON(http.StatusOK, AdminLogLevel{Level: loggerModule.GetLogLevel()})
+}
+
+func (s *rpcServer) GetV1AdminP2pAddressBook(ctx echo.Context) error {
+	networkInfo := s.GetBus().GetP2PModule().GetNetworkInfo()
+	peers := make([]Peer, 0, len(networkInfo.GetPeers()))
+	for _, peer := range networkInfo.GetPeers() {
+		peers = append(peers, Peer{
+			Address:    peer.GetAddress(),
+			PublicKey:  peer.GetPublicKey(),
+			ServiceUrl: peer.GetServiceUrl(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, AdminAddressBook{
+		Peers:          peers,
+		RaintreeLevels: int64(networkInfo.GetRaintreeLevels()),
+	})
+}
@@ -0,0 +1,241 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/runtime/configs"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "secret"
+
+// Returns an echo server with the handlers of an RPC server, with the admin endpoints restricted by `adminCfg`, along
+// with the consensus and logger module mocks
+func newTestAdminServer(t *testing.T, adminCfg *configs.RPCAdminConfig, isAdminServer bool) (*echo.Echo, *mockModules.MockConsensusModule, *mockModules.MockLoggerModule) {
+	ctrl := gomock.NewController(t)
+
+	consensusMock := mockModules.NewMockConsensusModule(ctrl)
+	loggerMock := mockModules.NewMockLoggerModule(ctrl)
+
+	mempool := typesUtil.NewTxFIFOMempool(1000000, 100)
+	require.NoError(t, mempool.AddTx([]byte("tx1")))
+	require.NoError(t, mempool.AddTx([]byte("tx2")))
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().GetMempool().Return(mempool).AnyTimes()
+
+	p2pMock := mockModules.NewMockP2PModule(ctrl)
+	p2pMock.EXPECT().GetNetworkInfo().Return(&coreTypes.P2PNetworkInfo{
+		Peers:          []*coreTypes.Peer{{Address: "self", PublicKey: "pubkey", ServiceUrl: "node1:42069"}},
+		RaintreeLevels: 1,
+	}).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetConsensusModule().Return(consensusMock).AnyTimes()
+	busMock.EXPECT().GetLoggerModule().Return(loggerMock).AnyTimes()
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+	busMock.EXPECT().GetP2PModule().Return(p2pMock).AnyTimes()
+
+	e := echo.New()
+	e.Use(adminMiddleware(adminCfg, isAdminServer))
+	RegisterHandlers(e, NewRPCServer(busMock))
+	return e, consensusMock, loggerMock
+}
+
+func doTestAdminRequest(t *testing.T, e *echo.Echo, method, target, token, body string, response any) int {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code == http.StatusOK && response != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+	}
+	return rec.Code
+}
+
+func TestAdmin_Authentication(t *testing.T) {
+	tests := []struct {
+		name          string
+		adminCfg      *configs.RPCAdminConfig
+		isAdminServer bool
+		target        string
+		token         string
+		wantStatus    int
+	}{
+		{"disabled without a token or client CA", &configs.RPCAdminConfig{}, false, "/v1/admin/log_level", testAdminToken, http.StatusNotFound},
+		{"missing token", &configs.RPCAdminConfig{BearerToken: testAdminToken}, false, "/v1/admin/log_level", "", http.StatusUnauthorized},
+		{"invalid token", &configs.RPCAdminConfig{BearerToken: testAdminToken}, false, "/v1/admin/log_level", "invalid", http.StatusUnauthorized},
+		{"valid token", &configs.RPCAdminConfig{BearerToken: testAdminToken}, false, "/v1/admin/log_level", testAdminToken, http.StatusOK},
+		{"not on the mTLS port", &configs.RPCAdminConfig{ClientCaFile: "ca.pem", Port: "50834"}, false, "/v1/admin/log_level", "", http.StatusNotFound},
+		{"on the mTLS port", &configs.RPCAdminConfig{ClientCaFile: "ca.pem"}, true, "/v1/admin/log_level", "", http.StatusOK},
+		{"token on the mTLS port", &configs.RPCAdminConfig{ClientCaFile: "ca.pem", BearerToken: testAdminToken}, true, "/v1/admin/log_level", "", http.StatusUnauthorized},
+		{"other endpoints on the mTLS port", &configs.RPCAdminConfig{ClientCaFile: "ca.pem"}, true, "/v1/health", "", http.StatusNotFound},
+		{"other endpoints without token", &configs.RPCAdminConfig{BearerToken: testAdminToken}, false, "/v1/health", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _, loggerMock := newTestAdminServer(t, tt.adminCfg, tt.isAdminServer)
+			loggerMock.EXPECT().GetLogLevel().Return("info").AnyTimes()
+
+			require.Equal(t, tt.wantStatus, doTestAdminRequest(t, e, http.MethodGet, tt.target, tt.token, "", nil))
+		})
+	}
+}
+
+func TestAdmin_Endpoints(t *testing.T) {
+	e, consensusMock, loggerMock := newTestAdminServer(t, &configs.RPCAdminConfig{BearerToken: testAdminToken}, false)
+
+	gomock.InOrder(
+		consensusMock.EXPECT().PauseConsensus(),
+		consensusMock.EXPECT().IsConsensusPaused().Return(true),
+		consensusMock.EXPECT().ResumeConsensus(),
+		consensusMock.EXPECT().IsConsensusPaused().Return(false),
+	)
+	var consensusStatus AdminConsensusStatus
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/consensus/pause", testAdminToken, "", &consensusStatus))
+	require.True(t, consensusStatus.Paused)
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/consensus/resume", testAdminToken, "", &consensusStatus))
+	require.False(t, consensusStatus.Paused)
+
+	var flushResponse AdminMempoolFlushResponse
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/mempool/flush", testAdminToken, "", &flushResponse))
+	require.Equal(t, int64(2), flushResponse.FlushedTxCount)
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/mempool/flush", testAdminToken, "", &flushResponse))
+	require.Zero(t, flushResponse.FlushedTxCount)
+
+	consensusMock.EXPECT().TriggerSnapshot().Return(uint64(9), "/snapshots/snapshot_9", nil)
+	var snapshotResponse AdminSnapshotResponse
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/snapshot", testAdminToken, "", &snapshotResponse))
+	require.Equal(t, AdminSnapshotResponse{Height: 9, Path: "/snapshots/snapshot_9"}, snapshotResponse)
+	consensusMock.EXPECT().TriggerSnapshot().Return(uint64(0), "", errors.New("snapshot error"))
+	require.Equal(t, http.StatusInternalServerError, doTestAdminRequest(t, e, http.MethodPost, "/v1/admin/snapshot", testAdminToken, "", nil))
+
+	loggerMock.EXPECT().SetLogLevel("debug").Return(nil)
+	loggerMock.EXPECT().GetLogLevel().Return("debug")
+	var logLevel AdminLogLevel
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodPut, "/v1/admin/log_level", testAdminToken, `{"level":"debug"}`, &logLevel))
+	require.Equal(t, "debug", logLevel.Level)
+	loggerMock.EXPECT().SetLogLevel("verbose").Return(errors.New("unknown log level: verbose"))
+	require.Equal(t, http.StatusBadRequest, doTestAdminRequest(t, e, http.MethodPut, "/v1/admin/log_level", testAdminToken, `{"level":"verbose"}`, nil))
+
+	// Unlike the peers endpoint, the address book includes the node itself
+	var addressBook AdminAddressBook
+	require.Equal(t, http.StatusOK, doTestAdminRequest(t, e, http.MethodGet, "/v1/admin/p2p/address_book", testAdminToken, "", &addressBook))
+	require.Equal(t, AdminAddressBook{
+		Peers:          []Peer{{Address: "self", PublicKey: "pubkey", ServiceUrl: "node1:42069"}},
+		RaintreeLevels: 1,
+	}, addressBook)
+}
+
+func TestAdmin_MTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCert, caKey := newTestCertificate(t, nil, nil, true)
+	serverCert, serverKey := newTestCertificate(t, caCert, caKey, false)
+	clientCert, clientKey := newTestCertificate(t, caCert, caKey, false)
+	otherCACert, otherCAKey := newTestCertificate(t, nil, nil, true)
+	otherClientCert, otherClientKey := newTestCertificate(t, otherCACert, otherCAKey, false)
+
+	adminCfg := &configs.RPCAdminConfig{
+		CertFile:     writeTestPEM(t, dir, "server.pem", "CERTIFICATE", serverCert.Raw),
+		KeyFile:      writeTestPEM(t, dir, "server.key", "EC PRIVATE KEY", marshalTestKey(t, serverKey)),
+		ClientCaFile: writeTestPEM(t, dir, "ca.pem", "CERTIFICATE", caCert.Raw),
+	}
+	tlsConfig, err := newAdminTLSConfig(adminCfg)
+	require.NoError(t, err)
+
+	e, _, loggerMock := newTestAdminServer(t, adminCfg, true)
+	loggerMock.EXPECT().GetLogLevel().Return("info").AnyTimes()
+	server := httptest.NewUnstartedServer(e)
+	server.TLS = tlsConfig
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	newClient := func(cert *x509.Certificate, key *ecdsa.PrivateKey) *http.Client {
+		clientTLSConfig := &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+		if cert != nil {
+			clientTLSConfig.Certificates = []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}}
+	}
+
+	resp, err := newClient(clientCert, clientKey).Get(server.URL + "/v1/admin/log_level")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Clients without a certificate signed by the client CA are rejected during the TLS handshake
+	_, err = newClient(nil, nil).Get(server.URL + "/v1/admin/log_level") // nolint:bodyclose // the request fails
+	require.Error(t, err)
+	_, err = newClient(otherClientCert, otherClientKey).Get(server.URL + "/v1/admin/log_level") // nolint:bodyclose // the request fails
+	require.Error(t, err)
+
+	_, err = newAdminTLSConfig(&configs.RPCAdminConfig{CertFile: adminCfg.CertFile, KeyFile: adminCfg.KeyFile, ClientCaFile: adminCfg.KeyFile})
+	require.Error(t, err)
+}
+
+// Returns a certificate for localhost signed by `parent`, or a self-signed CA certificate if `parent` is nil
+func newTestCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	certBz, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBz)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func marshalTestKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	keyBz, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return keyBz
+}
+
+func writeTestPEM(t *testing.T, dir, name, blockType string, bz []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bz}), 0o600))
+	return path
+}
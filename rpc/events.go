@@ -0,0 +1,306 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/pokt-network/pocket/shared/modules"
+)
+
+const (
+	eventsPath = "/v1/events"
+
+	// Topics, which are also the names of the server-sent events matching them
+	newBlockTopic      = "new_block"
+	txTopic            = "tx"
+	fsmTransitionTopic = "fsm_transition"
+	mempoolTxTopic     = "mempool_tx"
+
+	// Prefixes of the topics of the transactions signed by or sent to an address
+	txSenderTopicPrefix    = "tx:sender:"
+	txRecipientTopicPrefix = "tx:recipient:"
+
+	// overflowEvent is the last event sent to a subscriber before disconnecting it for not keeping up with the stream
+	overflowEvent = "overflow"
+
+	maxSubscriptionsPerConnection = 16
+	// eventsBufferSize is the number of events buffered for each connection. A connection whose buffer is full is
+	// disconnected rather than blocking the publication of the events to the other connections.
+	eventsBufferSize = 256
+	// eventsKeepAliveInterval is the interval between the comments sent to idle connections so they are not closed
+	eventsKeepAliveInterval = 15 * time.Second
+)
+
+// streamEvent is a node event published to the subscribers of its topic, which encode its payload for their transport.
+// The payload is a `*coreTypes.Block` for `new_block`, a `modules.TxResult` for `tx`, a
+// `*messaging.StateMachineTransitionEvent` for `fsm_transition` and the transaction bytes for `mempool_tx`.
+type streamEvent struct {
+	topic   string
+	payload any
+}
+
+// eventSubscriber receives the events matching the topics of a single connection
+type eventSubscriber struct {
+	topics     map[string]struct{}
+	senders    map[string]struct{}
+	recipients map[string]struct{}
+
+	events chan streamEvent
+	// overflowed is closed when the subscriber is dropped because its events buffer is full
+	overflowed chan struct{}
+}
+
+func newEventSubscriber(topics []string) (*eventSubscriber, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("at least one topic is required")
+	}
+	if len(topics) > maxSubscriptionsPerConnection {
+		return nil, fmt.Errorf("at most %d topics can be subscribed to per connection", maxSubscriptionsPerConnection)
+	}
+
+	sub := &eventSubscriber{
+		topics:     make(map[string]struct{}),
+		senders:    make(map[string]struct{}),
+		recipients: make(map[string]struct{}),
+		events:     make(chan streamEvent, eventsBufferSize),
+		overflowed: make(chan struct{}),
+	}
+	for _, topic := range topics {
+		switch {
+		case topic == newBlockTopic, topic == txTopic, topic == fsmTransitionTopic, topic == mempoolTxTopic:
+			sub.topics[topic] = struct{}{}
+		case strings.HasPrefix(topic, txSenderTopicPrefix):
+			address, err := parseTopicAddress(topic, txSenderTopicPrefix)
+			if err != nil {
+				return nil, err
+			}
+			sub.senders[address] = struct{}{}
+		case strings.HasPrefix(topic, txRecipientTopicPrefix):
+			address, err := parseTopicAddress(topic, txRecipientTopicPrefix)
+			if err != nil {
+				return nil, err
+			}
+			sub.recipients[address] = struct{}{}
+		default:
+			return nil, fmt.Errorf("unknown topic: %s", topic)
+		}
+	}
+	return sub, nil
+}
+
+func parseTopicAddress(topic, prefix string) (string, error) {
+	address := strings.ToLower(strings.TrimPrefix(topic, prefix))
+	if _, err := hex.DecodeString(address); err != nil || address == "" {
+		return "", fmt.Errorf("cannot decode the address of topic %s", topic)
+	}
+	return address, nil
+}
+
+func (sub *eventSubscriber) wantsTopic(topic string) bool {
+	_, ok := sub.topics[topic]
+	return ok
+}
+
+func (sub *eventSubscriber) wantsNewBlocks() bool {
+	return sub.wantsTopic(newBlockTopic)
+}
+
+func (sub *eventSubscriber) wantsTxs() bool {
+	return sub.wantsTopic(txTopic) || len(sub.senders) > 0 || len(sub.recipients) > 0
+}
+
+func (sub *eventSubscriber) wantsTx(txResult modules.TxResult) bool {
+	if sub.wantsTopic(txTopic) {
+		return true
+	}
+	if _, ok := sub.senders[strings.ToLower(txResult.GetSignerAddr())]; ok {
+		return true
+	}
+	_, ok := sub.recipients[strings.ToLower(txResult.GetRecipientAddr())]
+	return ok
+}
+
+func (sub *eventSubscriber) wantsFSMTransitions() bool {
+	return sub.wantsTopic(fsmTransitionTopic)
+}
+
+func (sub *eventSubscriber) wantsMempoolTxs() bool {
+	return sub.wantsTopic(mempoolTxTopic)
+}
+
+// eventBroadcaster publishes the node events to the subscribers of the REST & gRPC event streams
+type eventBroadcaster struct {
+	m           sync.Mutex
+	subscribers map[*eventSubscriber]struct{}
+}
+
+func newEventBroadcaster() *eventBroadcaster {
+	return &eventBroadcaster{
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+// subscribe registers the subscriber and returns a function to unsubscribe it once the connection is closed
+func (b *eventBroadcaster) subscribe(sub *eventSubscriber) (unsubscribe func()) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.subscribers[sub] = struct{}{}
+
+	return func() {
+		b.m.Lock()
+		defer b.m.Unlock()
+		delete(b.subscribers, sub)
+	}
+}
+
+// hasSubscribers returns whether any subscriber matches, so that events nobody subscribed to are not even built
+func (b *eventBroadcaster) hasSubscribers(matches func(*eventSubscriber) bool) bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for sub := range b.subscribers {
+		if matches(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish sends the event to the matching subscribers without blocking: the subscribers whose buffer is full are
+// dropped and notified of the overflow.
+func (b *eventBroadcaster) publish(event streamEvent, matches func(*eventSubscriber) bool) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for sub := range b.subscribers {
+		if !matches(sub) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.overflowed)
+		}
+	}
+}
+
+// publishCommittedBlock publishes the block committed at the height and its transactions
+func (s *rpcServer) publishCommittedBlock(height uint64) error {
+	if s.events.hasSubscribers((*eventSubscriber).wantsNewBlocks) {
+		block, err := s.getBlock(height)
+		if err != nil {
+			return err
+		}
+		s.events.publish(streamEvent{topic: newBlockTopic, payload: block}, (*eventSubscriber).wantsNewBlocks)
+	}
+
+	if !s.events.hasSubscribers((*eventSubscriber).wantsTxs) {
+		return nil
+	}
+	persistenceModule := s.GetBus().GetPersistenceModule()
+	cursor := ""
+	for {
+		txResults, nextCursor, err := persistenceModule.GetTransactionsByHeight(int64(height), cursor, maxTxsPageLimit, false)
+		if err != nil {
+			return err
+		}
+		for _, txResult := range txResults {
+			s.events.publish(streamEvent{topic: txTopic, payload: txResult}, func(sub *eventSubscriber) bool { return sub.wantsTx(txResult) })
+		}
+		if nextCursor == "" {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (s *rpcServer) publishStateMachineTransition(transition *messaging.StateMachineTransitionEvent) {
+	s.events.publish(streamEvent{topic: fsmTransitionTopic, payload: transition}, (*eventSubscriber).wantsFSMTransitions)
+}
+
+func (s *rpcServer) publishMempoolTx(txBz []byte) {
+	s.events.publish(streamEvent{topic: mempoolTxTopic, payload: txBz}, (*eventSubscriber).wantsMempoolTxs)
+}
+
+func (s *rpcServer) GetV1Events(ctx echo.Context, params GetV1EventsParams) error {
+	sub, err := newEventSubscriber(params.Topic)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, err.Error())
+	}
+	unsubscribe := s.events.subscribe(sub)
+	defer unsubscribe()
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-sub.overflowed:
+			return writeServerSentEvent(response, overflowEvent, []byte(fmt.Sprintf("more than %d events were pending", eventsBufferSize)))
+		case event := <-sub.events:
+			data, err := newServerSentEventData(event)
+			if err != nil {
+				return err
+			}
+			if err := writeServerSentEvent(response, event.topic, data); err != nil {
+				return err
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+			response.Flush()
+		}
+	}
+}
+
+// newServerSentEventData JSON encodes the payload of the event into the type of the REST API
+func newServerSentEventData(event streamEvent) ([]byte, error) {
+	switch payload := event.payload.(type) {
+	case *coreTypes.Block:
+		return json.Marshal(newBlock(payload))
+	case modules.TxResult:
+		tx, err := newTransaction(payload)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(tx)
+	case *messaging.StateMachineTransitionEvent:
+		return json.Marshal(StateMachineTransition{
+			Event:         payload.Event,
+			PreviousState: payload.PreviousState,
+			NewState:      payload.NewState,
+		})
+	case []byte:
+		return json.Marshal(MempoolTx{
+			Hash: coreTypes.TxHash(payload),
+			Tx:   hex.EncodeToString(payload),
+		})
+	default:
+		return nil, fmt.Errorf("unexpected payload of %s event: %T", event.topic, event.payload)
+	}
+}
+
+// writeServerSentEvent writes the event in the `text/event-stream` format. The data must not contain any newline,
+// which holds for JSON encoded data.
+func writeServerSentEvent(response *echo.Response, name string, data []byte) error {
+	if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", name, data); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/pokt-network/pocket/shared/modules"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/shared/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvents_InvalidTopics(t *testing.T) {
+	e, _, _ := newTestQueryServer(t)
+
+	tooManyTopics := make([]string, maxSubscriptionsPerConnection+1)
+	for i := range tooManyTopics {
+		tooManyTopics[i] = "topic=" + newBlockTopic
+	}
+
+	for _, query := range []string{
+		"",
+		"topic=unknown",
+		"topic=tx:sender:nothex",
+		"topic=tx:recipient:",
+		strings.Join(tooManyTopics, "&"),
+	} {
+		require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, eventsPath+"?"+query, nil), query)
+	}
+}
+
+func TestEvents_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockStore := kvstore.NewMemKVStore()
+	t.Cleanup(func() {
+		require.NoError(t, blockStore.Stop())
+	})
+	block := &coreTypes.Block{BlockHeader: &coreTypes.BlockHeader{Height: 5}}
+	blockBz, err := codec.GetCodec().Marshal(block)
+	require.NoError(t, err)
+	require.NoError(t, blockStore.Set(utils.HeightToBytes(5), blockBz))
+
+	sender := "00112233445566778899aabbccddeeff00112233"
+	sentTx := &indexer.TxRes{Tx: []byte{0x01}, Height: 5, Index: 1, SignerAddr: sender}
+	otherTx := &indexer.TxRes{Tx: []byte{0x02}, Height: 5, Index: 0, SignerAddr: "other"}
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().GetBlockStore().Return(blockStore).AnyTimes()
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "", maxTxsPageLimit, false).Return([]modules.TxResult{otherTx, sentTx}, "", nil)
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+
+	s := NewRPCServer(busMock)
+	e := echo.New()
+	RegisterHandlers(e, s)
+	httpServer := httptest.NewServer(e)
+	t.Cleanup(httpServer.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	query := url.Values{"topic": []string{newBlockTopic, txSenderTopicPrefix + strings.ToUpper(sender), fsmTransitionTopic}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+eventsPath+"?"+query.Encode(), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+	// The headers are flushed once the connection is subscribed
+	s.publishMempoolTx([]byte{0x03}) // not subscribed to
+	require.NoError(t, s.publishCommittedBlock(5))
+	s.publishStateMachineTransition(&messaging.StateMachineTransitionEvent{
+		Event:         string(coreTypes.StateMachineEvent_Start),
+		PreviousState: string(coreTypes.StateMachineState_Stopped),
+		NewState:      string(coreTypes.StateMachineState_P2P_Bootstrapping),
+	})
+
+	reader := bufio.NewReader(resp.Body)
+
+	var blockEvent Block
+	readTestEvent(t, reader, newBlockTopic, &blockEvent)
+	require.Equal(t, int64(5), blockEvent.BlockHeader.Height)
+
+	var txEvent Transaction
+	readTestEvent(t, reader, txTopic, &txEvent)
+	require.Equal(t, int32(1), txEvent.Index)
+	require.Equal(t, sender, txEvent.SignerAddr)
+
+	var transitionEvent StateMachineTransition
+	readTestEvent(t, reader, fsmTransitionTopic, &transitionEvent)
+	require.Equal(t, string(coreTypes.StateMachineState_P2P_Bootstrapping), transitionEvent.NewState)
+
+	// The subscription ends with the connection
+	cancel()
+	require.Eventually(t, func() bool {
+		return !s.events.hasSubscribers(func(*eventSubscriber) bool { return true })
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestEvents_Overflow(t *testing.T) {
+	b := newEventBroadcaster()
+	slow, err := newEventSubscriber([]string{newBlockTopic})
+	require.NoError(t, err)
+	other, err := newEventSubscriber([]string{fsmTransitionTopic})
+	require.NoError(t, err)
+	b.subscribe(slow)
+	b.subscribe(other)
+
+	for i := 0; i < eventsBufferSize; i++ {
+		b.publish(streamEvent{topic: newBlockTopic, payload: &coreTypes.Block{}}, (*eventSubscriber).wantsNewBlocks)
+	}
+	require.Len(t, slow.events, eventsBufferSize)
+	require.Len(t, b.subscribers, 2)
+
+	b.publish(streamEvent{topic: newBlockTopic, payload: &coreTypes.Block{}}, (*eventSubscriber).wantsNewBlocks)
+	require.Len(t, b.subscribers, 1)
+	select {
+	case <-slow.overflowed:
+	default:
+		t.Fatal("the slow subscriber was not notified of the overflow")
+	}
+
+	// The other subscribers are unaffected
+	b.publish(streamEvent{topic: fsmTransitionTopic, payload: &messaging.StateMachineTransitionEvent{}}, (*eventSubscriber).wantsFSMTransitions)
+	require.Len(t, other.events, 1)
+}
+
+// readTestEvent reads the next server-sent event and decodes its data into `data`
+func readTestEvent(t *testing.T, reader *bufio.Reader, name string, data any) {
+	t.Helper()
+
+	eventLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "event: "+name+"\n", eventLine)
+
+	dataLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(dataLine, "data: "))
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(dataLine, "data: ")), data))
+
+	emptyLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "\n", emptyLine)
+}
@@ -0,0 +1,511 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/pokt-network/pocket/app"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	rpcTypes "github.com/pokt-network/pocket/rpc/types"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements the gRPC services defined in `rpc/types/proto/rpc.proto` on top of the helpers shared with the
+// REST handlers, so both APIs behave the same
+type grpcServer struct {
+	rpcTypes.UnimplementedNodeServiceServer
+	rpcTypes.UnimplementedClientServiceServer
+	rpcTypes.UnimplementedQueryServiceServer
+	rpcTypes.UnimplementedEventServiceServer
+
+	*rpcServer
+}
+
+var (
+	_ rpcTypes.NodeServiceServer   = &grpcServer{}
+	_ rpcTypes.ClientServiceServer = &grpcServer{}
+	_ rpcTypes.QueryServiceServer  = &grpcServer{}
+	_ rpcTypes.EventServiceServer  = &grpcServer{}
+)
+
+// StartGRPC serves the gRPC services, along with the standard health service and reflection so that generic clients
+// (e.g. grpcurl) can discover them
+func (s *rpcServer) StartGRPC(port string) {
+	s.logger.Info().Msgf("Starting gRPC on port " + port)
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("gRPC server failed to listen")
+	}
+	if err := s.newGRPCServer().Serve(listener); err != nil {
+		s.logger.Fatal().Err(err).Msg("gRPC server failed to serve")
+	}
+}
+
+func (s *rpcServer) newGRPCServer() *grpc.Server {
+	var opts []grpc.ServerOption
+	if s.limits != nil {
+		opts = s.limits.grpcServerOptions()
+	}
+	server := grpc.NewServer(opts...)
+	g := &grpcServer{rpcServer: s}
+	rpcTypes.RegisterNodeServiceServer(server, g)
+	rpcTypes.RegisterClientServiceServer(server, g)
+	rpcTypes.RegisterQueryServiceServer(server, g)
+	rpcTypes.RegisterEventServiceServer(server, g)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	return server
+}
+
+// grpcMethodRoutes maps the gRPC methods to the path of the REST route they mirror, so the route quotas of the limits
+// apply to both APIs
+var grpcMethodRoutes = map[string]string{
+	"/rpc.NodeService/GetVersion":          "/v1/version",
+	"/rpc.NodeService/GetConsensusState":   "/v1/consensus/state",
+	"/rpc.ClientService/BroadcastTxSync":   "/v1/client/broadcast_tx_sync",
+	"/rpc.ClientService/BroadcastTxAsync":  "/v1/client/broadcast_tx_async",
+	"/rpc.ClientService/BroadcastTxCommit": broadcastTxCommitPath,
+	"/rpc.QueryService/GetHeight":          "/v1/query/height",
+	"/rpc.QueryService/GetAccount":         "/v1/query/account",
+	"/rpc.QueryService/GetPools":           "/v1/query/pools",
+	"/rpc.QueryService/GetParam":           "/v1/query/param",
+	"/rpc.QueryService/GetFlag":            "/v1/query/flag",
+	"/rpc.QueryService/GetBlock":           "/v1/query/block",
+	"/rpc.QueryService/GetTx":              "/v1/query/tx",
+	"/rpc.QueryService/GetTxsByHeight":     "/v1/query/txs_by_height",
+	"/rpc.QueryService/GetAccountTxs":      "/v1/query/account_txs",
+	"/rpc.QueryService/GetStateProof":      "/v1/query/proof",
+	"/rpc.EventService/SubscribeBlocks":    eventsPath,
+	"/rpc.EventService/SubscribeTxs":       eventsPath,
+}
+
+var grpcActorRoutes = map[coreTypes.ActorType]string{
+	coreTypes.ActorType_ACTOR_TYPE_APP:      "/v1/query/app",
+	coreTypes.ActorType_ACTOR_TYPE_SERVICER: "/v1/query/servicer",
+	coreTypes.ActorType_ACTOR_TYPE_FISH:     "/v1/query/fisherman",
+	coreTypes.ActorType_ACTOR_TYPE_VAL:      "/v1/query/validator",
+}
+
+// grpcMethodRoute returns the path of the REST route the gRPC method mirrors for the request, or the full name of the
+// method if it has no REST equivalent (e.g. the health & reflection services)
+func grpcMethodRoute(fullMethod string, req interface{}) string {
+	if actorReq, ok := req.(*rpcTypes.QueryActorRequest); ok {
+		if route, ok := grpcActorRoutes[actorReq.GetActorType()]; ok {
+			return route
+		}
+	}
+	if route, ok := grpcMethodRoutes[fullMethod]; ok {
+		return route
+	}
+	return fullMethod
+}
+
+// NodeService
+
+func (g *grpcServer) GetVersion(context.Context, *rpcTypes.GetVersionRequest) (*rpcTypes.GetVersionResponse, error) {
+	return &rpcTypes.GetVersionResponse{Version: app.AppVersion}, nil
+}
+
+func (g *grpcServer) GetConsensusState(context.Context, *rpcTypes.GetConsensusStateRequest) (*rpcTypes.GetConsensusStateResponse, error) {
+	consensus := g.GetBus().GetConsensusModule()
+	return &rpcTypes.GetConsensusStateResponse{
+		Height: consensus.CurrentHeight(),
+		Round:  consensus.CurrentRound(),
+		Step:   consensus.CurrentStep(),
+	}, nil
+}
+
+// ClientService
+
+func (g *grpcServer) BroadcastTxSync(_ context.Context, req *rpcTypes.BroadcastTxRequest) (*rpcTypes.BroadcastTxResponse, error) {
+	if err := g.handleAndBroadcastTx(req.Tx); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &rpcTypes.BroadcastTxResponse{Hash: coreTypes.TxHash(req.Tx)}, nil
+}
+
+func (g *grpcServer) BroadcastTxAsync(_ context.Context, req *rpcTypes.BroadcastTxRequest) (*rpcTypes.BroadcastTxResponse, error) {
+	if err := g.asyncTxs.enqueue(req.Tx); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &rpcTypes.BroadcastTxResponse{Hash: coreTypes.TxHash(req.Tx)}, nil
+}
+
+func (g *grpcServer) BroadcastTxCommit(ctx context.Context, req *rpcTypes.BroadcastTxRequest) (*rpcTypes.BroadcastTxCommitResponse, error) {
+	txResult, httpStatus, err := g.broadcastTxCommit(ctx, req.Tx)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	result, err := newGRPCTransaction(txResult)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &rpcTypes.BroadcastTxCommitResponse{
+		Hash:   coreTypes.TxHash(req.Tx),
+		Result: result,
+	}, nil
+}
+
+// QueryService
+
+func (g *grpcServer) GetHeight(context.Context, *rpcTypes.QueryHeightRequest) (*rpcTypes.QueryHeightResponse, error) {
+	height, err := g.getLatestCommittedHeight()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &rpcTypes.QueryHeightResponse{Height: int64(height)}, nil
+}
+
+func (g *grpcServer) GetAccount(_ context.Context, req *rpcTypes.QueryAccountRequest) (*rpcTypes.QueryAccountResponse, error) {
+	address, err := hex.DecodeString(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cannot decode address")
+	}
+
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	amount, err := persistenceContext.GetAccountAmount(address, height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &rpcTypes.QueryAccountResponse{
+		Account: &coreTypes.Account{
+			Address: req.Address,
+			Amount:  amount,
+		},
+		Height: height,
+	}, nil
+}
+
+func (g *grpcServer) GetPools(_ context.Context, req *rpcTypes.QueryPoolsRequest) (*rpcTypes.QueryPoolsResponse, error) {
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	pools, err := persistenceContext.GetAllPools(height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &rpcTypes.QueryPoolsResponse{
+		Pools:  pools,
+		Height: height,
+	}, nil
+}
+
+func (g *grpcServer) GetActor(_ context.Context, req *rpcTypes.QueryActorRequest) (*rpcTypes.QueryActorResponse, error) {
+	if req.ActorType == coreTypes.ActorType_ACTOR_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "unspecified actor type")
+	}
+	address, err := hex.DecodeString(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cannot decode address")
+	}
+
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	actor, err := persistenceContext.GetActor(req.ActorType, address, height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if actor == nil {
+		return nil, status.Errorf(codes.NotFound, "no %s found at address %s at height %d", req.ActorType, req.Address, height)
+	}
+
+	return &rpcTypes.QueryActorResponse{
+		Actor:  actor,
+		Height: height,
+	}, nil
+}
+
+func (g *grpcServer) GetParam(_ context.Context, req *rpcTypes.QueryParamRequest) (*rpcTypes.QueryParamResponse, error) {
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	// Params are stored as strings, so the string getter returns the value of a param of any type
+	value, err := persistenceContext.GetStringParam(req.Name, height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &rpcTypes.QueryParamResponse{
+		Name:   req.Name,
+		Value:  value,
+		Height: height,
+	}, nil
+}
+
+func (g *grpcServer) GetFlag(_ context.Context, req *rpcTypes.QueryFlagRequest) (*rpcTypes.QueryFlagResponse, error) {
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	// Flags are stored as strings, so the string getter returns the value of a flag of any type
+	value, enabled, err := persistenceContext.GetStringFlag(req.Name, height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &rpcTypes.QueryFlagResponse{
+		Name:    req.Name,
+		Value:   value,
+		Enabled: enabled,
+		Height:  height,
+	}, nil
+}
+
+func (g *grpcServer) GetBlock(_ context.Context, req *rpcTypes.QueryBlockRequest) (*rpcTypes.QueryBlockResponse, error) {
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	block, err := g.getBlock(uint64(height))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no block found at height %d", height)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &rpcTypes.QueryBlockResponse{Block: block}, nil
+}
+
+func (g *grpcServer) GetTx(_ context.Context, req *rpcTypes.QueryTxRequest) (*rpcTypes.QueryTxResponse, error) {
+	if _, err := hex.DecodeString(req.Hash); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cannot decode hash")
+	}
+
+	persistenceContext, height, httpStatus, err := g.newQueryContext(req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	defer persistenceContext.Close()
+
+	txResult, err := g.GetBus().GetPersistenceModule().GetTransactionByHash(req.Hash)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if txResult == nil || txResult.GetHeight() > height {
+		return nil, status.Errorf(codes.NotFound, "no transaction found with hash %s at height %d", req.Hash, height)
+	}
+
+	tx, err := newGRPCTransaction(txResult)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &rpcTypes.QueryTxResponse{Transaction: tx}, nil
+}
+
+func (g *grpcServer) GetTxsByHeight(_ context.Context, req *rpcTypes.QueryTxsByHeightRequest) (*rpcTypes.QueryTxsResponse, error) {
+	persistenceModule := g.GetBus().GetPersistenceModule()
+	return g.queryGRPCTxs(&req.Height, req.Cursor, req.Limit, req.Descending,
+		func(cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+			return persistenceModule.GetTransactionsByHeight(req.Height, cursor, limit, descending)
+		})
+}
+
+func (g *grpcServer) GetAccountTxs(_ context.Context, req *rpcTypes.QueryAccountTxsRequest) (*rpcTypes.QueryTxsResponse, error) {
+	if _, err := hex.DecodeString(req.Address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cannot decode address")
+	}
+
+	persistenceModule := g.GetBus().GetPersistenceModule()
+	var getTransactions func(address, cursor string, limit int, descending bool) ([]modules.TxResult, string, error)
+	switch req.Role {
+	case rpcTypes.TxRole_TX_ROLE_UNSPECIFIED:
+		getTransactions = persistenceModule.GetTransactionsByAddress
+	case rpcTypes.TxRole_TX_ROLE_SENDER:
+		getTransactions = persistenceModule.GetTransactionsBySender
+	case rpcTypes.TxRole_TX_ROLE_RECIPIENT:
+		getTransactions = persistenceModule.GetTransactionsByRecipient
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role %s", req.Role)
+	}
+
+	return g.queryGRPCTxs(nil, req.Cursor, req.Limit, req.Descending,
+		func(cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+			return getTransactions(req.Address, cursor, limit, descending)
+		})
+}
+
+func (g *grpcServer) queryGRPCTxs(requestedHeight *int64, cursor string, limit int32, descending bool, query txsQuery) (*rpcTypes.QueryTxsResponse, error) {
+	pageLimit := defaultTxsPageLimit
+	if limit != 0 {
+		pageLimit = int(limit)
+	}
+
+	txResults, nextCursor, height, httpStatus, err := g.getTxsPage(requestedHeight, cursor, pageLimit, descending, query)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+
+	response := &rpcTypes.QueryTxsResponse{
+		Transactions: make([]*rpcTypes.Transaction, 0, len(txResults)),
+		NextCursor:   nextCursor,
+		Height:       height,
+	}
+	for _, txResult := range txResults {
+		tx, err := newGRPCTransaction(txResult)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Transactions = append(response.Transactions, tx)
+	}
+	return response, nil
+}
+
+func (g *grpcServer) GetStateProof(_ context.Context, req *rpcTypes.QueryStateProofRequest) (*rpcTypes.StateProof, error) {
+	proof, httpStatus, err := g.getStateProof(StateProofTypesEnum(req.Type), req.Key, req.Height)
+	if err != nil {
+		return nil, grpcError(httpStatus, err)
+	}
+	return newGRPCStateProof(proof), nil
+}
+
+// EventService
+
+func (g *grpcServer) SubscribeBlocks(_ *rpcTypes.SubscribeBlocksRequest, stream rpcTypes.EventService_SubscribeBlocksServer) error {
+	sub, err := newEventSubscriber([]string{newBlockTopic})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return g.streamEvents(stream.Context(), sub, func(event streamEvent) error {
+		block, ok := event.payload.(*coreTypes.Block)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected payload of %s event: %T", event.topic, event.payload)
+		}
+		return stream.Send(block)
+	})
+}
+
+func (g *grpcServer) SubscribeTxs(req *rpcTypes.SubscribeTxsRequest, stream rpcTypes.EventService_SubscribeTxsServer) error {
+	topics := make([]string, 0, len(req.Senders)+len(req.Recipients))
+	for _, sender := range req.Senders {
+		topics = append(topics, txSenderTopicPrefix+sender)
+	}
+	for _, recipient := range req.Recipients {
+		topics = append(topics, txRecipientTopicPrefix+recipient)
+	}
+	if len(topics) == 0 {
+		topics = append(topics, txTopic)
+	}
+
+	sub, err := newEventSubscriber(topics)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return g.streamEvents(stream.Context(), sub, func(event streamEvent) error {
+		txResult, ok := event.payload.(modules.TxResult)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected payload of %s event: %T", event.topic, event.payload)
+		}
+		tx, err := newGRPCTransaction(txResult)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(tx)
+	})
+}
+
+// streamEvents sends the events of the subscriber until the stream is closed or the subscriber is dropped for not
+// keeping up with the stream
+func (g *grpcServer) streamEvents(ctx context.Context, sub *eventSubscriber, send func(streamEvent) error) error {
+	unsubscribe := g.events.subscribe(sub)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-sub.overflowed:
+			return status.Errorf(codes.ResourceExhausted, "more than %d events were pending", eventsBufferSize)
+		case event := <-sub.events:
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcError converts an error returned along with its HTTP status by the helpers shared with the REST handlers
+func grpcError(httpStatus int, err error) error {
+	code := codes.Internal
+	switch httpStatus {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusGone:
+		code = codes.OutOfRange
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	}
+	return status.Error(code, err.Error())
+}
+
+func newGRPCTransaction(txResult modules.TxResult) (*rpcTypes.Transaction, error) {
+	hash, err := txResult.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &rpcTypes.Transaction{
+		Hash:          hex.EncodeToString(hash),
+		Height:        txResult.GetHeight(),
+		Index:         txResult.GetIndex(),
+		Tx:            txResult.GetTx(),
+		ResultCode:    txResult.GetResultCode(),
+		Error:         txResult.GetError(),
+		SignerAddr:    txResult.GetSignerAddr(),
+		RecipientAddr: txResult.GetRecipientAddr(),
+		MessageType:   txResult.GetMessageType(),
+	}, nil
+}
+
+func newGRPCStateProof(proof *coreTypes.StateProof) *rpcTypes.StateProof {
+	return &rpcTypes.StateProof{
+		Height: int64(proof.Height),
+		Tree:   int32(proof.Tree),
+		Key:    proof.Key,
+		Value:  proof.Value,
+		Proof: &rpcTypes.SparseMerkleProof{
+			SideNodes:             proof.Proof.SideNodes,
+			NonMembershipLeafData: proof.Proof.NonMembershipLeafData,
+			SiblingData:           proof.Proof.SiblingData,
+		},
+		TreeRoots: proof.TreeRoots,
+		StateHash: coreTypes.ComputeStateHash(proof.TreeRoots),
+	}
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	rpcTypes "github.com/pokt-network/pocket/rpc/types"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Returns a client connection to the gRPC server of the RPC server, served in memory
+func newTestGRPCConn(t *testing.T, s *rpcServer) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := s.newGRPCServer()
+	go server.Serve(listener) // nolint:errcheck // Serve returns once the server is stopped
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return conn
+}
+
+// Returns a gRPC query client of an RPC server whose persistence module is mocked, with the same latest height as the
+// REST query server
+func newTestGRPCQueryClient(t *testing.T) (rpcTypes.QueryServiceClient, *rpcServer, *mockModules.MockPersistenceModule, *mockModules.MockPersistenceReadContext) {
+	ctrl := gomock.NewController(t)
+
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().Return(testLatestHeight, nil).AnyTimes()
+	readCtxMock.EXPECT().GetRetainedHeights().Return(int64(0), uint64(0), nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+
+	s := NewRPCServer(busMock)
+	return rpcTypes.NewQueryServiceClient(newTestGRPCConn(t, s)), s, persistenceMock, readCtxMock
+}
+
+func TestGRPC_Query(t *testing.T) {
+	client, _, persistenceMock, readCtxMock := newTestGRPCQueryClient(t)
+	ctx := context.Background()
+	address := "00112233445566778899aabbccddeeff00112233"
+	addressBz := []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x00, 0x11, 0x22, 0x33}
+
+	heightResponse, err := client.GetHeight(ctx, &rpcTypes.QueryHeightRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(testLatestHeight), heightResponse.Height)
+
+	height := int64(5)
+	readCtxMock.EXPECT().GetAccountAmount(addressBz, height).Return("100", nil)
+	accountResponse, err := client.GetAccount(ctx, &rpcTypes.QueryAccountRequest{Address: address, Height: &height})
+	require.NoError(t, err)
+	require.Equal(t, "100", accountResponse.Account.Amount)
+	require.Equal(t, height, accountResponse.Height)
+
+	// The errors of the helpers shared with the REST API are converted to gRPC statuses
+	futureHeight := int64(testLatestHeight + 1)
+	_, err = client.GetAccount(ctx, &rpcTypes.QueryAccountRequest{Address: address, Height: &futureHeight})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, codes.OutOfRange, status.Code(grpcError(http.StatusGone, errors.New("height 5 was pruned"))))
+
+	readCtxMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_VAL, addressBz, int64(testLatestHeight)).Return(nil, nil)
+	_, err = client.GetActor(ctx, &rpcTypes.QueryActorRequest{ActorType: coreTypes.ActorType_ACTOR_TYPE_VAL, Address: address})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	blockStore := kvstore.NewMemKVStore()
+	t.Cleanup(func() {
+		require.NoError(t, blockStore.Stop())
+	})
+	persistenceMock.EXPECT().GetBlockStore().Return(blockStore)
+	_, err = client.GetBlock(ctx, &rpcTypes.QueryBlockRequest{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	txResults := []modules.TxResult{
+		&indexer.TxRes{Tx: []byte{0x01}, Height: 5, Index: 0},
+		&indexer.TxRes{Tx: []byte{0x02}, Height: 5, Index: 1},
+	}
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "", defaultTxsPageLimit, false).Return(txResults, "next", nil)
+	txsResponse, err := client.GetTxsByHeight(ctx, &rpcTypes.QueryTxsByHeightRequest{Height: 5})
+	require.NoError(t, err)
+	require.Len(t, txsResponse.Transactions, 2)
+	require.Equal(t, []byte{0x02}, txsResponse.Transactions[1].Tx)
+	require.Equal(t, "next", txsResponse.NextCursor)
+
+	_, err = client.GetTxsByHeight(ctx, &rpcTypes.QueryTxsByHeightRequest{Height: 5, Limit: maxTxsPageLimit + 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_BroadcastTxSync(t *testing.T) {
+	_, s, _, _ := newTestBroadcastServer(t, new(uint64))
+	client := rpcTypes.NewClientServiceClient(newTestGRPCConn(t, s))
+
+	txBz := []byte("tx")
+	response, err := client.BroadcastTxSync(context.Background(), &rpcTypes.BroadcastTxRequest{Tx: txBz})
+	require.NoError(t, err)
+	require.Equal(t, coreTypes.TxHash(txBz), response.Hash)
+}
+
+func TestGRPC_BroadcastTxAsync_QueueFull(t *testing.T) {
+	_, s, _, _ := newTestBroadcastServer(t, new(uint64))
+	s.asyncTxs = &asyncTxQueue{txs: make(chan []byte, 1)}
+	client := rpcTypes.NewClientServiceClient(newTestGRPCConn(t, s))
+
+	response, err := client.BroadcastTxAsync(context.Background(), &rpcTypes.BroadcastTxRequest{Tx: []byte("tx")})
+	require.NoError(t, err)
+	require.Equal(t, coreTypes.TxHash([]byte("tx")), response.Hash)
+	_, err = client.BroadcastTxAsync(context.Background(), &rpcTypes.BroadcastTxRequest{Tx: []byte("tx2")})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestGRPC_SubscribeTxs(t *testing.T) {
+	_, s, persistenceMock, _ := newTestGRPCQueryClient(t)
+	eventsClient := rpcTypes.NewEventServiceClient(newTestGRPCConn(t, s))
+
+	recipient := "00112233445566778899aabbccddeeff00112233"
+	receivedTx := &indexer.TxRes{Tx: []byte{0x01}, Height: 5, Index: 1, RecipientAddr: recipient}
+	otherTx := &indexer.TxRes{Tx: []byte{0x02}, Height: 5, Index: 0, RecipientAddr: "other"}
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "", maxTxsPageLimit, false).Return([]modules.TxResult{otherTx, receivedTx}, "", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := eventsClient.SubscribeTxs(ctx, &rpcTypes.SubscribeTxsRequest{Recipients: []string{recipient}})
+	require.NoError(t, err)
+
+	// The stream is established lazily, so the subscription is awaited before publishing
+	require.Eventually(t, func() bool {
+		return s.events.hasSubscribers((*eventSubscriber).wantsTxs)
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, s.publishCommittedBlock(5))
+
+	tx, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int32(1), tx.Index)
+	require.Equal(t, recipient, tx.RecipientAddr)
+
+	invalidStream, err := eventsClient.SubscribeTxs(ctx, &rpcTypes.SubscribeTxsRequest{Senders: []string{"nothex"}})
+	require.NoError(t, err)
+	_, err = invalidStream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ReflectionAndHealth(t *testing.T) {
+	_, s, _, _ := newTestGRPCQueryClient(t)
+	conn := newTestGRPCConn(t, s)
+	ctx := context.Background()
+
+	healthResponse, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, healthResponse.Status)
+
+	reflectionStream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	reflectionResponse, err := reflectionStream.Recv()
+	require.NoError(t, err)
+
+	services := make([]string, 0)
+	for _, service := range reflectionResponse.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	require.Subset(t, services, []string{"rpc.NodeService", "rpc.ClientService", "rpc.QueryService", "rpc.EventService"})
+}
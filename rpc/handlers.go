@@ -2,13 +2,14 @@ package rpc
 
 import (
 	"encoding/hex"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pokt-network/pocket/app"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/modules"
-	"github.com/pokt-network/pocket/utility"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
 
 func (s *rpcServer) GetV1Health(ctx echo.Context) error {
@@ -20,25 +21,51 @@ func (s *rpcServer) GetV1Version(ctx echo.Context) error {
 }
 
 func (s *rpcServer) PostV1ClientBroadcastTxSync(ctx echo.Context) error {
-	txParams := new(RawTXRequest)
-	if err := ctx.Bind(txParams); err != nil {
-		return ctx.String(http.StatusBadRequest, "bad request")
+	txBz, err := bindRawTx(ctx)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, err.Error())
 	}
 
-	txBz, err := hex.DecodeString(txParams.RawHexBytes)
+	if err := s.handleAndBroadcastTx(txBz); err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, BroadcastTxResponse{Hash: coreTypes.TxHash(txBz)})
+}
+
+func (s *rpcServer) PostV1ClientBroadcastTxAsync(ctx echo.Context) error {
+	txBz, err := bindRawTx(ctx)
 	if err != nil {
-		return ctx.String(http.StatusBadRequest, "cannot decode tx bytes")
+		return ctx.String(http.StatusBadRequest, err.Error())
 	}
 
-	if err = s.GetBus().GetUtilityModule().HandleTransaction(txBz); err != nil {
-		return ctx.String(http.StatusInternalServerError, err.Error())
+	if err := s.asyncTxs.enqueue(txBz); err != nil {
+		return ctx.String(http.StatusServiceUnavailable, err.Error())
 	}
 
-	if err := s.broadcastMessage(txBz); err != nil {
-		return ctx.String(http.StatusInternalServerError, err.Error())
+	return ctx.JSON(http.StatusOK, BroadcastTxResponse{Hash: coreTypes.TxHash(txBz)})
+}
+
+func (s *rpcServer) PostV1ClientBroadcastTxCommit(ctx echo.Context) error {
+	txBz, err := bindRawTx(ctx)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, err.Error())
 	}
+	txHash := coreTypes.TxHash(txBz)
 
-	return nil
+	txResult, status, err := s.broadcastTxCommit(ctx.Request().Context(), txBz)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+
+	result, err := newTransaction(txResult)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, BroadcastTxCommitResponse{
+		Hash:   txHash,
+		Result: result,
+	})
 }
 
 func (s *rpcServer) GetV1ConsensusState(ctx echo.Context) error {
@@ -50,9 +77,37 @@ func (s *rpcServer) GetV1ConsensusState(ctx echo.Context) error {
 	})
 }
 
+func bindRawTx(ctx echo.Context) ([]byte, error) {
+	txParams := new(RawTXRequest)
+	if err := ctx.Bind(txParams); err != nil {
+		return nil, errors.New("bad request")
+	}
+
+	txBz, err := hex.DecodeString(txParams.RawHexBytes)
+	if err != nil {
+		return nil, errors.New("cannot decode tx bytes")
+	}
+	return txBz, nil
+}
+
+// handleAndBroadcastTx adds the transaction to the mempool if it is valid, and broadcasts it
+func (s *rpcServer) handleAndBroadcastTx(txBz []byte) error {
+	if err := s.GetBus().GetUtilityModule().HandleTransaction(txBz); err != nil {
+		return err
+	}
+	return s.broadcastMessage(txBz)
+}
+
+// handleAsyncTx is called by the workers of the async tx queue, which have no request to report the error to
+func (s *rpcServer) handleAsyncTx(txBz []byte) {
+	if err := s.handleAndBroadcastTx(txBz); err != nil {
+		s.logger.Error().Err(err).Str("hash", coreTypes.TxHash(txBz)).Msg("Failed to handle asynchronously broadcast transaction")
+	}
+}
+
 // Broadcast to the entire validator set
 func (s *rpcServer) broadcastMessage(msgBz []byte) error {
-	utilityMsg, err := utility.PrepareTxGossipMessage(msgBz)
+	utilityMsg, err := typesUtil.PrepareTxGossipMessage(msgBz)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to prepare transaction gossip message")
 		return err
@@ -110,13 +165,13 @@ func (s *rpcServer) GetV1P2pStakedActorsAddressBook(ctx echo.Context, params Get
 func protocolActorToRPCActorTypeEnum(protocolActorType coreTypes.ActorType) ActorTypesEnum {
 	switch protocolActorType {
 	case coreTypes.ActorType_ACTOR_TYPE_APP:
-		return Application
+		return ActorTypesEnumApplication
 	case coreTypes.ActorType_ACTOR_TYPE_FISH:
-		return Fisherman
+		return ActorTypesEnumFisherman
 	case coreTypes.ActorType_ACTOR_TYPE_SERVICER:
-		return Servicer
+		return ActorTypesEnumServicer
 	case coreTypes.ActorType_ACTOR_TYPE_VAL:
-		return Validator
+		return ActorTypesEnumValidator
 	default:
 		panic("invalid actor type")
 	}
@@ -129,14 +184,78 @@ func getProtocolActorGetter(persistenceContext modules.PersistenceReadContext, p
 		return persistenceContext.GetAllStakedActors
 	}
 	switch *params.ActorType {
-	case Application:
+	case ActorTypesEnumApplication:
 		protocolActorGetter = persistenceContext.GetAllApps
-	case Fisherman:
+	case ActorTypesEnumFisherman:
 		protocolActorGetter = persistenceContext.GetAllFishermen
-	case Servicer:
+	case ActorTypesEnumServicer:
 		protocolActorGetter = persistenceContext.GetAllServicers
-	case Validator:
+	case ActorTypesEnumValidator:
 		protocolActorGetter = persistenceContext.GetAllValidators
 	}
 	return protocolActorGetter
 }
+
+func (s *rpcServer) GetV1QueryProof(ctx echo.Context, params GetV1QueryProofParams) error {
+	proof, status, err := s.getStateProof(params.Type, params.Key, params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, newStateProof(proof))
+}
+
+// getStateProof returns the proof of the value of the key at the requested height, or at the latest committed height
+// if none is requested. It returns the HTTP status of the error if it fails.
+func (s *rpcServer) getStateProof(proofType StateProofTypesEnum, key string, requestedHeight *int64) (*coreTypes.StateProof, int, error) {
+	persistenceContext, err := s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	defer persistenceContext.Close()
+
+	var height int64
+	if requestedHeight != nil {
+		height = *requestedHeight
+	} else {
+		latestHeight, err := persistenceContext.GetMaximumBlockHeight()
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		height = int64(latestHeight)
+	}
+
+	var proof *coreTypes.StateProof
+	switch proofType {
+	case StateProofTypesEnumAccount:
+		address, decodeErr := hex.DecodeString(key)
+		if decodeErr != nil {
+			return nil, http.StatusBadRequest, errors.New("cannot decode address")
+		}
+		proof, err = persistenceContext.GetAccountProof(address, height)
+	case StateProofTypesEnumPool:
+		proof, err = persistenceContext.GetPoolProof(key, height)
+	case StateProofTypesEnumApplication, StateProofTypesEnumValidator, StateProofTypesEnumFisherman, StateProofTypesEnumServicer:
+		address, decodeErr := hex.DecodeString(key)
+		if decodeErr != nil {
+			return nil, http.StatusBadRequest, errors.New("cannot decode address")
+		}
+		proof, err = persistenceContext.GetActorProof(stateProofTypeToActorType[proofType], address, height)
+	case StateProofTypesEnumParam:
+		proof, err = persistenceContext.GetParamProof(key, height)
+	case StateProofTypesEnumFlag:
+		proof, err = persistenceContext.GetFlagProof(key, height)
+	case StateProofTypesEnumReportCard:
+		address, decodeErr := hex.DecodeString(key)
+		if decodeErr != nil {
+			return nil, http.StatusBadRequest, errors.New("cannot decode address")
+		}
+		proof, err = persistenceContext.GetReportCardProof(address, height)
+	default:
+		return nil, http.StatusBadRequest, errors.New("unknown state proof type")
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return proof, http.StatusOK, nil
+}
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/telemetry"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// rateLimiter combines a rate limit with the quotas of the routes it applies to. The clients (i.e. IPs or API keys) are
+// tracked independently by the underlying stores.
+type rateLimiter struct {
+	store       *middleware.RateLimiterMemoryStore            // nil if the rate limit is disabled
+	routeStores map[string]*middleware.RateLimiterMemoryStore // by echo route path (e.g. `/v1/mempool/tx/:hash`)
+}
+
+func newRateLimiter(rateLimit float64, burst uint32, routeQuotas []*configs.RPCRouteQuota) *rateLimiter {
+	limiter := &rateLimiter{
+		store:       newRateLimiterStore(rateLimit, burst),
+		routeStores: make(map[string]*middleware.RateLimiterMemoryStore, len(routeQuotas)),
+	}
+	for _, quota := range routeQuotas {
+		if store := newRateLimiterStore(quota.GetRateLimit(), quota.GetBurst()); store != nil {
+			limiter.routeStores[quota.GetPath()] = store
+		}
+	}
+	return limiter
+}
+
+func newRateLimiterStore(rateLimit float64, burst uint32) *middleware.RateLimiterMemoryStore {
+	if rateLimit <= 0 {
+		return nil
+	}
+	// A burst of 0 would reject every request when the rate limit is lower than 1 request per second
+	if burst == 0 {
+		burst = 1
+	}
+	return middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+		Rate:  rate.Limit(rateLimit),
+		Burst: int(burst),
+	})
+}
+
+// allow returns whether the client identified by `identifier` can call the route at `path`. The route quota is checked
+// first so that the requests it rejects do not count towards the rate limit of the client.
+func (l *rateLimiter) allow(identifier, path string) bool {
+	for _, store := range []*middleware.RateLimiterMemoryStore{l.routeStores[path], l.store} {
+		if store == nil {
+			continue
+		}
+		// The memory store never returns an error
+		if allowed, _ := store.Allow(identifier); !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// rpcLimits holds the rate limiters shared by the REST & gRPC APIs, so a client is limited across both of them
+type rpcLimits struct {
+	ipLimiter      *rateLimiter
+	apiKeyLimiters map[string]*rateLimiter
+	maxBodyBytes   int64 // 0 if the body size is unlimited
+	ipExtractor    echo.IPExtractor
+
+	timeSeriesAgent modules.TimeSeriesAgent
+}
+
+func newRPCLimits(limitsCfg *configs.RPCLimitsConfig, timeSeriesAgent modules.TimeSeriesAgent) *rpcLimits {
+	timeSeriesAgent.CounterRegister(
+		telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME,
+		telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_DESCRIPTION,
+	)
+	timeSeriesAgent.CounterRegister(
+		telemetry.RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_NAME,
+		telemetry.RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_DESCRIPTION,
+	)
+	timeSeriesAgent.CounterRegister(
+		telemetry.RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_NAME,
+		telemetry.RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_DESCRIPTION,
+	)
+
+	limits := &rpcLimits{
+		ipLimiter:       newRateLimiter(limitsCfg.GetRateLimit(), limitsCfg.GetBurst(), limitsCfg.GetRouteQuotas()),
+		apiKeyLimiters:  make(map[string]*rateLimiter, len(limitsCfg.GetApiKeys())),
+		maxBodyBytes:    int64(limitsCfg.GetMaxBodyBytes()),
+		ipExtractor:     ipExtractor(limitsCfg),
+		timeSeriesAgent: timeSeriesAgent,
+	}
+	for _, apiKey := range limitsCfg.GetApiKeys() {
+		limits.apiKeyLimiters[apiKey.GetKey()] = newRateLimiter(apiKey.GetRateLimit(), apiKey.GetBurst(), apiKey.GetRouteQuotas())
+	}
+	return limits
+}
+
+// allow checks the API key (if any) of a request to the route at `path` and the rate limits of its client, and counts
+// the rejected requests. It returns the HTTP status & the message of the rejection, or 0 if the request is allowed.
+func (limits *rpcLimits) allow(clientIP, apiKey, path string) (httpStatus int, msg string) {
+	limiter, identifier := limits.ipLimiter, clientIP
+	if apiKey != "" {
+		apiKeyLimiter, ok := limits.apiKeyLimiters[apiKey]
+		if !ok {
+			limits.timeSeriesAgent.CounterIncrement(telemetry.RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_NAME)
+			return http.StatusUnauthorized, "invalid API key"
+		}
+		limiter, identifier = apiKeyLimiter, apiKey
+	}
+
+	if !limiter.allow(identifier, path) {
+		limits.timeSeriesAgent.CounterIncrement(telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME)
+		return http.StatusTooManyRequests, "rate limit exceeded"
+	}
+	return 0, ""
+}
+
+// middleware rejects the requests whose body exceeds the maximum size, whose API key is unknown, or which exceed the
+// rate limits of their client IP or API key, and counts them in the RPC time series metrics.
+func (limits *rpcLimits) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if limits.maxBodyBytes > 0 {
+				if req.ContentLength > limits.maxBodyBytes {
+					limits.timeSeriesAgent.CounterIncrement(telemetry.RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_NAME)
+					return ctx.String(http.StatusRequestEntityTooLarge, fmt.Sprintf("the request body exceeds %d bytes", limits.maxBodyBytes))
+				}
+				// Covers the requests that do not declare the size of their body
+				req.Body = http.MaxBytesReader(ctx.Response(), req.Body, limits.maxBodyBytes)
+			}
+
+			if httpStatus, msg := limits.allow(ctx.RealIP(), req.Header.Get(apiKeyHeader), ctx.Path()); httpStatus != 0 {
+				return ctx.String(httpStatus, msg)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// grpcServerOptions applies the limits to the gRPC services. The maximum body size bounds the size of the messages
+// received, and the interceptors enforce the API keys & rate limits of the route each method mirrors.
+func (limits *rpcLimits) grpcServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := limits.allowGRPC(ctx, grpcMethodRoute(info.FullMethod, req)); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := limits.allowGRPC(stream.Context(), grpcMethodRoute(info.FullMethod, nil)); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+	if limits.maxBodyBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(limits.maxBodyBytes)))
+	}
+	return opts
+}
+
+func (limits *rpcLimits) allowGRPC(ctx context.Context, path string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var apiKey string
+	if apiKeys := md.Get(apiKeyHeader); len(apiKeys) > 0 {
+		apiKey = apiKeys[0]
+	}
+	if httpStatus, msg := limits.allow(limits.grpcClientIP(ctx, md), apiKey, path); httpStatus != 0 {
+		return grpcError(httpStatus, errors.New(msg))
+	}
+	return nil
+}
+
+// grpcClientIP determines the IP of a gRPC client the same way as for the REST API, from the address of the peer and
+// the `X-Forwarded-For` metadata
+func (limits *rpcLimits) grpcClientIP(ctx context.Context, md metadata.MD) string {
+	req := &http.Request{Header: make(http.Header)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+	for _, forwardedFor := range md.Get(echo.HeaderXForwardedFor) {
+		req.Header.Add(echo.HeaderXForwardedFor, forwardedFor)
+	}
+	return limits.ipExtractor(req)
+}
+
+// ipExtractor returns how the client IPs are determined for the rate limits
+func ipExtractor(limitsCfg *configs.RPCLimitsConfig) echo.IPExtractor {
+	if limitsCfg.GetTrustForwardedFor() {
+		// Only trusts the header set by proxies on loopback or private addresses
+		return echo.ExtractIPFromXFFHeader()
+	}
+	return echo.ExtractIPDirect()
+}
@@ -0,0 +1,225 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	rpcTypes "github.com/pokt-network/pocket/rpc/types"
+	"github.com/pokt-network/pocket/runtime/configs"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/telemetry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testLimitsQueryPath     = "/v1/query/height"
+	testLimitsBroadcastPath = "/v1/client/broadcast_tx_sync"
+	// Low enough for no token to be refilled during a test
+	testLimitsRateLimit = 0.001
+)
+
+// Returns an echo server restricted by `limitsCfg`, with a query route and a broadcast route that reads the body of
+// the requests, and the number of requests counted by each rejection metric
+func newTestLimitsServer(t *testing.T, limitsCfg *configs.RPCLimitsConfig) (*echo.Echo, map[string]int) {
+	ctrl := gomock.NewController(t)
+
+	rejectedCounts := make(map[string]int)
+	timeSeriesAgentMock := mockModules.NewMockTimeSeriesAgent(ctrl)
+	timeSeriesAgentMock.EXPECT().CounterRegister(gomock.Any(), gomock.Any()).Times(3)
+	timeSeriesAgentMock.EXPECT().CounterIncrement(gomock.Any()).Do(func(name string) {
+		rejectedCounts[name]++
+	}).AnyTimes()
+
+	e := echo.New()
+	e.IPExtractor = ipExtractor(limitsCfg)
+	e.Use(newRPCLimits(limitsCfg, timeSeriesAgentMock).middleware())
+	e.GET(testLimitsQueryPath, func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	})
+	e.POST(testLimitsBroadcastPath, func(ctx echo.Context) error {
+		if _, err := io.ReadAll(ctx.Request().Body); err != nil {
+			return ctx.String(http.StatusBadRequest, err.Error())
+		}
+		return ctx.NoContent(http.StatusOK)
+	})
+	return e, rejectedCounts
+}
+
+func doTestLimitsRequest(e *echo.Echo, method, target, remoteIP, apiKey string, body io.Reader) int {
+	req := httptest.NewRequest(method, target, body)
+	req.RemoteAddr = remoteIP + ":1234"
+	if apiKey != "" {
+		req.Header.Set(apiKeyHeader, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestLimits_RateLimitPerIP(t *testing.T) {
+	e, rejectedCounts := newTestLimitsServer(t, &configs.RPCLimitsConfig{
+		RateLimit: testLimitsRateLimit,
+		Burst:     2,
+		RouteQuotas: []*configs.RPCRouteQuota{
+			{Path: testLimitsBroadcastPath, RateLimit: testLimitsRateLimit, Burst: 1},
+		},
+	})
+
+	// The route quota is exhausted before the rate limit of the IP
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.1", "", nil))
+	require.Equal(t, http.StatusTooManyRequests, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.1", "", nil))
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "", nil))
+	require.Equal(t, http.StatusTooManyRequests, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "", nil))
+
+	// Every IP has its own limits
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.2", "", nil))
+
+	require.Equal(t, 2, rejectedCounts[telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME])
+}
+
+func TestLimits_APIKeys(t *testing.T) {
+	e, rejectedCounts := newTestLimitsServer(t, &configs.RPCLimitsConfig{
+		RateLimit: testLimitsRateLimit,
+		Burst:     1,
+		ApiKeys: []*configs.RPCAPIKeyConfig{
+			{Key: "unlimited"},
+			{
+				Key:         "limited",
+				RateLimit:   testLimitsRateLimit,
+				Burst:       3,
+				RouteQuotas: []*configs.RPCRouteQuota{{Path: testLimitsQueryPath, RateLimit: testLimitsRateLimit, Burst: 1}},
+			},
+		},
+	})
+
+	require.Equal(t, http.StatusUnauthorized, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "unknown", nil))
+	require.Equal(t, 1, rejectedCounts[telemetry.RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_NAME])
+
+	// The requests with an API key are not limited by the rate limit of their IP
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "", nil))
+	require.Equal(t, http.StatusTooManyRequests, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "", nil))
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "unlimited", nil))
+	}
+
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.1", "limited", nil))
+	require.Equal(t, http.StatusTooManyRequests, doTestLimitsRequest(e, http.MethodGet, testLimitsQueryPath, "10.0.0.2", "limited", nil))
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.2", "limited", nil))
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.2", "limited", nil))
+	require.Equal(t, http.StatusTooManyRequests, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.2", "limited", nil))
+
+	require.Equal(t, 3, rejectedCounts[telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME])
+}
+
+func TestLimits_MaxBodyBytes(t *testing.T) {
+	e, rejectedCounts := newTestLimitsServer(t, &configs.RPCLimitsConfig{MaxBodyBytes: 10})
+
+	require.Equal(t, http.StatusOK, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.1", "", strings.NewReader("small")))
+	require.Equal(t, http.StatusRequestEntityTooLarge, doTestLimitsRequest(e, http.MethodPost, testLimitsBroadcastPath, "10.0.0.1", "", strings.NewReader("larger than 10 bytes")))
+	require.Equal(t, 1, rejectedCounts[telemetry.RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_NAME])
+
+	// The body of a request that does not declare its size cannot be read past the limit
+	req := httptest.NewRequest(http.MethodPost, testLimitsBroadcastPath, io.NopCloser(strings.NewReader("larger than 10 bytes")))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "request body too large")
+}
+
+func TestLimits_TrustForwardedFor(t *testing.T) {
+	for _, trustForwardedFor := range []bool{false, true} {
+		e, _ := newTestLimitsServer(t, &configs.RPCLimitsConfig{
+			RateLimit:         testLimitsRateLimit,
+			Burst:             1,
+			TrustForwardedFor: trustForwardedFor,
+		})
+
+		codes := make([]int, 0, 2)
+		for _, clientIP := range []string{"1.2.3.4", "5.6.7.8"} {
+			req := httptest.NewRequest(http.MethodGet, testLimitsQueryPath, nil)
+			req.RemoteAddr = "127.0.0.1:1234"
+			req.Header.Set(echo.HeaderXForwardedFor, clientIP)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		// The clients behind the proxy are only told apart when the header is trusted
+		if trustForwardedFor {
+			require.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+		} else {
+			require.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+		}
+	}
+}
+
+func TestLimits_GRPC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rejectedCounts := make(map[string]int)
+	timeSeriesAgentMock := mockModules.NewMockTimeSeriesAgent(ctrl)
+	timeSeriesAgentMock.EXPECT().CounterRegister(gomock.Any(), gomock.Any()).Times(3)
+	timeSeriesAgentMock.EXPECT().CounterIncrement(gomock.Any()).Do(func(name string) {
+		rejectedCounts[name]++
+	}).AnyTimes()
+
+	s := NewRPCServer(mockModules.NewMockBus(ctrl))
+	s.limits = newRPCLimits(&configs.RPCLimitsConfig{
+		RateLimit:    testLimitsRateLimit,
+		Burst:        2,
+		MaxBodyBytes: 100,
+		RouteQuotas: []*configs.RPCRouteQuota{
+			{Path: "/v1/version", RateLimit: testLimitsRateLimit, Burst: 1},
+		},
+		ApiKeys: []*configs.RPCAPIKeyConfig{{Key: "unlimited"}},
+	}, timeSeriesAgentMock)
+	conn := newTestGRPCConn(t, s)
+	nodeClient := rpcTypes.NewNodeServiceClient(conn)
+	ctx := context.Background()
+
+	// The route quota of the REST route applies to the gRPC method mirroring it
+	_, err := nodeClient.GetVersion(ctx, &rpcTypes.GetVersionRequest{})
+	require.NoError(t, err)
+	_, err = nodeClient.GetVersion(ctx, &rpcTypes.GetVersionRequest{})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The API keys are passed in the metadata
+	_, err = nodeClient.GetVersion(metadata.AppendToOutgoingContext(ctx, apiKeyHeader, "unknown"), &rpcTypes.GetVersionRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	for i := 0; i < 3; i++ {
+		_, err = nodeClient.GetVersion(metadata.AppendToOutgoingContext(ctx, apiKeyHeader, "unlimited"), &rpcTypes.GetVersionRequest{})
+		require.NoError(t, err)
+	}
+
+	// The messages are limited to the maximum body size
+	_, err = rpcTypes.NewClientServiceClient(conn).BroadcastTxSync(ctx, &rpcTypes.BroadcastTxRequest{Tx: make([]byte, 200)})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The streams count towards the rate limit of the client too
+	eventClient := rpcTypes.NewEventServiceClient(conn)
+	for _, expectedCode := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		streamCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		stream, err := eventClient.SubscribeBlocks(streamCtx, &rpcTypes.SubscribeBlocksRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		if expectedCode == codes.OK {
+			require.Equal(t, codes.DeadlineExceeded, status.Code(err), "the stream is served until the client disconnects")
+		} else {
+			require.Equal(t, expectedCode, status.Code(err))
+		}
+		cancel()
+	}
+
+	require.Equal(t, 2, rejectedCounts[telemetry.RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME])
+	require.Equal(t, 1, rejectedCounts[telemetry.RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_NAME])
+}
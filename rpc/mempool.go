@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (s *rpcServer) GetV1MempoolTxs(ctx echo.Context, params GetV1MempoolTxsParams) error {
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	limit := defaultTxsPageLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if offset < 0 {
+		return ctx.String(http.StatusBadRequest, fmt.Sprintf("invalid offset %d, expected a non-negative number", offset))
+	}
+	if limit < 1 || limit > maxTxsPageLimit {
+		return ctx.String(http.StatusBadRequest, fmt.Sprintf("invalid limit %d, expected a number between 1 and %d", limit, maxTxsPageLimit))
+	}
+
+	// The transactions are decoded once the mempool is no longer locked by `Range`
+	page := make([][]byte, 0, limit)
+	totalCount := 0
+	s.GetBus().GetUtilityModule().GetMempool().Range(func(tx []byte) bool {
+		if totalCount >= offset && len(page) < limit {
+			page = append(page, tx)
+		}
+		totalCount++
+		return true
+	})
+
+	txs := make([]MempoolTransaction, len(page))
+	for i, txBz := range page {
+		txs[i] = newMempoolTransaction(txBz)
+	}
+
+	return ctx.JSON(http.StatusOK, MempoolTxsResponse{
+		Txs:        txs,
+		TotalCount: int64(totalCount),
+	})
+}
+
+func (s *rpcServer) GetV1MempoolTxHash(ctx echo.Context, hash string) error {
+	txBz := s.GetBus().GetUtilityModule().GetMempool().GetTx(hash)
+	if txBz == nil {
+		return ctx.String(http.StatusNotFound, fmt.Sprintf("transaction %s is not in the mempool", hash))
+	}
+	return ctx.JSON(http.StatusOK, newMempoolTransaction(txBz))
+}
+
+func (s *rpcServer) GetV1MempoolStats(ctx echo.Context) error {
+	txs := make([][]byte, 0)
+	s.GetBus().GetUtilityModule().GetMempool().Range(func(tx []byte) bool {
+		txs = append(txs, tx)
+		return true
+	})
+
+	utilityCfg := s.GetBus().GetRuntimeMgr().GetConfig().Utility
+	stats := MempoolStats{
+		TxCount:          int64(len(txs)),
+		MaxTxs:           int64(utilityCfg.MaxMempoolTransactions),
+		MaxTxsBytesTotal: int64(utilityCfg.MaxMempoolTransactionBytes),
+		MessageTypes:     make(map[string]int64),
+	}
+	signers := make(map[string]struct{})
+	for _, txBz := range txs {
+		stats.TxsBytesTotal += int64(len(txBz))
+		tx := newMempoolTransaction(txBz)
+		if tx.MessageType != nil {
+			stats.MessageTypes[*tx.MessageType]++
+		}
+		if tx.SignerAddr != nil {
+			signers[*tx.SignerAddr] = struct{}{}
+		}
+	}
+	stats.SignersCount = int64(len(signers))
+	if len(txs) > 0 {
+		oldestTxHash := coreTypes.TxHash(txs[0])
+		stats.OldestTxHash = &oldestTxHash
+	}
+
+	return ctx.JSON(http.StatusOK, stats)
+}
+
+// newMempoolTransaction decodes as much of the transaction as possible. The transactions in the mempool passed basic
+// validation, so failing to decode one is unexpected but should not prevent the others from being inspected.
+func newMempoolTransaction(txBz []byte) MempoolTransaction {
+	mempoolTx := MempoolTransaction{
+		Hash: coreTypes.TxHash(txBz),
+		Tx:   hex.EncodeToString(txBz),
+		Size: int64(len(txBz)),
+	}
+	if err := decodeMempoolTransaction(txBz, &mempoolTx); err != nil {
+		decodeErr := err.Error()
+		mempoolTx.DecodeError = &decodeErr
+	}
+	return mempoolTx
+}
+
+func decodeMempoolTransaction(txBz []byte, mempoolTx *MempoolTransaction) error {
+	tx, err := coreTypes.TxFromBytes(txBz)
+	if err != nil {
+		return err
+	}
+	nonce := tx.GetNonce()
+	mempoolTx.Nonce = &nonce
+
+	if tx.GetSignature() != nil {
+		pubKey, err := crypto.NewPublicKeyFromBytes(tx.GetSignature().GetPublicKey())
+		if err != nil {
+			return err
+		}
+		signerAddr := pubKey.Address().String()
+		mempoolTx.SignerAddr = &signerAddr
+	}
+
+	msg, err := tx.GetMessage()
+	if err != nil {
+		return err
+	}
+	messageType := string(msg.ProtoReflect().Descriptor().FullName())
+	if utilityMsg, ok := msg.(typesUtil.Message); ok {
+		messageType = utilityMsg.GetMessageName()
+	}
+	mempoolTx.MessageType = &messageType
+
+	msgJSON, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	message := make(map[string]interface{})
+	if err := json.Unmarshal(msgJSON, &message); err != nil {
+		return err
+	}
+	mempoolTx.Message = &message
+	return nil
+}
@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns an echo server with the handlers of an RPC server whose mempool contains the transactions
+func newTestMempoolServer(t *testing.T, txs ...[]byte) *echo.Echo {
+	ctrl := gomock.NewController(t)
+
+	mempool := typesUtil.NewTxFIFOMempool(1000000, 100)
+	for _, tx := range txs {
+		require.NoError(t, mempool.AddTx(tx))
+	}
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().GetMempool().Return(mempool).AnyTimes()
+
+	runtimeMgrMock := mockModules.NewMockRuntimeMgr(ctrl)
+	runtimeMgrMock.EXPECT().GetConfig().Return(&configs.Config{
+		Utility: &configs.UtilityConfig{MaxMempoolTransactionBytes: 1000000, MaxMempoolTransactions: 100},
+	}).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+	busMock.EXPECT().GetRuntimeMgr().Return(runtimeMgrMock).AnyTimes()
+
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+	return e
+}
+
+// Returns the bytes of a transaction sending `amount` signed by `signer`
+func newTestMempoolTx(t *testing.T, signer crypto.PrivateKey, amount string) []byte {
+	msg := &typesUtil.MessageSend{
+		FromAddress: signer.Address(),
+		ToAddress:   signer.Address(),
+		Amount:      amount,
+	}
+	anyMsg, err := codec.GetCodec().ToAny(msg)
+	require.NoError(t, err)
+	tx := &coreTypes.Transaction{Msg: anyMsg, Nonce: amount}
+	require.NoError(t, tx.Sign(signer))
+	txBz, err := tx.Bytes()
+	require.NoError(t, err)
+	return txBz
+}
+
+func TestMempool_Txs(t *testing.T) {
+	signer, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	txs := [][]byte{
+		newTestMempoolTx(t, signer, "1"),
+		newTestMempoolTx(t, signer, "2"),
+		newTestMempoolTx(t, signer, "3"),
+	}
+	e := newTestMempoolServer(t, txs...)
+
+	var page MempoolTxsResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/mempool/txs?offset=1&limit=1", &page))
+	require.Equal(t, int64(3), page.TotalCount)
+	require.Len(t, page.Txs, 1)
+
+	tx := page.Txs[0]
+	require.Equal(t, coreTypes.TxHash(txs[1]), tx.Hash)
+	require.Equal(t, int64(len(txs[1])), tx.Size)
+	require.Nil(t, tx.DecodeError)
+	require.Equal(t, "2", *tx.Nonce)
+	require.Equal(t, signer.Address().String(), *tx.SignerAddr)
+	require.Equal(t, "MessageSend", *tx.MessageType)
+	require.Equal(t, "2", (*tx.Message)["amount"])
+
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/mempool/txs?offset=5", &page))
+	require.Empty(t, page.Txs)
+
+	for _, query := range []string{"offset=-1", "limit=0", "limit=1001"} {
+		require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/mempool/txs?"+query, &page), query)
+	}
+}
+
+func TestMempool_Tx(t *testing.T) {
+	signer, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	txBz := newTestMempoolTx(t, signer, "1")
+	invalidTxBz := []byte("invalid")
+	e := newTestMempoolServer(t, txBz, invalidTxBz)
+
+	var tx MempoolTransaction
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/mempool/tx/"+coreTypes.TxHash(txBz), &tx))
+	require.Equal(t, coreTypes.TxHash(txBz), tx.Hash)
+	require.Nil(t, tx.DecodeError)
+
+	// A transaction that cannot be decoded can still be inspected
+	var invalidTx MempoolTransaction
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/mempool/tx/"+coreTypes.TxHash(invalidTxBz), &invalidTx))
+	require.NotNil(t, invalidTx.DecodeError)
+	require.Nil(t, invalidTx.MessageType)
+
+	require.Equal(t, http.StatusNotFound, doTestQuery(t, e, "/v1/mempool/tx/"+coreTypes.TxHash([]byte("missing")), &tx))
+}
+
+func TestMempool_Stats(t *testing.T) {
+	signer1, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	signer2, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	txs := [][]byte{
+		newTestMempoolTx(t, signer1, "1"),
+		newTestMempoolTx(t, signer1, "2"),
+		newTestMempoolTx(t, signer2, "3"),
+	}
+	e := newTestMempoolServer(t, txs...)
+
+	var stats MempoolStats
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/mempool/stats", &stats))
+	require.Equal(t, int64(3), stats.TxCount)
+	require.Equal(t, int64(len(txs[0])+len(txs[1])+len(txs[2])), stats.TxsBytesTotal)
+	require.Equal(t, int64(100), stats.MaxTxs)
+	require.Equal(t, int64(1000000), stats.MaxTxsBytesTotal)
+	require.Equal(t, map[string]int64{"MessageSend": 3}, stats.MessageTypes)
+	require.Equal(t, int64(2), stats.SignersCount)
+	require.Equal(t, coreTypes.TxHash(txs[0]), *stats.OldestTxHash)
+
+	var emptyStats MempoolStats
+	emptyServer := newTestMempoolServer(t)
+	require.Equal(t, http.StatusOK, doTestQuery(t, emptyServer, "/v1/mempool/stats", &emptyStats))
+	require.Zero(t, emptyStats.TxCount)
+	require.Nil(t, emptyStats.OldestTxHash)
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"fmt"
 
 	// importing because used by code-generated files that are git ignored and to allow go mod tidy and go mod vendor to function properly
 	_ "github.com/getkin/kin-openapi/openapi3"
@@ -8,8 +9,11 @@ import (
 
 	"github.com/pokt-network/pocket/logger"
 	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/messaging"
 	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/pokt-network/pocket/shared/modules/base_modules"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var _ modules.RPCModule = &rpcModule{}
@@ -20,6 +24,7 @@ type rpcModule struct {
 
 	logger *modules.Logger
 	config *configs.RPCConfig
+	server *rpcServer
 }
 
 func Create(bus modules.Bus, options ...modules.ModuleOption) (modules.Module, error) {
@@ -32,6 +37,7 @@ func (*rpcModule) Create(bus modules.Bus, options ...modules.ModuleOption) (modu
 	rpcCfg := cfg.RPC
 	m := modules.RPCModule(&rpcModule{
 		config: rpcCfg,
+		server: NewRPCServer(bus),
 	})
 	if !rpcCfg.Enabled {
 		m = &noopRpcModule{}
@@ -48,10 +54,62 @@ func (*rpcModule) Create(bus modules.Bus, options ...modules.ModuleOption) (modu
 
 func (u *rpcModule) Start() error {
 	u.logger = logger.Global.CreateLoggerForModule(u.GetModuleName())
-	go NewRPCServer(u.GetBus()).StartRPC(u.config.Port, u.config.Timeout, u.logger)
+	// The async tx queue is created before the server starts serving requests, so it can be stopped with the module
+	u.server.asyncTxs = newAsyncTxQueue(u.config.Limits.GetMaxPendingAsyncTxs(), u.server.handleAsyncTx)
+	go u.server.StartRPC(u.config.Port, u.config.Timeout, u.logger)
+	return nil
+}
+
+// Stop waits for the transactions broadcast asynchronously that were already accepted to be handled, and rejects
+// the ones broadcast afterwards
+func (u *rpcModule) Stop() error {
+	if u.server.asyncTxs != nil {
+		u.server.asyncTxs.stop()
+	}
 	return nil
 }
 
 func (u *rpcModule) GetModuleName() string {
 	return modules.RPCModuleName
 }
+
+func (u *rpcModule) HandleEvent(event *anypb.Any) error {
+	evt, err := codec.GetCodec().FromAny(event)
+	if err != nil {
+		return err
+	}
+
+	switch event.MessageName() {
+	case messaging.ConsensusNewHeightEventType:
+		consensusNewHeightEvent, ok := evt.(*messaging.ConsensusNewHeightEvent)
+		if !ok {
+			return fmt.Errorf("failed to cast event to ConsensusNewHeightEvent")
+		}
+
+		u.server.newHeights.notify()
+		// The new height follows the committed block, if any (i.e. the height was not reset to genesis)
+		if consensusNewHeightEvent.Height > 0 {
+			return u.server.publishCommittedBlock(consensusNewHeightEvent.Height - 1)
+		}
+
+	case messaging.StateMachineTransitionEventType:
+		stateMachineTransitionEvent, ok := evt.(*messaging.StateMachineTransitionEvent)
+		if !ok {
+			return fmt.Errorf("failed to cast event to StateMachineTransitionEvent")
+		}
+
+		u.server.publishStateMachineTransition(stateMachineTransitionEvent)
+
+	case messaging.MempoolTxAdmittedEventType:
+		mempoolTxAdmittedEvent, ok := evt.(*messaging.MempoolTxAdmittedEvent)
+		if !ok {
+			return fmt.Errorf("failed to cast event to MempoolTxAdmittedEvent")
+		}
+
+		u.server.publishMempoolTx(mempoolTxAdmittedEvent.Tx)
+
+	default:
+		return fmt.Errorf("unknown event type: %s", event.MessageName())
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/app"
+)
+
+func (s *rpcServer) GetV1NodeStatus(ctx echo.Context) error {
+	bus := s.GetBus()
+
+	address, err := bus.GetP2PModule().GetAddress()
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	consensus := bus.GetConsensusModule()
+	localHeight, networkHeight, err := consensus.GetSyncHeights()
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	networkInfo := bus.GetP2PModule().GetNetworkInfo()
+	mempool := bus.GetUtilityModule().GetMempool()
+
+	return ctx.JSON(http.StatusOK, NodeStatus{
+		Address:  address.String(),
+		Version:  app.AppVersion,
+		ChainId:  bus.GetRuntimeMgr().GetGenesis().GetChainId(),
+		FsmState: bus.GetStateMachineModule().Current(),
+		Consensus: ConsensusState{
+			Height: int64(consensus.CurrentHeight()),
+			Round:  int64(consensus.CurrentRound()),
+			Step:   int64(consensus.CurrentStep()),
+		},
+		Sync: SyncStatus{
+			LocalHeight:   int64(localHeight),
+			NetworkHeight: int64(networkHeight),
+			CatchingUp:    localHeight < networkHeight,
+		},
+		P2p: P2PStatus{
+			AddressBookSize: int64(len(networkInfo.GetPeers())),
+			RaintreeLevels:  int64(networkInfo.GetRaintreeLevels()),
+		},
+		Mempool: MempoolStatus{
+			TxCount:       int64(mempool.TxCount()),
+			TxsBytesTotal: int64(mempool.TxsBytesTotal()),
+		},
+	})
+}
+
+func (s *rpcServer) GetV1P2pPeers(ctx echo.Context) error {
+	address, err := s.GetBus().GetP2PModule().GetAddress()
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	// The address book of a node includes the node itself, which is not one of its peers
+	networkInfo := s.GetBus().GetP2PModule().GetNetworkInfo()
+	peers := make([]Peer, 0, len(networkInfo.GetPeers()))
+	for _, peer := range networkInfo.GetPeers() {
+		if peer.GetAddress() == address.String() {
+			continue
+		}
+		peers = append(peers, Peer{
+			Address:    peer.GetAddress(),
+			PublicKey:  peer.GetPublicKey(),
+			ServiceUrl: peer.GetServiceUrl(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, P2PPeersResponse{Peers: peers})
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/app"
+	"github.com/pokt-network/pocket/runtime/genesis"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns an echo server with the handlers of an RPC server whose node has the address of `self` and the peers in its
+// address book, along with its consensus module mock
+func newTestNodeStatusServer(t *testing.T, self *coreTypes.Peer, peers ...*coreTypes.Peer) (*echo.Echo, *mockModules.MockConsensusModule) {
+	ctrl := gomock.NewController(t)
+
+	selfAddr, err := crypto.NewAddress(self.Address)
+	require.NoError(t, err)
+	p2pMock := mockModules.NewMockP2PModule(ctrl)
+	p2pMock.EXPECT().GetAddress().Return(selfAddr, nil).AnyTimes()
+	p2pMock.EXPECT().GetNetworkInfo().Return(&coreTypes.P2PNetworkInfo{
+		Peers:          append([]*coreTypes.Peer{self}, peers...),
+		RaintreeLevels: 2,
+	}).AnyTimes()
+
+	consensusMock := mockModules.NewMockConsensusModule(ctrl)
+	consensusMock.EXPECT().CurrentHeight().Return(uint64(7)).AnyTimes()
+	consensusMock.EXPECT().CurrentRound().Return(uint64(1)).AnyTimes()
+	consensusMock.EXPECT().CurrentStep().Return(uint64(3)).AnyTimes()
+
+	stateMachineMock := mockModules.NewMockStateMachineModule(ctrl)
+	stateMachineMock.EXPECT().Current().Return(string(coreTypes.StateMachineState_Consensus_SyncMode)).AnyTimes()
+
+	runtimeMgrMock := mockModules.NewMockRuntimeMgr(ctrl)
+	runtimeMgrMock.EXPECT().GetGenesis().Return(&genesis.GenesisState{ChainId: "testnet"}).AnyTimes()
+
+	mempool := typesUtil.NewTxFIFOMempool(1000000, 100)
+	require.NoError(t, mempool.AddTx([]byte("tx")))
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().GetMempool().Return(mempool).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetP2PModule().Return(p2pMock).AnyTimes()
+	busMock.EXPECT().GetConsensusModule().Return(consensusMock).AnyTimes()
+	busMock.EXPECT().GetStateMachineModule().Return(stateMachineMock).AnyTimes()
+	busMock.EXPECT().GetRuntimeMgr().Return(runtimeMgrMock).AnyTimes()
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+	return e, consensusMock
+}
+
+func TestNodeStatus(t *testing.T) {
+	self := &coreTypes.Peer{Address: "00112233445566778899aabbccddeeff00112233", ServiceUrl: "node1:42069"}
+	peer := &coreTypes.Peer{Address: "a3d9ea9d9ad9c58bb96ec41340f83cb2cabb6496", ServiceUrl: "node2:42069"}
+	e, consensusMock := newTestNodeStatusServer(t, self, peer)
+
+	consensusMock.EXPECT().GetSyncHeights().Return(uint64(6), uint64(10), nil)
+	var status NodeStatus
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/node/status", &status))
+	require.Equal(t, NodeStatus{
+		Address:   self.Address,
+		Version:   app.AppVersion,
+		ChainId:   "testnet",
+		FsmState:  "Consensus_SyncMode",
+		Consensus: ConsensusState{Height: 7, Round: 1, Step: 3},
+		Sync:      SyncStatus{LocalHeight: 6, NetworkHeight: 10, CatchingUp: true},
+		P2p:       P2PStatus{AddressBookSize: 2, RaintreeLevels: 2},
+		Mempool:   MempoolStatus{TxCount: 1, TxsBytesTotal: 2},
+	}, status)
+
+	consensusMock.EXPECT().GetSyncHeights().Return(uint64(0), uint64(0), errors.New("persistence error"))
+	require.Equal(t, http.StatusInternalServerError, doTestQuery(t, e, "/v1/node/status", &status))
+}
+
+func TestP2PPeers(t *testing.T) {
+	self := &coreTypes.Peer{Address: "00112233445566778899aabbccddeeff00112233", ServiceUrl: "node1:42069"}
+	peer := &coreTypes.Peer{Address: "a3d9ea9d9ad9c58bb96ec41340f83cb2cabb6496", PublicKey: "pubkey", ServiceUrl: "node2:42069"}
+	e, _ := newTestNodeStatusServer(t, self, peer)
+
+	// The node itself is not listed among its peers
+	var response P2PPeersResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/p2p/peers", &response))
+	require.Equal(t, []Peer{{Address: peer.Address, PublicKey: "pubkey", ServiceUrl: "node2:42069"}}, response.Peers)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/pokt-network/pocket/shared/modules/base_modules"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var _ modules.RPCModule = &noopRpcModule{}
@@ -26,3 +27,7 @@ func (m *noopRpcModule) Start() error {
 	log.Println("[WARN] RPC server: OFFLINE")
 	return nil
 }
+
+func (m *noopRpcModule) HandleEvent(_ *anypb.Any) error {
+	return nil
+}
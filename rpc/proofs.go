@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+var stateProofTypeToStateTree = map[StateProofTypesEnum]coreTypes.StateTree{
+	StateProofTypesEnumAccount:     coreTypes.StateTreeAccount,
+	StateProofTypesEnumPool:        coreTypes.StateTreePool,
+	StateProofTypesEnumApplication: coreTypes.StateTreeApp,
+	StateProofTypesEnumValidator:   coreTypes.StateTreeValidator,
+	StateProofTypesEnumFisherman:   coreTypes.StateTreeFisherman,
+	StateProofTypesEnumServicer:    coreTypes.StateTreeServicer,
+	StateProofTypesEnumParam:       coreTypes.StateTreeParams,
+	StateProofTypesEnumFlag:        coreTypes.StateTreeFlags,
+	StateProofTypesEnumReportCard:  coreTypes.StateTreeReportCards,
+}
+
+var stateProofTypeToActorType = map[StateProofTypesEnum]coreTypes.ActorType{
+	StateProofTypesEnumApplication: coreTypes.ActorType_ACTOR_TYPE_APP,
+	StateProofTypesEnumValidator:   coreTypes.ActorType_ACTOR_TYPE_VAL,
+	StateProofTypesEnumFisherman:   coreTypes.ActorType_ACTOR_TYPE_FISH,
+	StateProofTypesEnumServicer:    coreTypes.ActorType_ACTOR_TYPE_SERVICER,
+}
+
+func newStateProof(proof *coreTypes.StateProof) StateProof {
+	sideNodes := make([]string, 0, len(proof.Proof.SideNodes))
+	for _, sideNode := range proof.Proof.SideNodes {
+		sideNodes = append(sideNodes, hex.EncodeToString(sideNode))
+	}
+	treeRoots := make([]string, 0, len(proof.TreeRoots))
+	for _, root := range proof.TreeRoots {
+		treeRoots = append(treeRoots, hex.EncodeToString(root))
+	}
+
+	smtProof := SparseMerkleProof{SideNodes: sideNodes}
+	if proof.Proof.NonMembershipLeafData != nil {
+		leafData := hex.EncodeToString(proof.Proof.NonMembershipLeafData)
+		smtProof.NonMembershipLeafData = &leafData
+	}
+	if proof.Proof.SiblingData != nil {
+		siblingData := hex.EncodeToString(proof.Proof.SiblingData)
+		smtProof.SiblingData = &siblingData
+	}
+
+	return StateProof{
+		Height:    int64(proof.Height),
+		Tree:      int(proof.Tree),
+		Key:       hex.EncodeToString(proof.Key),
+		Value:     hex.EncodeToString(proof.Value),
+		Proof:     smtProof,
+		TreeRoots: treeRoots,
+		StateHash: coreTypes.ComputeStateHash(proof.TreeRoots),
+	}
+}
+
+// ToCoreStateProof decodes a state proof returned by the RPC
+func (p *StateProof) ToCoreStateProof() (*coreTypes.StateProof, error) {
+	key, err := hex.DecodeString(p.Key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the key of the proof: %w", err)
+	}
+	value, err := hex.DecodeString(p.Value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the value of the proof: %w", err)
+	}
+	sideNodes, err := decodeHexStrings(p.Proof.SideNodes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the side nodes of the proof: %w", err)
+	}
+	treeRoots, err := decodeHexStrings(p.TreeRoots)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the tree roots of the proof: %w", err)
+	}
+
+	smtProof := smt.SparseMerkleProof{SideNodes: sideNodes}
+	if p.Proof.NonMembershipLeafData != nil {
+		if smtProof.NonMembershipLeafData, err = hex.DecodeString(*p.Proof.NonMembershipLeafData); err != nil {
+			return nil, fmt.Errorf("cannot decode the non-membership leaf data of the proof: %w", err)
+		}
+	}
+	if p.Proof.SiblingData != nil {
+		if smtProof.SiblingData, err = hex.DecodeString(*p.Proof.SiblingData); err != nil {
+			return nil, fmt.Errorf("cannot decode the sibling data of the proof: %w", err)
+		}
+	}
+
+	return &coreTypes.StateProof{
+		Height:    uint64(p.Height),
+		Tree:      coreTypes.StateTree(p.Tree),
+		Key:       key,
+		Value:     value,
+		Proof:     smtProof,
+		TreeRoots: treeRoots,
+	}, nil
+}
+
+// VerifyStateProof verifies that a proof returned by `/v1/query/proof` for the given type and key is valid for a
+// trusted state hash (e.g. the one of a block header signed by the validators), without trusting the node that
+// returned it. It returns the proven protobuf encoded value, which is empty if the key is not in the state.
+func VerifyStateProof(proof *StateProof, proofType StateProofTypesEnum, key, stateHash string) ([]byte, error) {
+	coreProof, err := proof.ToCoreStateProof()
+	if err != nil {
+		return nil, err
+	}
+
+	tree, ok := stateProofTypeToStateTree[proofType]
+	if !ok {
+		return nil, fmt.Errorf("unknown state proof type: %s", proofType)
+	}
+	if coreProof.Tree != tree {
+		return nil, fmt.Errorf("the proof is for state tree %d rather than state tree %d", coreProof.Tree, tree)
+	}
+
+	// Make sure the proof is for the key that was queried rather than any key of the tree
+	switch proofType {
+	case StateProofTypesEnumPool:
+		err = verifyStateProofKey(coreProof, []byte(key))
+	case StateProofTypesEnumParam:
+		err = verifyHashedStateProofKey(coreProof, new(coreTypes.Param), key)
+	case StateProofTypesEnumFlag:
+		err = verifyHashedStateProofKey(coreProof, new(coreTypes.Flag), key)
+	default:
+		address, decodeErr := hex.DecodeString(key)
+		if decodeErr != nil {
+			return nil, fmt.Errorf("cannot decode address: %w", decodeErr)
+		}
+		err = verifyStateProofKey(coreProof, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := coreProof.Verify(stateHash); err != nil {
+		return nil, err
+	}
+	return coreProof.Value, nil
+}
+
+func verifyStateProofKey(proof *coreTypes.StateProof, key []byte) error {
+	if !bytes.Equal(proof.Key, key) {
+		return fmt.Errorf("the proof is for key %s rather than key %s", hex.EncodeToString(proof.Key), hex.EncodeToString(key))
+	}
+	return nil
+}
+
+// namedMessage is implemented by params & flags
+type namedMessage interface {
+	proto.Message
+	GetName() string
+}
+
+// The keys of params & flags are the hashes of their values, so only a proof of membership can be verified by
+// checking the value is the one of the param or flag that was queried
+func verifyHashedStateProofKey(proof *coreTypes.StateProof, value namedMessage, name string) error {
+	if !proof.IsMembershipProof() {
+		return fmt.Errorf("cannot verify a proof of non-membership for %s", name)
+	}
+	if err := codec.GetCodec().Unmarshal(proof.Value, value); err != nil {
+		return fmt.Errorf("cannot decode the value of the proof: %w", err)
+	}
+	if value.GetName() != name {
+		return fmt.Errorf("the proof is for %s rather than %s", value.GetName(), name)
+	}
+	return verifyStateProofKey(proof, crypto.SHA3Hash(proof.Value))
+}
+
+func decodeHexStrings(strs []string) ([][]byte, error) {
+	bzs := make([][]byte, 0, len(strs))
+	for _, str := range strs {
+		bz, err := hex.DecodeString(str)
+		if err != nil {
+			return nil, err
+		}
+		bzs = append(bzs, bz)
+	}
+	return bzs, nil
+}
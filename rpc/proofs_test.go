@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns the RPC proof of the key in the given state tree, which holds the given key-value pairs, along with the
+// state hash of the state trees which are otherwise empty
+func newTestStateProof(t *testing.T, tree coreTypes.StateTree, kvs map[string][]byte, key []byte) (StateProof, string) {
+	smTree := smt.NewSparseMerkleTree(smt.NewSimpleMap(), smt.NewSimpleMap(), sha256.New())
+	for k, v := range kvs {
+		_, err := smTree.Update([]byte(k), v)
+		require.NoError(t, err)
+	}
+	treeRoots := make([][]byte, 0, int(coreTypes.NumStateTrees))
+	for stateTree := coreTypes.StateTree(0); stateTree < coreTypes.NumStateTrees; stateTree++ {
+		if stateTree == tree {
+			treeRoots = append(treeRoots, smTree.Root())
+		} else {
+			treeRoots = append(treeRoots, make([]byte, sha256.Size))
+		}
+	}
+
+	value, err := smTree.Get(key)
+	require.NoError(t, err)
+	proof, err := smTree.Prove(key)
+	require.NoError(t, err)
+	return newStateProof(&coreTypes.StateProof{
+		Height:    1,
+		Tree:      tree,
+		Key:       key,
+		Value:     value,
+		Proof:     proof,
+		TreeRoots: treeRoots,
+	}), coreTypes.ComputeStateHash(treeRoots)
+}
+
+func TestVerifyStateProof_Account(t *testing.T) {
+	address1, address2 := []byte{0x01}, []byte{0x02}
+	kvs := map[string][]byte{
+		string(address1): []byte("account1"),
+		string(address2): []byte("account2"),
+	}
+
+	proof, stateHash := newTestStateProof(t, coreTypes.StateTreeAccount, kvs, address1)
+	value, err := VerifyStateProof(&proof, StateProofTypesEnumAccount, hex.EncodeToString(address1), stateHash)
+	require.NoError(t, err)
+	require.Equal(t, []byte("account1"), value)
+
+	// The proof of another key, or from another tree, is rejected even though it is valid
+	_, err = VerifyStateProof(&proof, StateProofTypesEnumAccount, hex.EncodeToString(address2), stateHash)
+	require.Error(t, err)
+	_, err = VerifyStateProof(&proof, StateProofTypesEnumValidator, hex.EncodeToString(address1), stateHash)
+	require.Error(t, err)
+
+	// A proof that does not match the trusted state hash is rejected
+	_, err = VerifyStateProof(&proof, StateProofTypesEnumAccount, hex.EncodeToString(address1), hex.EncodeToString(make([]byte, sha256.Size)))
+	require.Error(t, err)
+
+	// Non-membership proof
+	address3 := []byte{0x03}
+	proof, stateHash = newTestStateProof(t, coreTypes.StateTreeAccount, kvs, address3)
+	value, err = VerifyStateProof(&proof, StateProofTypesEnumAccount, hex.EncodeToString(address3), stateHash)
+	require.NoError(t, err)
+	require.Empty(t, value)
+}
+
+func TestVerifyStateProof_Param(t *testing.T) {
+	paramBz, err := codec.GetCodec().Marshal(&coreTypes.Param{Name: "app_max_chains", Value: "42", Height: 1})
+	require.NoError(t, err)
+	paramKey := crypto.SHA3Hash(paramBz)
+
+	proof, stateHash := newTestStateProof(t, coreTypes.StateTreeParams, map[string][]byte{string(paramKey): paramBz}, paramKey)
+	value, err := VerifyStateProof(&proof, StateProofTypesEnumParam, "app_max_chains", stateHash)
+	require.NoError(t, err)
+	require.Equal(t, paramBz, value)
+
+	// The value must be the one of the param that was queried
+	_, err = VerifyStateProof(&proof, StateProofTypesEnumParam, "app_minimum_stake", stateHash)
+	require.Error(t, err)
+}
@@ -0,0 +1,494 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/shared/utils"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+const (
+	defaultTxsPageLimit = 100
+	maxTxsPageLimit     = 1000
+)
+
+func (s *rpcServer) GetV1QueryHeight(ctx echo.Context) error {
+	persistenceContext, err := s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	height, err := persistenceContext.GetMaximumBlockHeight()
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, QueryHeightResponse{Height: int64(height)})
+}
+
+func (s *rpcServer) GetV1QueryAccount(ctx echo.Context, params GetV1QueryAccountParams) error {
+	address, err := hex.DecodeString(params.Address)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode address")
+	}
+
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	amount, err := persistenceContext.GetAccountAmount(address, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, QueryAccountResponse{
+		Account: Account{
+			Address: params.Address,
+			Amount:  amount,
+		},
+		Height: height,
+	})
+}
+
+func (s *rpcServer) GetV1QueryPools(ctx echo.Context, params GetV1QueryPoolsParams) error {
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	pools, err := persistenceContext.GetAllPools(height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	response := QueryPoolsResponse{
+		Pools:  make([]Account, 0, len(pools)),
+		Height: height,
+	}
+	for _, pool := range pools {
+		response.Pools = append(response.Pools, Account{
+			Address: pool.GetAddress(),
+			Amount:  pool.GetAmount(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *rpcServer) GetV1QueryApp(ctx echo.Context, params GetV1QueryAppParams) error {
+	return s.queryActor(ctx, coreTypes.ActorType_ACTOR_TYPE_APP, params.Address, params.Height)
+}
+
+func (s *rpcServer) GetV1QueryServicer(ctx echo.Context, params GetV1QueryServicerParams) error {
+	return s.queryActor(ctx, coreTypes.ActorType_ACTOR_TYPE_SERVICER, params.Address, params.Height)
+}
+
+func (s *rpcServer) GetV1QueryFisherman(ctx echo.Context, params GetV1QueryFishermanParams) error {
+	return s.queryActor(ctx, coreTypes.ActorType_ACTOR_TYPE_FISH, params.Address, params.Height)
+}
+
+func (s *rpcServer) GetV1QueryValidator(ctx echo.Context, params GetV1QueryValidatorParams) error {
+	return s.queryActor(ctx, coreTypes.ActorType_ACTOR_TYPE_VAL, params.Address, params.Height)
+}
+
+func (s *rpcServer) queryActor(ctx echo.Context, actorType coreTypes.ActorType, hexAddress string, requestedHeight *int64) error {
+	address, err := hex.DecodeString(hexAddress)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode address")
+	}
+
+	persistenceContext, height, status, err := s.newQueryContext(requestedHeight)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	actor, err := persistenceContext.GetActor(actorType, address, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	if actor == nil {
+		return ctx.String(http.StatusNotFound, fmt.Sprintf("no %s found at address %s at height %d", protocolActorToRPCActorTypeEnum(actorType), hexAddress, height))
+	}
+
+	return ctx.JSON(http.StatusOK, QueryActorResponse{
+		Actor:  newProtocolActor(actor),
+		Height: height,
+	})
+}
+
+func (s *rpcServer) GetV1QuerySession(ctx echo.Context, params GetV1QuerySessionParams) error {
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	// The session is dispatched from the world state at its start, not at the requested height
+	persistenceContext.Close()
+
+	var geoZone string
+	if params.GeoZone != nil {
+		geoZone = *params.GeoZone
+	}
+	session, err := s.GetBus().GetUtilityModule().GetSession(params.AppAddress, height, params.RelayChain, geoZone)
+	if err != nil {
+		return ctx.String(sessionErrorStatusCode(err), err.Error())
+	}
+
+	servicers := make([]ProtocolActor, 0, len(session.GetServicers()))
+	for _, servicer := range session.GetServicers() {
+		servicers = append(servicers, newProtocolActor(servicer))
+	}
+	fishermen := make([]ProtocolActor, 0, len(session.GetFishermen()))
+	for _, fisherman := range session.GetFishermen() {
+		fishermen = append(fishermen, newProtocolActor(fisherman))
+	}
+
+	return ctx.JSON(http.StatusOK, Session{
+		SessionId:        session.GetId(),
+		SessionNumber:    session.GetSessionNumber(),
+		SessionHeight:    session.GetSessionHeight(),
+		NumSessionBlocks: session.GetNumSessionBlocks(),
+		RelayChain:       session.GetRelayChain(),
+		GeoZone:          session.GetGeoZone(),
+		Application:      newProtocolActor(session.GetApplication()),
+		Servicers:        servicers,
+		Fishermen:        fishermen,
+	})
+}
+
+// sessionErrorStatusCode distinguishes the sessions that cannot be dispatched from the failures of the node
+func sessionErrorStatusCode(err error) int {
+	utilityErr, ok := err.(typesUtil.Error)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	switch utilityErr.Code() {
+	case typesUtil.CodeInvalidSessionError, typesUtil.CodeHexDecodeFromStringError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *rpcServer) GetV1QueryParam(ctx echo.Context, params GetV1QueryParamParams) error {
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	// Params are stored as strings, so the string getter returns the value of a param of any type
+	value, err := persistenceContext.GetStringParam(params.Name, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, QueryParamResponse{
+		Name:   params.Name,
+		Value:  value,
+		Height: height,
+	})
+}
+
+func (s *rpcServer) GetV1QueryFlag(ctx echo.Context, params GetV1QueryFlagParams) error {
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	// Flags are stored as strings, so the string getter returns the value of a flag of any type
+	value, enabled, err := persistenceContext.GetStringFlag(params.Name, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, QueryFlagResponse{
+		Name:    params.Name,
+		Value:   value,
+		Enabled: enabled,
+		Height:  height,
+	})
+}
+
+func (s *rpcServer) GetV1QueryBlock(ctx echo.Context, params GetV1QueryBlockParams) error {
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	block, err := s.getBlock(uint64(height))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return ctx.String(http.StatusNotFound, fmt.Sprintf("no block found at height %d", height))
+	}
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, newBlock(block))
+}
+
+func (s *rpcServer) GetV1QueryTx(ctx echo.Context, params GetV1QueryTxParams) error {
+	if _, err := hex.DecodeString(params.Hash); err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode hash")
+	}
+
+	persistenceContext, height, status, err := s.newQueryContext(params.Height)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+	defer persistenceContext.Close()
+
+	txResult, err := s.GetBus().GetPersistenceModule().GetTransactionByHash(params.Hash)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	if txResult == nil || txResult.GetHeight() > height {
+		return ctx.String(http.StatusNotFound, fmt.Sprintf("no transaction found with hash %s at height %d", params.Hash, height))
+	}
+
+	tx, err := newTransaction(txResult)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	return ctx.JSON(http.StatusOK, tx)
+}
+
+func (s *rpcServer) GetV1QueryTxsByHeight(ctx echo.Context, params GetV1QueryTxsByHeightParams) error {
+	persistenceModule := s.GetBus().GetPersistenceModule()
+	return s.queryTxs(ctx, &params.Height, params.Cursor, params.Limit, params.Descending,
+		func(cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+			return persistenceModule.GetTransactionsByHeight(params.Height, cursor, limit, descending)
+		})
+}
+
+func (s *rpcServer) GetV1QueryAccountTxs(ctx echo.Context, params GetV1QueryAccountTxsParams) error {
+	if _, err := hex.DecodeString(params.Address); err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode address")
+	}
+
+	persistenceModule := s.GetBus().GetPersistenceModule()
+	getTransactions := persistenceModule.GetTransactionsByAddress
+	if params.Role != nil {
+		switch *params.Role {
+		case Sender:
+			getTransactions = persistenceModule.GetTransactionsBySender
+		case Recipient:
+			getTransactions = persistenceModule.GetTransactionsByRecipient
+		default:
+			return ctx.String(http.StatusBadRequest, fmt.Sprintf("invalid role %s", *params.Role))
+		}
+	}
+
+	return s.queryTxs(ctx, nil, params.Cursor, params.Limit, params.Descending,
+		func(cursor string, limit int, descending bool) ([]modules.TxResult, string, error) {
+			return getTransactions(params.Address, cursor, limit, descending)
+		})
+}
+
+// txsQuery returns a page of transactions starting at the cursor
+type txsQuery func(cursor string, limit int, descending bool) ([]modules.TxResult, string, error)
+
+func (s *rpcServer) queryTxs(
+	ctx echo.Context,
+	requestedHeight *int64,
+	cursorParam *string,
+	limitParam *int,
+	descendingParam *bool,
+	query txsQuery,
+) error {
+	var cursor string
+	if cursorParam != nil {
+		cursor = *cursorParam
+	}
+	limit := defaultTxsPageLimit
+	if limitParam != nil {
+		limit = *limitParam
+	}
+	descending := descendingParam != nil && *descendingParam
+
+	txResults, nextCursor, height, status, err := s.getTxsPage(requestedHeight, cursor, limit, descending, query)
+	if err != nil {
+		return ctx.String(status, err.Error())
+	}
+
+	response := QueryTxsResponse{
+		Transactions: make([]Transaction, 0, len(txResults)),
+		Height:       height,
+	}
+	if nextCursor != "" {
+		response.NextCursor = &nextCursor
+	}
+	for _, txResult := range txResults {
+		tx, err := newTransaction(txResult)
+		if err != nil {
+			return ctx.String(http.StatusInternalServerError, err.Error())
+		}
+		response.Transactions = append(response.Transactions, tx)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// getTxsPage returns a page of the transactions returned by the query, excluding the ones above the height the query is
+// executed at (e.g. transactions of a block that is being committed), along with that height. It returns the HTTP
+// status of the error if it fails.
+func (s *rpcServer) getTxsPage(
+	requestedHeight *int64,
+	cursor string,
+	limit int,
+	descending bool,
+	query txsQuery,
+) (txResults []modules.TxResult, nextCursor string, height int64, status int, err error) {
+	if limit < 1 || limit > maxTxsPageLimit {
+		return nil, "", 0, http.StatusBadRequest, fmt.Errorf("limit %d is not between 1 and %d", limit, maxTxsPageLimit)
+	}
+
+	persistenceContext, height, status, err := s.newQueryContext(requestedHeight)
+	if err != nil {
+		return nil, "", 0, status, err
+	}
+	defer persistenceContext.Close()
+
+	pageTxResults, nextCursor, err := query(cursor, limit, descending)
+	if errors.Is(err, indexer.ErrInvalidCursor) {
+		return nil, "", 0, http.StatusBadRequest, err
+	}
+	if err != nil {
+		return nil, "", 0, http.StatusInternalServerError, err
+	}
+
+	txResults = make([]modules.TxResult, 0, len(pageTxResults))
+	for _, txResult := range pageTxResults {
+		if txResult.GetHeight() <= height {
+			txResults = append(txResults, txResult)
+		}
+	}
+	return txResults, nextCursor, height, http.StatusOK, nil
+}
+
+// newQueryContext opens a read context for a query, along with the height the query is executed at: the requested
+// height if any, or the latest committed height otherwise. It returns the HTTP status of the error if it fails, which
+// is `http.StatusGone` if the state at the requested height was pruned.
+func (s *rpcServer) newQueryContext(requestedHeight *int64) (persistenceContext modules.PersistenceReadContext, height int64, status int, err error) {
+	persistenceContext, err = s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return nil, 0, http.StatusInternalServerError, err
+	}
+
+	latestHeight, err := persistenceContext.GetMaximumBlockHeight()
+	if err != nil {
+		persistenceContext.Close()
+		return nil, 0, http.StatusInternalServerError, err
+	}
+	if requestedHeight == nil {
+		return persistenceContext, int64(latestHeight), http.StatusOK, nil
+	}
+	if *requestedHeight < 0 || uint64(*requestedHeight) > latestHeight {
+		persistenceContext.Close()
+		return nil, 0, http.StatusBadRequest, fmt.Errorf("height %d is not between 0 and the latest committed height %d", *requestedHeight, latestHeight)
+	}
+	minHeight, keepEvery, err := persistenceContext.GetRetainedHeights()
+	if err != nil {
+		persistenceContext.Close()
+		return nil, 0, http.StatusInternalServerError, err
+	}
+	if *requestedHeight < minHeight && (keepEvery == 0 || uint64(*requestedHeight)%keepEvery != 0) {
+		persistenceContext.Close()
+		retainedHeights := fmt.Sprintf("between %d and %d", minHeight, latestHeight)
+		if keepEvery > 0 {
+			retainedHeights += fmt.Sprintf(", and at every multiple of %d below", keepEvery)
+		}
+		return nil, 0, http.StatusGone, fmt.Errorf("height %d was pruned, the state is retained %s", *requestedHeight, retainedHeights)
+	}
+	return persistenceContext, *requestedHeight, http.StatusOK, nil
+}
+
+func newProtocolActor(actor *coreTypes.Actor) ProtocolActor {
+	chains := actor.GetChains()
+	if chains == nil {
+		chains = []string{}
+	}
+	return ProtocolActor{
+		Type:            protocolActorToRPCActorTypeEnum(actor.GetActorType()),
+		Address:         actor.GetAddress(),
+		PublicKey:       actor.GetPublicKey(),
+		Chains:          chains,
+		ServiceUrl:      actor.GetServiceUrl(),
+		StakedAmount:    actor.GetStakedAmount(),
+		PausedHeight:    actor.GetPausedHeight(),
+		UnstakingHeight: actor.GetUnstakingHeight(),
+		Output:          actor.GetOutput(),
+	}
+}
+
+// getBlock returns the block committed at the height, or `kvstore.ErrKeyNotFound` if there is none
+func (s *rpcServer) getBlock(height uint64) (*coreTypes.Block, error) {
+	blockBz, err := s.GetBus().GetPersistenceModule().GetBlockStore().Get(utils.HeightToBytes(height))
+	if err != nil {
+		return nil, err
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func newBlock(block *coreTypes.Block) Block {
+	header := block.GetBlockHeader()
+	response := Block{
+		BlockHeader: BlockHeader{
+			Height:            int64(header.GetHeight()),
+			NetworkId:         header.GetNetworkId(),
+			StateHash:         header.GetStateHash(),
+			PrevStateHash:     header.GetPrevStateHash(),
+			ProposerAddress:   hex.EncodeToString(header.GetProposerAddress()),
+			QuorumCertificate: hex.EncodeToString(header.GetQuorumCertificate()),
+		},
+		Transactions: make([]string, 0, len(block.GetTransactions())),
+	}
+	if header.GetTimestampt() != nil {
+		timestamp := header.GetTimestampt().AsTime()
+		response.BlockHeader.Timestamp = &timestamp
+	}
+	for _, tx := range block.GetTransactions() {
+		response.Transactions = append(response.Transactions, hex.EncodeToString(tx))
+	}
+	return response
+}
+
+func newTransaction(txResult modules.TxResult) (Transaction, error) {
+	hash, err := txResult.Hash()
+	if err != nil {
+		return Transaction{}, err
+	}
+	tx := Transaction{
+		Hash:          hex.EncodeToString(hash),
+		Height:        txResult.GetHeight(),
+		Index:         txResult.GetIndex(),
+		Tx:            hex.EncodeToString(txResult.GetTx()),
+		ResultCode:    txResult.GetResultCode(),
+		SignerAddr:    txResult.GetSignerAddr(),
+		RecipientAddr: txResult.GetRecipientAddr(),
+		MessageType:   txResult.GetMessageType(),
+	}
+	if txErr := txResult.GetError(); txErr != "" {
+		tx.Error = &txErr
+	}
+	return tx, nil
+}
@@ -0,0 +1,306 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/shared/utils"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testLatestHeight = uint64(10)
+
+// Returns an echo server with the handlers of an RPC server whose persistence module is mocked
+func newTestQueryServer(t *testing.T) (*echo.Echo, *mockModules.MockPersistenceModule, *mockModules.MockPersistenceReadContext) {
+	ctrl := gomock.NewController(t)
+
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().Return(testLatestHeight, nil).AnyTimes()
+	readCtxMock.EXPECT().GetRetainedHeights().Return(int64(0), uint64(0), nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+	return e, persistenceMock, readCtxMock
+}
+
+func doTestQuery(t *testing.T, e *echo.Echo, target string, response any) int {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code == http.StatusOK && response != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+	}
+	return rec.Code
+}
+
+func TestQuery_Height(t *testing.T) {
+	e, _, _ := newTestQueryServer(t)
+
+	var response QueryHeightResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/height", &response))
+	require.Equal(t, int64(testLatestHeight), response.Height)
+}
+
+func TestQuery_Account(t *testing.T) {
+	e, _, readCtxMock := newTestQueryServer(t)
+	address := "00112233445566778899aabbccddeeff00112233"
+	addressBz, err := hex.DecodeString(address)
+	require.NoError(t, err)
+	readCtxMock.EXPECT().GetAccountAmount(addressBz, int64(testLatestHeight)).Return("100", nil)
+	readCtxMock.EXPECT().GetAccountAmount(addressBz, int64(5)).Return("50", nil)
+
+	// The latest committed height is used by default
+	var response QueryAccountResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/account?address="+address, &response))
+	require.Equal(t, QueryAccountResponse{Account: Account{Address: address, Amount: "100"}, Height: int64(testLatestHeight)}, response)
+
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/account?height=5&address="+address, &response))
+	require.Equal(t, QueryAccountResponse{Account: Account{Address: address, Amount: "50"}, Height: 5}, response)
+
+	// Invalid addresses and heights above the latest committed one are rejected
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/account?address=not_hex", nil))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/account?height=11&address="+address, nil))
+}
+
+func TestQuery_PrunedHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().Return(testLatestHeight, nil).AnyTimes()
+	// The state is retained from height 6, and at every multiple of 4 below
+	readCtxMock.EXPECT().GetRetainedHeights().Return(int64(6), uint64(4), nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+
+	address := "00112233445566778899aabbccddeeff00112233"
+	addressBz, err := hex.DecodeString(address)
+	require.NoError(t, err)
+	for _, height := range []int64{4, 6} {
+		readCtxMock.EXPECT().GetAccountAmount(addressBz, height).Return("100", nil)
+		require.Equal(t, http.StatusOK, doTestQuery(t, e, fmt.Sprintf("/v1/query/account?height=%d&address=%s", height, address), nil))
+	}
+
+	// The pruned heights are rejected along with the heights that are retained
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/query/account?height=5&address="+address, nil))
+	require.Equal(t, http.StatusGone, rec.Code)
+	require.Equal(t, "height 5 was pruned, the state is retained between 6 and 10, and at every multiple of 4 below", rec.Body.String())
+}
+
+func TestQuery_Actor(t *testing.T) {
+	e, _, readCtxMock := newTestQueryServer(t)
+	address := "00112233445566778899aabbccddeeff00112233"
+	addressBz, err := hex.DecodeString(address)
+	require.NoError(t, err)
+	servicer := &coreTypes.Actor{
+		ActorType:    coreTypes.ActorType_ACTOR_TYPE_SERVICER,
+		Address:      address,
+		PublicKey:    "publickey",
+		Chains:       []string{"0001"},
+		ServiceUrl:   "https://servicer.pokt.network:443",
+		StakedAmount: "1000",
+		Output:       address,
+	}
+	readCtxMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_SERVICER, addressBz, int64(testLatestHeight)).Return(servicer, nil)
+	readCtxMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_VAL, addressBz, int64(testLatestHeight)).Return(nil, nil)
+
+	var response QueryActorResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/servicer?address="+address, &response))
+	require.Equal(t, ActorTypesEnumServicer, response.Actor.Type)
+	require.Equal(t, servicer.Chains, response.Actor.Chains)
+	require.Equal(t, servicer.ServiceUrl, response.Actor.ServiceUrl)
+	require.Equal(t, servicer.StakedAmount, response.Actor.StakedAmount)
+
+	require.Equal(t, http.StatusNotFound, doTestQuery(t, e, "/v1/query/validator?address="+address, nil))
+}
+
+func TestQuery_Session(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().Return(testLatestHeight, nil).AnyTimes()
+	readCtxMock.EXPECT().GetRetainedHeights().Return(int64(0), uint64(0), nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+
+	appAddress := "00112233445566778899aabbccddeeff00112233"
+	session := &coreTypes.Session{
+		Id:               "abcdef",
+		SessionNumber:    2,
+		SessionHeight:    8,
+		NumSessionBlocks: 4,
+		RelayChain:       "0001",
+		GeoZone:          "zone",
+		Application:      &coreTypes.Actor{ActorType: coreTypes.ActorType_ACTOR_TYPE_APP, Address: appAddress, Chains: []string{"0001"}},
+		Servicers: []*coreTypes.Actor{
+			{ActorType: coreTypes.ActorType_ACTOR_TYPE_SERVICER, Address: "servicer1", Chains: []string{"0001"}},
+			{ActorType: coreTypes.ActorType_ACTOR_TYPE_SERVICER, Address: "servicer2", Chains: []string{"0001"}},
+		},
+	}
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().GetSession(appAddress, int64(testLatestHeight), "0001", "zone").Return(session, nil)
+	utilityMock.EXPECT().GetSession(appAddress, int64(9), "0001", "").Return(session, nil)
+	utilityMock.EXPECT().GetSession(appAddress, int64(testLatestHeight), "0002", "").Return(nil, typesUtil.ErrInvalidSession("the application is not staked"))
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+
+	// The latest committed height is used by default
+	var response Session
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/session?relay_chain=0001&geo_zone=zone&app_address="+appAddress, &response))
+	require.Equal(t, "abcdef", response.SessionId)
+	require.Equal(t, int64(2), response.SessionNumber)
+	require.Equal(t, int64(8), response.SessionHeight)
+	require.Equal(t, ActorTypesEnumApplication, response.Application.Type)
+	require.Len(t, response.Servicers, 2)
+	require.Equal(t, "servicer2", response.Servicers[1].Address)
+	require.Empty(t, response.Fishermen)
+
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/session?height=9&relay_chain=0001&app_address="+appAddress, &response))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/session?relay_chain=0002&app_address="+appAddress, nil))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/session?height=11&relay_chain=0001&app_address="+appAddress, nil))
+}
+
+func TestQuery_Block(t *testing.T) {
+	e, persistenceMock, _ := newTestQueryServer(t)
+	blockStore := kvstore.NewMemKVStore()
+	t.Cleanup(func() {
+		require.NoError(t, blockStore.Stop())
+	})
+	persistenceMock.EXPECT().GetBlockStore().Return(blockStore).AnyTimes()
+
+	block := &coreTypes.Block{
+		BlockHeader: &coreTypes.BlockHeader{
+			Height:          testLatestHeight,
+			StateHash:       "statehash",
+			PrevStateHash:   "prevstatehash",
+			ProposerAddress: []byte{0x01, 0x02},
+		},
+		Transactions: [][]byte{{0x03, 0x04}},
+	}
+	blockBz, err := codec.GetCodec().Marshal(block)
+	require.NoError(t, err)
+	require.NoError(t, blockStore.Set(utils.HeightToBytes(testLatestHeight), blockBz))
+
+	var response Block
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/block", &response))
+	require.Equal(t, int64(testLatestHeight), response.BlockHeader.Height)
+	require.Equal(t, "statehash", response.BlockHeader.StateHash)
+	require.Equal(t, "0102", response.BlockHeader.ProposerAddress)
+	require.Equal(t, []string{"0304"}, response.Transactions)
+
+	// e.g. a pruned block
+	require.Equal(t, http.StatusNotFound, doTestQuery(t, e, "/v1/query/block?height=1", nil))
+}
+
+func TestQuery_Tx(t *testing.T) {
+	e, persistenceMock, _ := newTestQueryServer(t)
+	txResult := &indexer.TxRes{
+		Tx:          []byte{0x01, 0x02},
+		Height:      7,
+		Index:       1,
+		SignerAddr:  "signer",
+		MessageType: "MessageSend",
+	}
+	txHashBz, err := txResult.Hash()
+	require.NoError(t, err)
+	txHash := hex.EncodeToString(txHashBz)
+	persistenceMock.EXPECT().GetTransactionByHash(txHash).Return(txResult, nil).AnyTimes()
+	persistenceMock.EXPECT().GetTransactionByHash(gomock.Not(txHash)).Return(nil, nil).AnyTimes()
+
+	var response Transaction
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/tx?hash="+txHash, &response))
+	require.Equal(t, txHash, response.Hash)
+	require.Equal(t, int64(7), response.Height)
+	require.Equal(t, "0102", response.Tx)
+	require.Nil(t, response.Error)
+
+	// The transaction is not included as of a height below the one of its block
+	require.Equal(t, http.StatusNotFound, doTestQuery(t, e, "/v1/query/tx?height=6&hash="+txHash, nil))
+	require.Equal(t, http.StatusNotFound, doTestQuery(t, e, "/v1/query/tx?hash=00", nil))
+}
+
+func TestQuery_TxsByHeight(t *testing.T) {
+	e, persistenceMock, _ := newTestQueryServer(t)
+	txResults := []modules.TxResult{
+		&indexer.TxRes{Tx: []byte{0x01}, Height: 5, Index: 0},
+		&indexer.TxRes{Tx: []byte{0x02}, Height: 5, Index: 1},
+	}
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "", defaultTxsPageLimit, false).Return(txResults, "next", nil)
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "next", 2, true).Return(txResults[:1], "", nil)
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(5), "bad", defaultTxsPageLimit, false).Return(nil, "", indexer.ErrInvalidCursor)
+
+	var response QueryTxsResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/txs_by_height?height=5", &response))
+	require.Len(t, response.Transactions, 2)
+	require.Equal(t, "02", response.Transactions[1].Tx)
+	require.Equal(t, "next", *response.NextCursor)
+	require.Equal(t, int64(5), response.Height)
+
+	response = QueryTxsResponse{}
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/txs_by_height?height=5&cursor=next&limit=2&descending=true", &response))
+	require.Len(t, response.Transactions, 1)
+	require.Nil(t, response.NextCursor)
+
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/txs_by_height?height=5&cursor=bad", nil))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/txs_by_height?height=5&limit=0", nil))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/txs_by_height?height=11", nil))
+}
+
+func TestQuery_AccountTxs(t *testing.T) {
+	e, persistenceMock, _ := newTestQueryServer(t)
+	address := "00112233445566778899aabbccddeeff00112233"
+	sent := &indexer.TxRes{Tx: []byte{0x01}, Height: 3, SignerAddr: address}
+	received := &indexer.TxRes{Tx: []byte{0x02}, Height: 4, RecipientAddr: address}
+	// e.g. a transaction of the block being committed
+	uncommitted := &indexer.TxRes{Tx: []byte{0x03}, Height: int64(testLatestHeight) + 1, SignerAddr: address}
+	persistenceMock.EXPECT().GetTransactionsByAddress(address, "", defaultTxsPageLimit, false).Return([]modules.TxResult{sent, received, uncommitted}, "", nil)
+	persistenceMock.EXPECT().GetTransactionsBySender(address, "", defaultTxsPageLimit, false).Return([]modules.TxResult{sent}, "", nil)
+	persistenceMock.EXPECT().GetTransactionsByRecipient(address, "", defaultTxsPageLimit, false).Return([]modules.TxResult{received}, "", nil)
+
+	var response QueryTxsResponse
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/account_txs?address="+address, &response))
+	require.Len(t, response.Transactions, 2)
+	require.Equal(t, "01", response.Transactions[0].Tx)
+	require.Equal(t, "02", response.Transactions[1].Tx)
+	require.Equal(t, int64(testLatestHeight), response.Height)
+
+	response = QueryTxsResponse{}
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/account_txs?role=sender&address="+address, &response))
+	require.Len(t, response.Transactions, 1)
+	require.Equal(t, "01", response.Transactions[0].Tx)
+
+	response = QueryTxsResponse{}
+	require.Equal(t, http.StatusOK, doTestQuery(t, e, "/v1/query/account_txs?role=recipient&address="+address, &response))
+	require.Len(t, response.Transactions, 1)
+	require.Equal(t, "02", response.Transactions[0].Tx)
+
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/account_txs?role=owner&address="+address, nil))
+	require.Equal(t, http.StatusBadRequest, doTestQuery(t, e, "/v1/query/account_txs?address=not_hex", nil))
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+func (s *rpcServer) PostV1ClientRelay(ctx echo.Context) error {
+	var relay Relay
+	if err := ctx.Bind(&relay); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+
+	response, err := s.GetBus().GetUtilityModule().HandleRelay(newCoreRelay(&relay))
+	if err != nil {
+		return ctx.String(relayErrorStatusCode(err), err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, RelayResponse{
+		Payload:           response.GetPayload(),
+		StatusCode:        response.GetStatusCode(),
+		ServicerSignature: response.GetServicerSignature(),
+		RequestHash:       response.GetRequestHash(),
+	})
+}
+
+func newCoreRelay(relay *Relay) *coreTypes.Relay {
+	payload := &coreTypes.RelayPayload{}
+	if relay.Payload.Data != nil {
+		payload.Data = *relay.Payload.Data
+	}
+	if relay.Payload.Method != nil {
+		payload.Method = *relay.Payload.Method
+	}
+	if relay.Payload.HttpPath != nil {
+		payload.HttpPath = *relay.Payload.HttpPath
+	}
+	if relay.Payload.Headers != nil {
+		payload.Headers = *relay.Payload.Headers
+	}
+
+	meta := &coreTypes.RelayMeta{
+		BlockHeight:       relay.Meta.BlockHeight,
+		ServicerPublicKey: relay.Meta.ServicerPublicKey,
+		RelayChain:        relay.Meta.RelayChain,
+		Token: &coreTypes.AAT{
+			Version:              relay.Meta.Token.Version,
+			ApplicationPublicKey: relay.Meta.Token.ApplicationPublicKey,
+			ClientPublicKey:      relay.Meta.Token.ClientPublicKey,
+			ApplicationSignature: relay.Meta.Token.ApplicationSignature,
+		},
+		Signature: relay.Meta.Signature,
+	}
+	if relay.Meta.GeoZone != nil {
+		meta.GeoZone = *relay.Meta.GeoZone
+	}
+
+	return &coreTypes.Relay{Payload: payload, Meta: meta}
+}
+
+// relayErrorStatusCode distinguishes the invalid relays from the failures of the servicer and of the relay chain
+func relayErrorStatusCode(err error) int {
+	utilityErr, ok := err.(typesUtil.Error)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	switch utilityErr.Code() {
+	case typesUtil.CodeRelayExecutionError:
+		return http.StatusBadGateway
+	case typesUtil.CodeServicerDisabledError,
+		typesUtil.CodeInvalidRelayError,
+		typesUtil.CodeUnsupportedRelayChainError,
+		typesUtil.CodeInvalidRelaySessionHeightError,
+		typesUtil.CodeInvalidAATError,
+		typesUtil.CodeServicerNotInSessionError,
+		typesUtil.CodeInvalidSessionError,
+		typesUtil.CodeAppOverServicedError,
+		typesUtil.CodeNewPublicKeyFromBytesError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay(t *testing.T) {
+	data, geoZone := `{"method":"eth_blockNumber"}`, "zone"
+	relay := Relay{
+		Payload: RelayPayload{Data: &data},
+		Meta: RelayMeta{
+			BlockHeight:       5,
+			ServicerPublicKey: "servicer",
+			RelayChain:        "0001",
+			GeoZone:           &geoZone,
+			Token:             AAT{Version: coreTypes.AATVersion, ApplicationPublicKey: "app", ClientPublicKey: "client", ApplicationSignature: "app_sig"},
+			Signature:         "client_sig",
+		},
+	}
+	relayResponse := &coreTypes.RelayResponse{Payload: "0x1", StatusCode: http.StatusOK, ServicerSignature: "servicer_sig", RequestHash: "relay_hash"}
+
+	testCases := []struct {
+		name               string
+		handleRelayErr     error
+		expectedStatusCode int
+	}{
+		{name: "relay executed", expectedStatusCode: http.StatusOK},
+		{name: "invalid relay", handleRelayErr: typesUtil.ErrAppOverServiced("2"), expectedStatusCode: http.StatusBadRequest},
+		{name: "servicer disabled", handleRelayErr: typesUtil.ErrServicerDisabled(), expectedStatusCode: http.StatusBadRequest},
+		{name: "relay chain failure", handleRelayErr: typesUtil.ErrRelayExecution(errors.New("connection refused")), expectedStatusCode: http.StatusBadGateway},
+		{name: "servicer failure", handleRelayErr: typesUtil.ErrStoreRelay(errors.New("disk full")), expectedStatusCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			utilityMock := mockModules.NewMockUtilityModule(ctrl)
+			utilityMock.EXPECT().HandleRelay(gomock.Any()).DoAndReturn(func(coreRelay *coreTypes.Relay) (*coreTypes.RelayResponse, error) {
+				require.Equal(t, data, coreRelay.GetPayload().GetData())
+				require.Equal(t, geoZone, coreRelay.GetMeta().GetGeoZone())
+				require.Equal(t, "app_sig", coreRelay.GetMeta().GetToken().GetApplicationSignature())
+				require.Equal(t, "client_sig", coreRelay.GetMeta().GetSignature())
+				if tc.handleRelayErr != nil {
+					return nil, tc.handleRelayErr
+				}
+				return relayResponse, nil
+			})
+			busMock := mockModules.NewMockBus(ctrl)
+			busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+
+			e := echo.New()
+			RegisterHandlers(e, NewRPCServer(busMock))
+
+			body, err := json.Marshal(relay)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/v1/client/relay", strings.NewReader(string(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code, rec.Body.String())
+			if tc.handleRelayErr != nil {
+				return
+			}
+			var response RelayResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Equal(t, RelayResponse{Payload: "0x1", StatusCode: http.StatusOK, ServicerSignature: "servicer_sig", RequestHash: "relay_hash"}, response)
+		})
+	}
+}
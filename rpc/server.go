@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/pokt-network/pocket/runtime/configs"
 	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/pokt-network/pocket/shared/modules/base_modules"
 )
@@ -13,16 +14,27 @@ import (
 type rpcServer struct {
 	base_modules.IntegratableModule
 
-	logger modules.Logger
+	logger  modules.Logger
+	timeout time.Duration
+
+	newHeights *newHeightNotifier
+	events     *eventBroadcaster
+	limits     *rpcLimits // Shared by the REST & gRPC APIs once started; nil (i.e. unlimited) until then
+	asyncTxs   *asyncTxQueue
 }
 
+const broadcastTxCommitPath = "/v1/client/broadcast_tx_commit"
+
 var (
 	_ ServerInterface            = &rpcServer{}
 	_ modules.IntegratableModule = &rpcServer{}
 )
 
 func NewRPCServer(bus modules.Bus) *rpcServer {
-	s := &rpcServer{}
+	s := &rpcServer{
+		newHeights: newNewHeightNotifier(),
+		events:     newEventBroadcaster(),
+	}
 	s.SetBus(bus)
 
 	return s
@@ -30,10 +42,16 @@ func NewRPCServer(bus modules.Bus) *rpcServer {
 
 func (s *rpcServer) StartRPC(port string, timeout uint64, logger *modules.Logger) {
 	s.logger = *logger
+	s.timeout = time.Duration(timeout) * time.Millisecond
 
 	s.logger.Info().Msgf("Starting RPC on port " + port)
 
+	rpcCfg := s.GetBus().GetRuntimeMgr().GetConfig().RPC
+
+	s.limits = newRPCLimits(rpcCfg.Limits, s.GetBus().GetTelemetryModule().GetTimeSeriesAgent())
+
 	e := echo.New()
+	e.IPExtractor = ipExtractor(rpcCfg.Limits)
 	middlewares := []echo.MiddlewareFunc{
 		middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 			LogURI:    true,
@@ -47,19 +65,32 @@ func (s *rpcServer) StartRPC(port string, timeout uint64, logger *modules.Logger
 				return nil
 			},
 		}),
+		s.limits.middleware(),
 		middleware.TimeoutWithConfig(middleware.TimeoutConfig{
-			Skipper:      middleware.DefaultSkipper,
+			// broadcast_tx_commit enforces the timeout itself so it can report the hash of the transaction it waited for,
+			// and events are streamed until the client disconnects
+			Skipper: func(c echo.Context) bool {
+				return c.Path() == broadcastTxCommitPath || c.Path() == eventsPath
+			},
 			ErrorMessage: "Request timed out",
-			Timeout:      time.Duration(timeout) * time.Millisecond,
+			Timeout:      s.timeout,
 		}),
 	}
-	if s.GetBus().GetRuntimeMgr().GetConfig().RPC.UseCors {
+	if rpcCfg.GrpcEnabled {
+		go s.StartGRPC(rpcCfg.GrpcPort)
+	}
+
+	if rpcCfg.UseCors {
 		s.logger.Info().Msg("Enabling CORS middleware")
 		middlewares = append(middlewares, middleware.CORS())
 	}
+	if isAdminMTLSEnabled(rpcCfg.Admin) {
+		go s.StartAdminRPC(rpcCfg.Admin, middlewares)
+	}
 	e.Use(
 		middlewares...,
 	)
+	e.Use(adminMiddleware(rpcCfg.Admin, false))
 
 	RegisterHandlers(e, s)
 
@@ -67,3 +98,31 @@ func (s *rpcServer) StartRPC(port string, timeout uint64, logger *modules.Logger
 		s.logger.Fatal().Err(err).Msg("RPC server failed to start")
 	}
 }
+
+// StartAdminRPC serves the admin endpoints over mTLS on the admin port, with the same middlewares as the main server
+func (s *rpcServer) StartAdminRPC(adminCfg *configs.RPCAdminConfig, middlewares []echo.MiddlewareFunc) {
+	tlsConfig, err := newAdminTLSConfig(adminCfg)
+	if err != nil {
+		s.logger.Fatal().Err(err).Msg("Admin RPC server failed to start")
+	}
+
+	s.logger.Info().Msgf("Starting admin RPC over mTLS on port " + adminCfg.Port)
+
+	e := echo.New()
+	e.HideBanner = true
+	e.IPExtractor = ipExtractor(s.GetBus().GetRuntimeMgr().GetConfig().RPC.Limits)
+	e.Use(
+		middlewares...,
+	)
+	e.Use(adminMiddleware(adminCfg, true))
+
+	RegisterHandlers(e, s)
+
+	server := &http.Server{
+		Addr:      ":" + adminCfg.Port,
+		TLSConfig: tlsConfig,
+	}
+	if err := e.StartServer(server); err != http.ErrServerClosed {
+		s.logger.Fatal().Err(err).Msg("Admin RPC server failed to start")
+	}
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
+func (s *rpcServer) PostV1ClientSimulateTx(ctx echo.Context) error {
+	txBz, err := bindRawTx(ctx)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, err.Error())
+	}
+
+	simulation, err := s.GetBus().GetUtilityModule().SimulateTransaction(txBz)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, newSimulateTxResponse(simulation))
+}
+
+func newSimulateTxResponse(simulation *coreTypes.TxSimulation) SimulateTxResponse {
+	response := SimulateTxResponse{
+		Height:       simulation.GetHeight(),
+		Fee:          simulation.GetFee(),
+		ResultCode:   simulation.GetResultCode(),
+		StateChanges: newStateChanges(simulation.GetStateChanges()),
+	}
+	if simulationErr := simulation.GetError(); simulationErr != "" {
+		response.Error = &simulationErr
+	}
+	return response
+}
+
+func newStateChanges(stateChanges *coreTypes.StateChanges) StateChanges {
+	changes := StateChanges{
+		Accounts: make([]Account, 0, len(stateChanges.GetAccounts())),
+		Pools:    make([]Account, 0, len(stateChanges.GetPools())),
+		Actors:   make([]ProtocolActor, 0, len(stateChanges.GetActors())),
+		Params:   make([]Param, 0, len(stateChanges.GetParams())),
+		Flags:    make([]Flag, 0, len(stateChanges.GetFlags())),
+	}
+	for _, account := range stateChanges.GetAccounts() {
+		changes.Accounts = append(changes.Accounts, Account{Address: account.GetAddress(), Amount: account.GetAmount()})
+	}
+	for _, pool := range stateChanges.GetPools() {
+		changes.Pools = append(changes.Pools, Account{Address: pool.GetAddress(), Amount: pool.GetAmount()})
+	}
+	for _, actor := range stateChanges.GetActors() {
+		changes.Actors = append(changes.Actors, newProtocolActor(actor))
+	}
+	for _, param := range stateChanges.GetParams() {
+		changes.Params = append(changes.Params, Param{Name: param.GetName(), Value: param.GetValue()})
+	}
+	for _, flag := range stateChanges.GetFlags() {
+		// The enabled column of a flag is scanned as a string (e.g. "true" or "t")
+		enabled, _ := strconv.ParseBool(flag.GetEnabled())
+		changes.Flags = append(changes.Flags, Flag{Name: flag.GetName(), Value: flag.GetValue(), Enabled: enabled})
+	}
+	return changes
+}
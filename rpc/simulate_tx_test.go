@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	txBz := []byte("tx")
+	invalidTxBz := []byte("invalid")
+
+	// The transaction is not broadcasted, so neither the mempool nor the P2P module are used
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().SimulateTransaction(txBz).Return(&coreTypes.TxSimulation{
+		Height:     11,
+		Fee:        "10000",
+		ResultCode: 41,
+		Error:      "insufficient amount",
+		StateChanges: &coreTypes.StateChanges{
+			Accounts: []*coreTypes.Account{{Address: "signer", Amount: "90000"}},
+			Pools:    []*coreTypes.Account{{Address: "FeeCollector", Amount: "10000"}},
+			Flags:    []*coreTypes.Flag{{Name: "flag", Value: "1", Enabled: "true"}},
+		},
+	}, nil)
+	utilityMock.EXPECT().SimulateTransaction(invalidTxBz).Return(nil, errors.New("invalid transaction"))
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+
+	e := echo.New()
+	RegisterHandlers(e, NewRPCServer(busMock))
+
+	rec := doTestBroadcast(t, e, "/v1/client/simulate_tx", txBz)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response SimulateTxResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, int64(11), response.Height)
+	require.Equal(t, "10000", response.Fee)
+	require.Equal(t, int32(41), response.ResultCode)
+	require.Equal(t, "insufficient amount", *response.Error)
+	require.Equal(t, []Account{{Address: "signer", Amount: "90000"}}, response.StateChanges.Accounts)
+	require.Equal(t, []Account{{Address: "FeeCollector", Amount: "10000"}}, response.StateChanges.Pools)
+	require.Equal(t, []Flag{{Name: "flag", Value: "1", Enabled: true}}, response.StateChanges.Flags)
+	require.Empty(t, response.StateChanges.Actors)
+	require.NotNil(t, response.StateChanges.Params)
+
+	rec = doTestBroadcast(t, e, "/v1/client/simulate_tx", invalidTxBz)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"errors"
+	"sync"
+)
+
+// The number of transactions broadcast asynchronously that are handled at the same time
+const asyncTxWorkers = 4
+
+var (
+	errAsyncTxQueueFull    = errors.New("too many transactions are waiting to be handled, try again later")
+	errAsyncTxQueueStopped = errors.New("the node is shutting down and no longer accepts transactions")
+)
+
+// asyncTxQueue handles the transactions broadcast asynchronously with a fixed number of workers, so a burst of requests
+// cannot spawn an unbounded number of goroutines. The transactions that do not fit in the queue are rejected.
+type asyncTxQueue struct {
+	m       sync.RWMutex
+	stopped bool
+	txs     chan []byte
+	workers sync.WaitGroup
+}
+
+// newAsyncTxQueue starts the workers, which call `handleTx` for every transaction enqueued until the queue is stopped.
+// Up to `maxPendingTxs` transactions can wait for a worker; with none, a transaction is only accepted if a worker is idle.
+func newAsyncTxQueue(maxPendingTxs uint32, handleTx func(txBz []byte)) *asyncTxQueue {
+	q := &asyncTxQueue{
+		txs: make(chan []byte, maxPendingTxs),
+	}
+	q.workers.Add(asyncTxWorkers)
+	for i := 0; i < asyncTxWorkers; i++ {
+		go func() {
+			defer q.workers.Done()
+			for txBz := range q.txs {
+				handleTx(txBz)
+			}
+		}()
+	}
+	return q
+}
+
+// enqueue returns `errAsyncTxQueueFull` without waiting if the transaction cannot be handled, or
+// `errAsyncTxQueueStopped` once the queue is stopped
+func (q *asyncTxQueue) enqueue(txBz []byte) error {
+	q.m.RLock()
+	defer q.m.RUnlock()
+
+	if q.stopped {
+		return errAsyncTxQueueStopped
+	}
+	select {
+	case q.txs <- txBz:
+		return nil
+	default:
+		return errAsyncTxQueueFull
+	}
+}
+
+// stop rejects the transactions enqueued from now on and returns once the workers handled the ones already accepted
+func (q *asyncTxQueue) stop() {
+	q.m.Lock()
+	if !q.stopped {
+		q.stopped = true
+		close(q.txs)
+	}
+	q.m.Unlock()
+
+	q.workers.Wait()
+}
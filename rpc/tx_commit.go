@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+)
+
+// newHeightNotifier notifies the requests waiting for a transaction to be committed whenever consensus reaches a new
+// height (i.e. a block is committed)
+type newHeightNotifier struct {
+	m           sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newNewHeightNotifier() *newHeightNotifier {
+	return &newHeightNotifier{
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// subscribe returns a channel that receives a notification whenever a new height is reached, and a function to
+// unsubscribe once no more notifications are needed. Notifications are dropped while one is pending, so the
+// subscriber must catch up with all the heights committed since the previous one it was notified of.
+func (n *newHeightNotifier) subscribe() (notifications <-chan struct{}, unsubscribe func()) {
+	ch := make(chan struct{}, 1)
+
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		n.m.Lock()
+		defer n.m.Unlock()
+		delete(n.subscribers, ch)
+	}
+}
+
+func (n *newHeightNotifier) notify() {
+	n.m.Lock()
+	defer n.m.Unlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // A notification is already pending
+		}
+	}
+}
+
+// broadcastTxCommit broadcasts the transaction and waits for it to be committed, for up to the timeout of the server.
+// It returns the HTTP status of the error if it fails.
+func (s *rpcServer) broadcastTxCommit(ctx context.Context, txBz []byte) (modules.TxResult, int, error) {
+	txHash := coreTypes.TxHash(txBz)
+
+	// Subscribe before the transaction is broadcast so the block it is committed in cannot be missed
+	newHeights, unsubscribe := s.newHeights.subscribe()
+	defer unsubscribe()
+	fromHeight, err := s.getLatestCommittedHeight()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := s.handleAndBroadcastTx(txBz); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	txResult, err := s.waitForTxResult(waitCtx, txHash, fromHeight, newHeights)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, http.StatusGatewayTimeout, fmt.Errorf("timed out waiting for transaction %s to be committed", txHash)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return txResult, http.StatusOK, nil
+}
+
+// waitForTxResult waits for the transaction with the given hash to be committed above `fromHeight` and returns its
+// result, or returns the error of the context if it is done first.
+func (s *rpcServer) waitForTxResult(ctx context.Context, txHash string, fromHeight uint64, newHeights <-chan struct{}) (modules.TxResult, error) {
+	checkedHeight := fromHeight
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-newHeights:
+		}
+
+		latestHeight, err := s.getLatestCommittedHeight()
+		if err != nil {
+			return nil, err
+		}
+		for ; checkedHeight < latestHeight; checkedHeight++ {
+			txResult, err := s.findTxResultAtHeight(txHash, int64(checkedHeight+1))
+			if err != nil {
+				return nil, err
+			}
+			if txResult != nil {
+				return txResult, nil
+			}
+		}
+	}
+}
+
+// findTxResultAtHeight returns the result of the transaction with the given hash if it was committed at the height, or
+// nil otherwise
+func (s *rpcServer) findTxResultAtHeight(txHash string, height int64) (modules.TxResult, error) {
+	persistenceModule := s.GetBus().GetPersistenceModule()
+	cursor := ""
+	for {
+		txResults, nextCursor, err := persistenceModule.GetTransactionsByHeight(height, cursor, maxTxsPageLimit, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, txResult := range txResults {
+			if coreTypes.TxHash(txResult.GetTx()) == txHash {
+				return txResult, nil
+			}
+		}
+		if nextCursor == "" {
+			return nil, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (s *rpcServer) getLatestCommittedHeight() (uint64, error) {
+	persistenceContext, err := s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return 0, err
+	}
+	defer persistenceContext.Close()
+
+	return persistenceContext.GetMaximumBlockHeight()
+}
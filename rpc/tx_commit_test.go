@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns an echo server with the handlers of an RPC server whose utility & P2P modules accept any transaction, and
+// whose latest committed height is returned by `latestHeight`, along with a channel notified of every broadcast
+func newTestBroadcastServer(t *testing.T, latestHeight *uint64) (*echo.Echo, *rpcServer, *mockModules.MockPersistenceModule, <-chan struct{}) {
+	ctrl := gomock.NewController(t)
+
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().DoAndReturn(func() (uint64, error) {
+		return atomic.LoadUint64(latestHeight), nil
+	}).AnyTimes()
+	readCtxMock.EXPECT().GetRetainedHeights().Return(int64(0), uint64(0), nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().HandleTransaction(gomock.Any()).Return(nil).AnyTimes()
+	p2pMock := mockModules.NewMockP2PModule(ctrl)
+	broadcasts := make(chan struct{}, 1)
+	p2pMock.EXPECT().Broadcast(gomock.Any()).DoAndReturn(func(_ any) error {
+		select {
+		case broadcasts <- struct{}{}:
+		default:
+		}
+		return nil
+	}).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+	busMock.EXPECT().GetP2PModule().Return(p2pMock).AnyTimes()
+
+	s := NewRPCServer(busMock)
+	s.timeout = time.Second
+	s.asyncTxs = newAsyncTxQueue(1, s.handleAsyncTx)
+	e := echo.New()
+	RegisterHandlers(e, s)
+	return e, s, persistenceMock, broadcasts
+}
+
+func doTestBroadcast(t *testing.T, e *echo.Echo, path string, txBz []byte) *httptest.ResponseRecorder {
+	body, err := json.Marshal(RawTXRequest{Address: "address", RawHexBytes: hex.EncodeToString(txBz)})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBroadcastTx_SyncAndAsyncReturnHash(t *testing.T) {
+	latestHeight := new(uint64)
+	e, _, _, _ := newTestBroadcastServer(t, latestHeight)
+	txBz := []byte("tx")
+
+	for _, path := range []string{"/v1/client/broadcast_tx_sync", "/v1/client/broadcast_tx_async"} {
+		rec := doTestBroadcast(t, e, path, txBz)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response BroadcastTxResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Equal(t, coreTypes.TxHash(txBz), response.Hash)
+	}
+}
+
+func TestBroadcastTx_AsyncQueueFull(t *testing.T) {
+	e, s, _, _ := newTestBroadcastServer(t, new(uint64))
+	// A queue without workers is full once a transaction is waiting
+	s.asyncTxs = &asyncTxQueue{txs: make(chan []byte, 1)}
+
+	rec := doTestBroadcast(t, e, "/v1/client/broadcast_tx_async", []byte("tx"))
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = doTestBroadcast(t, e, "/v1/client/broadcast_tx_async", []byte("tx2"))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestBroadcastTx_AsyncQueueStopped(t *testing.T) {
+	e, s, _, _ := newTestBroadcastServer(t, new(uint64))
+	handledTxs := make(chan []byte, 2)
+	s.asyncTxs = newAsyncTxQueue(2, func(txBz []byte) {
+		handledTxs <- txBz
+	})
+
+	rec := doTestBroadcast(t, e, "/v1/client/broadcast_tx_async", []byte("tx"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// The transactions accepted before the queue is stopped are handled before `stop` returns
+	s.asyncTxs.stop()
+	require.Len(t, handledTxs, 1)
+	require.Equal(t, []byte("tx"), <-handledTxs)
+
+	rec = doTestBroadcast(t, e, "/v1/client/broadcast_tx_async", []byte("tx2"))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Empty(t, handledTxs)
+}
+
+func TestBroadcastTx_Commit(t *testing.T) {
+	latestHeight := new(uint64)
+	atomic.StoreUint64(latestHeight, 5)
+	e, s, persistenceMock, broadcasts := newTestBroadcastServer(t, latestHeight)
+	txBz := []byte("tx")
+	txResult := &indexer.TxRes{Tx: txBz, Height: 7, Index: 1, ResultCode: 0}
+	otherTxResult := &indexer.TxRes{Tx: []byte("other tx"), Height: 7, Index: 0}
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(6), "", maxTxsPageLimit, false).Return(nil, "", nil)
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(7), "", maxTxsPageLimit, false).Return([]modules.TxResult{otherTxResult}, "next", nil)
+	persistenceMock.EXPECT().GetTransactionsByHeight(int64(7), "next", maxTxsPageLimit, false).Return([]modules.TxResult{txResult}, "", nil)
+
+	responses := make(chan *httptest.ResponseRecorder)
+	go func() {
+		responses <- doTestBroadcast(t, e, broadcastTxCommitPath, txBz)
+	}()
+
+	// Heights 6 and 7 are committed once the transaction is broadcast, before the new height event is handled
+	<-broadcasts
+	atomic.StoreUint64(latestHeight, 7)
+	s.newHeights.notify()
+
+	rec := <-responses
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response BroadcastTxCommitResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, coreTypes.TxHash(txBz), response.Hash)
+	require.Equal(t, int64(7), response.Result.Height)
+	require.Equal(t, int32(1), response.Result.Index)
+	require.Equal(t, hex.EncodeToString(txBz), response.Result.Tx)
+
+	// the waiting request unsubscribed
+	require.Empty(t, s.newHeights.subscribers)
+}
+
+func TestBroadcastTx_CommitTimeout(t *testing.T) {
+	latestHeight := new(uint64)
+	e, s, _, _ := newTestBroadcastServer(t, latestHeight)
+	s.timeout = 50 * time.Millisecond
+
+	rec := doTestBroadcast(t, e, broadcastTxCommitPath, []byte("tx"))
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	require.Contains(t, rec.Body.String(), coreTypes.TxHash([]byte("tx")))
+}
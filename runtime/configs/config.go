@@ -32,6 +32,10 @@ func NewDefaultConfig(options ...func(*Config)) *Config {
 		Utility: &UtilityConfig{
 			MaxMempoolTransactionBytes: defaults.DefaultUtilityMaxMempoolTransactionBytes,
 			MaxMempoolTransactions:     defaults.DefaultUtilityMaxMempoolTransactions,
+			Servicer: &ServicerConfig{
+				RelayTimeoutMsec:      defaults.DefaultServicerRelayTimeoutMsec,
+				MaxRelayResponseBytes: defaults.DefaultServicerMaxRelayResponseBytes,
+			},
 		},
 		Persistence: &PersistenceConfig{
 			PostgresUrl:    defaults.DefaultPersistencePostgresURL,
@@ -53,8 +57,18 @@ func NewDefaultConfig(options ...func(*Config)) *Config {
 			Format: defaults.DefaultLoggerFormat,
 		},
 		RPC: &RPCConfig{
-			Timeout: defaults.DefaultRPCTimeout,
-			Port:    defaults.DefaultRPCPort,
+			Timeout:  defaults.DefaultRPCTimeout,
+			Port:     defaults.DefaultRPCPort,
+			GrpcPort: defaults.DefaultRPCGRPCPort,
+			Admin: &RPCAdminConfig{
+				Port: defaults.DefaultRPCAdminPort,
+			},
+			Limits: &RPCLimitsConfig{
+				RateLimit:          defaults.DefaultRPCRateLimit,
+				Burst:              defaults.DefaultRPCBurst,
+				MaxBodyBytes:       defaults.DefaultRPCMaxBodyBytes,
+				MaxPendingAsyncTxs: defaults.DefaultRPCMaxPendingAsyncTxs,
+			},
 		},
 	}
 
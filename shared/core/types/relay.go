@@ -0,0 +1,134 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/crypto"
+)
+
+// AATVersion is the only version of the application authentication tokens supported at the moment
+const AATVersion = "0.0.1"
+
+// Sign signs the relay on behalf of the client of its token
+func (r *Relay) Sign(clientPrivateKey crypto.PrivateKey) error {
+	if r.Meta == nil {
+		return fmt.Errorf("the relay has no metadata to sign")
+	}
+	signableBz, err := r.SignableBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := clientPrivateKey.Sign(signableBz)
+	if err != nil {
+		return err
+	}
+	r.Meta.Signature = hex.EncodeToString(signature)
+	return nil
+}
+
+// ValidateSignature verifies the signature of the relay by the client of its token
+func (r *Relay) ValidateSignature() error {
+	signableBz, err := r.SignableBytes()
+	if err != nil {
+		return err
+	}
+	return verifyHexSignature(r.GetMeta().GetToken().GetClientPublicKey(), signableBz, r.GetMeta().GetSignature())
+}
+
+// The bytes of the relay that should have been signed by the client
+func (r *Relay) SignableBytes() ([]byte, error) {
+	relayCopy := codec.GetCodec().Clone(r).(*Relay)
+	if relayCopy.Meta != nil {
+		relayCopy.Meta.Signature = ""
+	}
+	return codec.GetCodec().Marshal(relayCopy)
+}
+
+// Hash returns the hash of the proto marshaled relay, which identifies it among the relays of its session
+func (r *Relay) Hash() (string, error) {
+	relayBz, err := codec.GetCodec().Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return crypto.GetHashStringFromBytes(relayBz), nil
+}
+
+// Sign signs the token on behalf of its application
+func (aat *AAT) Sign(appPrivateKey crypto.PrivateKey) error {
+	signableBz, err := aat.SignableBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := appPrivateKey.Sign(signableBz)
+	if err != nil {
+		return err
+	}
+	aat.ApplicationSignature = hex.EncodeToString(signature)
+	return nil
+}
+
+// ValidateSignature verifies the signature of the token by its application
+func (aat *AAT) ValidateSignature() error {
+	if aat.GetVersion() != AATVersion {
+		return fmt.Errorf("unsupported AAT version %q, expected %q", aat.GetVersion(), AATVersion)
+	}
+	signableBz, err := aat.SignableBytes()
+	if err != nil {
+		return err
+	}
+	return verifyHexSignature(aat.GetApplicationPublicKey(), signableBz, aat.GetApplicationSignature())
+}
+
+// The bytes of the token that should have been signed by the application
+func (aat *AAT) SignableBytes() ([]byte, error) {
+	aatCopy := codec.GetCodec().Clone(aat).(*AAT)
+	aatCopy.ApplicationSignature = ""
+	return codec.GetCodec().Marshal(aatCopy)
+}
+
+// Sign signs the response on behalf of the servicer
+func (r *RelayResponse) Sign(servicerPrivateKey crypto.PrivateKey) error {
+	signableBz, err := r.SignableBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := servicerPrivateKey.Sign(signableBz)
+	if err != nil {
+		return err
+	}
+	r.ServicerSignature = hex.EncodeToString(signature)
+	return nil
+}
+
+// ValidateSignature verifies the signature of the response by the servicer the relay was sent to
+func (r *RelayResponse) ValidateSignature(servicerPublicKey string) error {
+	signableBz, err := r.SignableBytes()
+	if err != nil {
+		return err
+	}
+	return verifyHexSignature(servicerPublicKey, signableBz, r.GetServicerSignature())
+}
+
+// The bytes of the response that should have been signed by the servicer
+func (r *RelayResponse) SignableBytes() ([]byte, error) {
+	responseCopy := codec.GetCodec().Clone(r).(*RelayResponse)
+	responseCopy.ServicerSignature = ""
+	return codec.GetCodec().Marshal(responseCopy)
+}
+
+func verifyHexSignature(publicKeyHex string, msg []byte, signatureHex string) error {
+	publicKey, err := crypto.NewPublicKey(publicKeyHex)
+	if err != nil {
+		return err
+	}
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return err
+	}
+	if !publicKey.Verify(msg, signature) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package types
+
+// GetHeader returns the header identifying the session
+func (s *Session) GetHeader() *SessionHeader {
+	return &SessionHeader{
+		ApplicationPublicKey: s.GetApplication().GetPublicKey(),
+		RelayChain:           s.GetRelayChain(),
+		GeoZone:              s.GetGeoZone(),
+		SessionHeight:        s.GetSessionHeight(),
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+)
+
+// StateTree identifies one of the Sparse Merkle Trees whose roots make up the state hash.
+//
+// IMPORTANT: The order in which the trees are defined is important and strict. It is the order in which their
+// roots are concatenated together to generate the state hash, which light clients rely on to verify proofs.
+type StateTree int
+
+const (
+	// Actor Merkle Trees
+	StateTreeApp StateTree = iota
+	StateTreeValidator
+	StateTreeFisherman
+	StateTreeServicer
+
+	// Account Merkle Trees
+	StateTreeAccount
+	StateTreePool
+
+	// Data Merkle Trees
+	StateTreeTransactions
+	StateTreeParams
+	StateTreeFlags
+	StateTreeReportCards
+	StateTreeDoubleSignEvidence
+
+	// Used for iteration purposes only
+	NumStateTrees
+)
+
+// StateProof proves that a key of a state tree holds a value at a given height, or that it is absent if the value
+// is empty. It contains the roots of all the state trees so the state hash of that height can be recomputed.
+type StateProof struct {
+	Height    uint64
+	Tree      StateTree
+	Key       []byte
+	Value     []byte
+	Proof     smt.SparseMerkleProof
+	TreeRoots [][]byte // Ordered by `StateTree`
+}
+
+// ComputeStateHash hashes the roots of the state trees, ordered by `StateTree`, into the state hash
+func ComputeStateHash(treeRoots [][]byte) string {
+	stateHash := sha256.Sum256(bytes.Join(treeRoots, []byte{}))
+	return hex.EncodeToString(stateHash[:])
+}
+
+// Verify checks that the proof is valid for the given state hash, which must come from a trusted source (e.g. the
+// header of a block signed by the validators) since the proof only shows it is consistent with the tree roots.
+func (p *StateProof) Verify(stateHash string) error {
+	if p.Tree < 0 || p.Tree >= NumStateTrees {
+		return fmt.Errorf("invalid state tree: %d", p.Tree)
+	}
+	if len(p.TreeRoots) != int(NumStateTrees) {
+		return fmt.Errorf("expected %d state tree roots but got %d", int(NumStateTrees), len(p.TreeRoots))
+	}
+	if computedStateHash := ComputeStateHash(p.TreeRoots); computedStateHash != stateHash {
+		return fmt.Errorf("the tree roots hash to %s rather than the state hash %s", computedStateHash, stateHash)
+	}
+	if !smt.VerifyProof(p.Proof, p.TreeRoots[p.Tree], p.Key, p.Value, sha256.New()) {
+		return fmt.Errorf("invalid proof for key %s in state tree %d", hex.EncodeToString(p.Key), p.Tree)
+	}
+	return nil
+}
+
+// IsMembershipProof returns whether the proof shows the key holds a value rather than being absent
+func (p *StateProof) IsMembershipProof() bool {
+	return len(p.Value) > 0
+}
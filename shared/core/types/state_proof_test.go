@@ -0,0 +1,71 @@
+package types
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/celestiaorg/smt"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns a proof for the key of the account tree, out of state trees that are otherwise empty
+func newTestStateProof(t *testing.T, key []byte) *StateProof {
+	accountTree := smt.NewSparseMerkleTree(smt.NewSimpleMap(), smt.NewSimpleMap(), sha256.New())
+	_, err := accountTree.Update([]byte("address1"), []byte("account1"))
+	require.NoError(t, err)
+	_, err = accountTree.Update([]byte("address2"), []byte("account2"))
+	require.NoError(t, err)
+
+	treeRoots := make([][]byte, 0, int(NumStateTrees))
+	for tree := StateTree(0); tree < NumStateTrees; tree++ {
+		if tree == StateTreeAccount {
+			treeRoots = append(treeRoots, accountTree.Root())
+		} else {
+			treeRoots = append(treeRoots, make([]byte, sha256.Size))
+		}
+	}
+
+	value, err := accountTree.Get(key)
+	require.NoError(t, err)
+	proof, err := accountTree.Prove(key)
+	require.NoError(t, err)
+	return &StateProof{
+		Height:    1,
+		Tree:      StateTreeAccount,
+		Key:       key,
+		Value:     value,
+		Proof:     proof,
+		TreeRoots: treeRoots,
+	}
+}
+
+func TestStateProof_Verify(t *testing.T) {
+	proof := newTestStateProof(t, []byte("address1"))
+	stateHash := ComputeStateHash(proof.TreeRoots)
+	require.True(t, proof.IsMembershipProof())
+	require.NoError(t, proof.Verify(stateHash))
+
+	nonMembershipProof := newTestStateProof(t, []byte("address3"))
+	require.False(t, nonMembershipProof.IsMembershipProof())
+	require.NoError(t, nonMembershipProof.Verify(stateHash))
+
+	tests := []struct {
+		name   string
+		tamper func(proof *StateProof)
+	}{
+		{name: "different value", tamper: func(proof *StateProof) { proof.Value = []byte("account2") }},
+		{name: "different key", tamper: func(proof *StateProof) { proof.Key = []byte("address2") }},
+		{name: "different tree", tamper: func(proof *StateProof) { proof.Tree = StateTreePool }},
+		{name: "invalid tree", tamper: func(proof *StateProof) { proof.Tree = NumStateTrees }},
+		{name: "missing tree root", tamper: func(proof *StateProof) { proof.TreeRoots = proof.TreeRoots[1:] }},
+		{name: "different tree root", tamper: func(proof *StateProof) { proof.TreeRoots[StateTreeApp] = make([]byte, 1) }},
+		{name: "value omitted", tamper: func(proof *StateProof) { proof.Value = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := newTestStateProof(t, []byte("address1"))
+			tt.tamper(proof)
+			require.Error(t, proof.Verify(stateHash))
+		})
+	}
+}
@@ -58,9 +58,10 @@ func (g *GenericFIFOSet[TIdx, TData]) Push(item TData) error {
 
 	if g.isOverflowing != nil && g.isOverflowing(g) {
 		front := g.queue.Front()
-		delete(g.set, g.indexerFn(front.Value.(TData)))
+		frontItem := front.Value.(TData)
+		delete(g.set, g.indexerFn(frontItem))
 		g.queue.Remove(front)
-		g.onRemove(item, g)
+		g.onRemove(frontItem, g)
 	}
 	return nil
 }
@@ -136,6 +137,35 @@ func (g *GenericFIFOSet[TIdx, TData]) ContainsIndex(index TIdx) bool {
 	return ok
 }
 
+// Get returns the item with the given index, if it is in the set
+func (g *GenericFIFOSet[TIdx, TData]) Get(index TIdx) (item TData, ok bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if _, ok := g.set[index]; !ok {
+		return item, false
+	}
+	for e := g.queue.Front(); e != nil; e = e.Next() {
+		if g.indexerFn(e.Value.(TData)) == index {
+			return e.Value.(TData), true
+		}
+	}
+	return item, false
+}
+
+// Range calls `fn` on the items from the oldest to the newest until it returns false. The set is locked in the
+// meantime, so `fn` must not call the methods of the set.
+func (g *GenericFIFOSet[TIdx, TData]) Range(fn func(item TData) bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	for e := g.queue.Front(); e != nil; e = e.Next() {
+		if !fn(e.Value.(TData)) {
+			return
+		}
+	}
+}
+
 // Options
 
 func WithIndexerFn[TIdx comparable, TData any](fn func(any) TIdx) func(*GenericFIFOSet[TIdx, TData]) {
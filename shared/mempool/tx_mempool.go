@@ -10,4 +10,9 @@ type TXMempool interface {
 	IsEmpty() bool
 	TxsBytesTotal() uint64 // Returns the total sum of all transactions' sizes (in bytes) stored in the mempool
 	PopTx() (tx []byte, err error)
+
+	// Read-only access; the returned transactions are shared with the mempool and must not be modified
+	GetTx(hash string) []byte // Returns nil if the tx is not in the mempool
+	// Calls `fn` on the txs in the order they are popped until it returns false. `fn` must not call the mempool.
+	Range(fn func(tx []byte) bool)
 }
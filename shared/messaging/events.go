@@ -7,5 +7,6 @@ const (
 	StateMachineTransitionEventType = "pocket.StateMachineTransitionEvent"
 
 	// Utility
+	MempoolTxAdmittedEventType = "pocket.MempoolTxAdmittedEvent"
 	TxGossipMessageContentType = "utility.TxGossipMessage"
 )
@@ -29,6 +29,8 @@ type ConsensusModule interface {
 	HandleMessage(*anypb.Any) error
 	// State Sync messages Handler
 	HandleStateSyncMessage(*anypb.Any) error
+	// HandleEvent is used to react to events that occur inside the application (e.g. state machine transitions)
+	HandleEvent(*anypb.Any) error
 
 	// Consensus State Accessors
 	CurrentHeight() uint64
@@ -37,6 +39,17 @@ type ConsensusModule interface {
 
 	// State Sync functions
 	EnableServerMode()
+	// Returns the latest height of the local block store and of the network, as advertised by the peers during state sync
+	GetSyncHeights() (localHeight, networkHeight uint64, err error)
+
+	// Admin functions
+	// Holds the pacemaker at the start of the next view until consensus is resumed, as in its manual mode
+	PauseConsensus()
+	// Releases the pacemaker and starts the view it is held at, if any
+	ResumeConsensus()
+	IsConsensusPaused() bool
+	// Creates a snapshot of the state at the latest committed height, to be served by state sync, and returns its height & path
+	TriggerSnapshot() (height uint64, path string, err error)
 }
 
 // This interface represents functions exposed by the Consensus module for Pacemaker specific business logic.
@@ -74,6 +87,12 @@ type ConsensusPacemaker interface {
 type ConsensusStateSync interface {
 	GetNodeIdFromNodeAddress(string) (uint64, error)
 	GetNodeAddress() string
+	// Validates the QC of a block retrieved from a peer, applies it on top of the local state and commits it.
+	// IMPORTANT: Expected to be called while the consensus module lock is already held (i.e. from within state sync handlers).
+	CommitSyncedBlock(*types.Block) error
+	// Lets state sync check its progress periodically while holding the consensus module lock, since it is
+	// otherwise only driven by the messages received from the peers
+	HandleStateSyncTick() error
 }
 
 type ConsensusDebugModule interface {
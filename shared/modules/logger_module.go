@@ -20,4 +20,8 @@ type LoggerModule interface {
 	// NB: returns a pointer to mitigate `hugParam` linter error.
 	// (see: https://golangci-lint.run/usage/linters/#gocritic)
 	CreateLoggerForModule(string) *Logger
+
+	// Sets the level of all the loggers (e.g. "debug" or "info") at runtime
+	SetLogLevel(level string) error
+	GetLogLevel() string
 }
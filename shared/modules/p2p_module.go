@@ -3,6 +3,7 @@ package modules
 //go:generate mockgen -source=$GOFILE -destination=./mocks/p2p_module_mock.go -aux_files=github.com/pokt-network/pocket/shared/modules=module.go
 
 import (
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -21,6 +22,9 @@ type P2PModule interface {
 	// A direct asynchronous
 	Send(addr cryptoPocket.Address, msg *anypb.Any) error
 
+	// Returns the peers in the address book of this node along with its RainTree topology
+	GetNetworkInfo() *coreTypes.P2PNetworkInfo
+
 	// HandleEvent is used to react to events that occur inside the application
 	HandleEvent(*anypb.Any) error
 
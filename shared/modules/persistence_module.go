@@ -3,6 +3,8 @@ package modules
 //go:generate mockgen -source=$GOFILE -destination=./mocks/persistence_module_mock.go -aux_files=github.com/pokt-network/pocket/shared/modules=module.go
 
 import (
+	"io"
+
 	"github.com/pokt-network/pocket/persistence/kvstore"
 	"github.com/pokt-network/pocket/runtime/genesis"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
@@ -18,6 +20,8 @@ type PersistenceModule interface {
 	// Context operations
 	NewRWContext(height int64) (PersistenceRWContext, error)
 	NewReadContext(height int64) (PersistenceReadContext, error)
+	// Opens a context, isolated from the write context, whose changes are discarded on release (e.g. to simulate txs)
+	NewSimulationContext(height int64) (PersistenceRWContext, error)
 	ReleaseWriteContext() error // The module can maintain many read contexts, but only one write context can exist at a time
 
 	// BlockStore operations
@@ -25,7 +29,19 @@ type PersistenceModule interface {
 	NewWriteContext() PersistenceRWContext
 
 	// Indexer Queries
+	// The hash of a transaction is either the hash of its result or the hash of the transaction itself (i.e. `TxHash`)
 	TransactionExists(transactionHash string) (bool, error)
+	GetTransactionByHash(transactionHash string) (TxResult, error) // Returns nil if the transaction is not indexed
+	// Return up to `limit` transactions ordered by (height, index) starting at the `cursor` (empty for the first page),
+	// along with the cursor of the next page, which is empty once there are no more transactions
+	GetTransactionsByHeight(height int64, cursor string, limit int, descending bool) (txResults []TxResult, nextCursor string, err error)
+	GetTransactionsBySender(sender string, cursor string, limit int, descending bool) (txResults []TxResult, nextCursor string, err error)
+	GetTransactionsByRecipient(recipient string, cursor string, limit int, descending bool) (txResults []TxResult, nextCursor string, err error)
+	GetTransactionsByAddress(address string, cursor string, limit int, descending bool) (txResults []TxResult, nextCursor string, err error) // Signed by or sent to the address
+
+	// Snapshot operations
+	CreateSnapshot(w io.Writer) (height uint64, err error)  // Writes a snapshot of the state at the latest committed height
+	RestoreSnapshot(r io.Reader) (height uint64, err error) // Replaces the entire state with the snapshot after verifying it
 
 	// Debugging / development only
 	HandleDebugMessage(*messaging.DebugMessage) error
@@ -71,6 +87,9 @@ type PersistenceWriteContext interface {
 	// if the context is committed.
 	ComputeStateHash() (string, error)
 
+	// Returns the accounts, pools, actors, params & flags written at the height of the context
+	GetStateChanges() (*coreTypes.StateChanges, error)
+
 	// Indexes the transaction using several different keys (for lookup purposes) in the key-value store
 	// that backs the transaction merkle tree.
 	IndexTransaction(txResult TxResult) error
@@ -126,6 +145,12 @@ type PersistenceWriteContext interface {
 	// Flag Operations
 	InitFlags() error
 	SetFlag(paramName string, value any, enabled bool) error
+
+	// Report Card Operations
+	SetReportCard(reportCard *coreTypes.ReportCard) error // Versioned at the height of the context
+
+	// Double Sign Evidence Operations
+	SetDoubleSignEvidence(validatorAddr []byte, voteHeight, voteRound uint64, voteStep int32) error // Handled at the height of the context
 }
 
 type PersistenceReadContext interface {
@@ -138,6 +163,9 @@ type PersistenceReadContext interface {
 	GetMaximumBlockHeight() (uint64, error)    // Returns the height of the latest block in the persistence layer
 	GetMinimumBlockHeight() (uint64, error)    // Returns the min block height in the persistence layer
 	GetBlockHash(height int64) (string, error) // Returns the app hash corresponding to the height provided
+	// Returns the lowest height whose state is fully retained by the pruning policy, and the interval at which the
+	// state of the heights below it is retained (0 if none is). Reading the state at any other height fails.
+	GetRetainedHeights() (minHeight int64, keepEvery uint64, err error)
 
 	// Pool Queries
 
@@ -191,6 +219,7 @@ type PersistenceReadContext interface {
 
 	// Actors Queries
 	GetAllStakedActors(height int64) ([]*coreTypes.Actor, error)
+	GetActor(actorType coreTypes.ActorType, address []byte, height int64) (*coreTypes.Actor, error) // Returns nil if the actor does not exist
 
 	// Params
 	GetIntParam(paramName string, height int64) (int, error)
@@ -202,4 +231,21 @@ type PersistenceReadContext interface {
 	GetIntFlag(paramName string, height int64) (int, bool, error)
 	GetStringFlag(paramName string, height int64) (string, bool, error)
 	GetBytesFlag(paramName string, height int64) ([]byte, bool, error)
+
+	// Report Card Queries
+	GetReportCard(servicerAddr []byte, height int64) (*coreTypes.ReportCard, error) // Returns nil if the servicer has no report card
+
+	// Double Sign Evidence Queries
+	GetDoubleSignEvidenceExists(validatorAddr []byte, voteHeight, voteRound uint64, voteStep int32, height int64) (exists bool, err error) // Whether the evidence was handled at or before the height
+
+	// State Proofs
+	// Return the value of the key in its state tree at the given height, along with a proof of (non-)membership and
+	// the roots of all the state trees needed to recompute the state hash. Any height the state trees are retained
+	// at can be proven.
+	GetAccountProof(address []byte, height int64) (*coreTypes.StateProof, error)
+	GetPoolProof(name string, height int64) (*coreTypes.StateProof, error)
+	GetActorProof(actorType coreTypes.ActorType, address []byte, height int64) (*coreTypes.StateProof, error)
+	GetParamProof(paramName string, height int64) (*coreTypes.StateProof, error)
+	GetFlagProof(flagName string, height int64) (*coreTypes.StateProof, error)
+	GetReportCardProof(servicerAddr []byte, height int64) (*coreTypes.StateProof, error)
 }
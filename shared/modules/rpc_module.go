@@ -2,8 +2,15 @@ package modules
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/rpc_module_mock.go -aux_files=github.com/pokt-network/pocket/shared/modules=module.go
 
+import (
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
 const RPCModuleName = "rpc"
 
 type RPCModule interface {
 	Module
+
+	// HandleEvent is used to react to events that occur inside the application
+	HandleEvent(*anypb.Any) error
 }
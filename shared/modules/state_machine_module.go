@@ -12,4 +12,6 @@ type StateMachineModule interface {
 	Module
 
 	SendEvent(event coreTypes.StateMachineEvent, args ...any) error
+	// Returns the current state of the node's finite state machine (e.g. `Consensus_SyncMode`)
+	Current() string
 }
@@ -3,6 +3,7 @@ package modules
 //go:generate mockgen -source=$GOFILE -destination=./mocks/utility_module_mock.go -aux_files=github.com/pokt-network/pocket/shared/modules=module.go
 
 import (
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/mempool"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -22,6 +23,18 @@ type UtilityModule interface {
 	// HandleTransaction does basic `Transaction` validation & adds it to the utility's module mempool if valid
 	HandleTransaction(tx []byte) error
 
+	// SimulateTransaction applies the `Transaction` on top of the latest committed state, as if it was included in the
+	// next block, and discards the changes. It returns the fee, result & state changes the transaction would have.
+	SimulateTransaction(tx []byte) (*coreTypes.TxSimulation, error)
+
+	// HandleRelay validates, persists & executes a relay sent to the node as a servicer, and returns the response of the
+	// `RelayChain` signed by the servicer. It fails if the node is not configured to service relays.
+	HandleRelay(relay *coreTypes.Relay) (*coreTypes.RelayResponse, error)
+
+	// GetSession dispatches the session of the application at the hex encoded `appAddr` on `relayChain` in `geoZone`
+	// containing `height`, i.e. the servicers serving its relays & the fishermen monitoring them
+	GetSession(appAddr string, height int64, relayChain, geoZone string) (*coreTypes.Session, error)
+
 	// GetMempool returns the utility module's mempool of transactions gossiped throughout the network
 	GetMempool() mempool.TXMempool
 
@@ -152,8 +152,21 @@ func (node *Node) handleEvent(message *messaging.PocketEnvelope) error {
 		return node.GetBus().GetUtilityModule().HandleUtilityMessage(message.Content)
 	case messaging.DebugMessageEventType:
 		return node.handleDebugMessage(message)
-	case messaging.ConsensusNewHeightEventType, messaging.StateMachineTransitionEventType:
-		return node.GetBus().GetP2PModule().HandleEvent(message.Content)
+	case messaging.ConsensusNewHeightEventType:
+		if err := node.GetBus().GetP2PModule().HandleEvent(message.Content); err != nil {
+			return err
+		}
+		return node.GetBus().GetRPCModule().HandleEvent(message.Content)
+	case messaging.StateMachineTransitionEventType:
+		if err := node.GetBus().GetP2PModule().HandleEvent(message.Content); err != nil {
+			return err
+		}
+		if err := node.GetBus().GetConsensusModule().HandleEvent(message.Content); err != nil {
+			return err
+		}
+		return node.GetBus().GetRPCModule().HandleEvent(message.Content)
+	case messaging.MempoolTxAdmittedEventType:
+		return node.GetBus().GetRPCModule().HandleEvent(message.Content)
 	default:
 		logger.Global.Warn().Msgf("Unsupported message content type: %s", contentType)
 	}
@@ -0,0 +1,13 @@
+package telemetry
+
+const (
+	// Time Series Metrics
+	RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_NAME        = "rpc_rate_limited_requests_counter"
+	RPC_RATE_LIMITED_REQUESTS_TIMESERIES_METRIC_DESCRIPTION = "the counter to track the number of RPC requests rejected by a rate limit or a route quota"
+
+	RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_NAME        = "rpc_body_too_large_requests_counter"
+	RPC_BODY_TOO_LARGE_REQUESTS_TIMESERIES_METRIC_DESCRIPTION = "the counter to track the number of RPC requests rejected because of the size of their body"
+
+	RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_NAME        = "rpc_invalid_api_key_requests_counter"
+	RPC_INVALID_API_KEY_REQUESTS_TIMESERIES_METRIC_DESCRIPTION = "the counter to track the number of RPC requests rejected because of an unknown API key"
+)
@@ -5,8 +5,6 @@ package utility
 // An Application stakes POKT in exchange for tokens to access Web3 access provided by the servicers.
 
 import (
-	"math/big"
-
 	"github.com/pokt-network/pocket/shared/utils"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
@@ -26,7 +24,5 @@ func (u *utilityContext) calculateAppSessionTokens(appStakeStr string) (string,
 		return typesUtil.EmptyString, err
 	}
 
-	stakeToSessionTokens := big.NewInt(int64(stakeToSessionTokensMultiplier))
-	sessionTokens := appStake.Mul(appStake, stakeToSessionTokens)
-	return sessionTokens.String(), nil
+	return typesUtil.CalculateAppSessionTokens(appStake, stakeToSessionTokensMultiplier).String(), nil
 }
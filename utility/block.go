@@ -4,8 +4,12 @@ package utility
 
 import (
 	"encoding/hex"
+	"errors"
 	"math/big"
 
+	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	moduleTypes "github.com/pokt-network/pocket/shared/modules/types"
 	"github.com/pokt-network/pocket/shared/utils"
@@ -53,10 +57,28 @@ func (u *utilityContext) CreateAndApplyProposalBlock(proposer []byte, maxTransac
 			break // we've reached our max
 		}
 
+		txHash, err := tx.Hash()
+		if err != nil {
+			return "", nil, err
+		}
+		txHashBz, err := hex.DecodeString(txHash)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Every transaction is applied on top of a save point so it can be reverted, on its own, if it fails
+		if err := u.newSavePoint(txHashBz); err != nil {
+			if err.Code() == typesUtil.CodeDuplicateSavePointError {
+				u.logger.Warn().Str("tx_hash", txHash).Msg("Skipping tx that was already applied in this block")
+				txsTotalBz -= txBzSize
+				continue
+			}
+			return "", nil, err
+		}
+
 		txResult, err := u.hydrateTxResult(tx, txIdx)
 		if err != nil {
-			u.logger.Err(err).Msg("Error in ApplyTransaction")
-			// TODO(#327): Properly implement 'unhappy path' for save points
+			u.logger.Err(err).Str("tx_hash", txHash).Msg("Error in ApplyTransaction; reverting the tx")
 			if err := u.revertLastSavePoint(); err != nil {
 				return "", nil, err
 			}
@@ -325,7 +347,55 @@ func (u *utilityContext) beginUnstakingActorsPausedBefore(pausedBeforeHeight int
 	return nil
 }
 
-// TODO: Need to design & document this business logic.
+// prevBlockByzantineValidators returns the addresses of the validators of the previous height whose signature is missing
+// from the commit QC of the previous block. Validators who double signed are burnt when the evidence is submitted in a
+// `MessageDoubleSign` instead.
 func (u *utilityContext) prevBlockByzantineValidators() ([][]byte, error) {
-	return nil, nil
+	if u.height == 0 {
+		return nil, nil
+	}
+	prevHeight := u.height - 1
+
+	blockBz, err := u.GetBus().GetPersistenceModule().GetBlockStore().Get(utils.HeightToBytes(uint64(prevHeight)))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		// The block store does not have the previous block, e.g. when the state was restored from a snapshot
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return nil, err
+	}
+
+	commitQC := new(typesCons.QuorumCertificate)
+	if err := codec.GetCodec().Unmarshal(block.GetBlockHeader().GetQuorumCertificate(), commitQC); err != nil {
+		return nil, err
+	}
+	// Without signatures (e.g. the genesis block), there is no way to tell which validators did not sign the block
+	if len(commitQC.GetThresholdSignature().GetSignatures()) == 0 {
+		return nil, nil
+	}
+	signers := make(map[string]struct{}, len(commitQC.ThresholdSignature.Signatures))
+	for _, partialSig := range commitQC.ThresholdSignature.Signatures {
+		signers[partialSig.GetAddress()] = struct{}{}
+	}
+
+	validators, err := u.store.GetAllValidators(prevHeight)
+	if err != nil {
+		return nil, err
+	}
+	byzantineValidators := make([][]byte, 0)
+	for _, validator := range validators {
+		if _, ok := signers[validator.GetAddress()]; ok {
+			continue
+		}
+		addr, err := hex.DecodeString(validator.GetAddress())
+		if err != nil {
+			return nil, err
+		}
+		byzantineValidators = append(byzantineValidators, addr)
+	}
+	return byzantineValidators, nil
 }
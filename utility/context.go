@@ -34,6 +34,20 @@ func (u *utilityModule) NewContext(height int64) (modules.UtilityContext, error)
 	if err != nil {
 		return nil, typesUtil.ErrNewPersistenceContext(err)
 	}
+	return u.newContext(height, persistenceCtx), nil
+}
+
+// newSimulationContext creates a `utilityContext` whose changes are discarded on release. Unlike the ones created by
+// `NewContext`, it can exist alongside the context used by consensus but cannot be committed.
+func (u *utilityModule) newSimulationContext(height int64) (*utilityContext, typesUtil.Error) {
+	persistenceCtx, err := u.GetBus().GetPersistenceModule().NewSimulationContext(height)
+	if err != nil {
+		return nil, typesUtil.ErrNewPersistenceContext(err)
+	}
+	return u.newContext(height, persistenceCtx), nil
+}
+
+func (u *utilityModule) newContext(height int64, persistenceCtx modules.PersistenceRWContext) *utilityContext {
 	ctx := &utilityContext{
 		logger: u.logger,
 		height: height,
@@ -44,7 +58,7 @@ func (u *utilityModule) NewContext(height int64) (modules.UtilityContext, error)
 		savePointsSet:  make(map[string]struct{}),
 	}
 	ctx.IntegratableModule.SetBus(u.GetBus())
-	return ctx, nil
+	return ctx
 }
 
 func (p *utilityContext) SetProposalBlock(blockHash string, proposerAddr []byte, txs [][]byte) error {
@@ -73,7 +87,7 @@ func (u *utilityContext) Release() error {
 	return nil
 }
 
-// TODO: This has not been tested or investigated in detail
+// revertLastSavePoint reverts all the changes made to the store since the last save point was created
 func (u *utilityContext) revertLastSavePoint() typesUtil.Error {
 	if len(u.savePointsSet) == 0 {
 		return typesUtil.ErrEmptySavePoints()
@@ -88,15 +102,16 @@ func (u *utilityContext) revertLastSavePoint() typesUtil.Error {
 	return nil
 }
 
-//nolint:unused // TODO: This has not been tested or investigated in detail
+// newSavePoint creates a save point, identified by the hash of the transaction about to be applied, that the
+// store can be reverted to if the transaction fails
 func (u *utilityContext) newSavePoint(txHashBz []byte) typesUtil.Error {
-	if err := u.store.NewSavePoint(txHashBz); err != nil {
-		return typesUtil.ErrNewSavePoint(err)
-	}
 	txHash := hex.EncodeToString(txHashBz)
 	if _, exists := u.savePointsSet[txHash]; exists {
 		return typesUtil.ErrDuplicateSavePoint()
 	}
+	if err := u.store.NewSavePoint(txHashBz); err != nil {
+		return typesUtil.ErrNewSavePoint(err)
+	}
 	u.savePointsList = append(u.savePointsList, txHashBz)
 	u.savePointsSet[txHash] = struct{}{}
 	return nil
@@ -525,6 +525,14 @@ func (u *utilityContext) getFee(msg typesUtil.Message, actorType coreTypes.Actor
 		}
 	case *typesUtil.MessageChangeParameter:
 		return u.getMessageChangeParameterFee()
+	case *typesUtil.MessageTestScore:
+		return u.getMessageTestScoreFee()
+	case *typesUtil.MessageProveTestScore:
+		return u.getMessageProveTestScoreFee()
+	case *typesUtil.MessageFishermanPauseServicer:
+		return u.getMessageFishermanPauseServicerFee()
+	case *typesUtil.MessageDoubleSign:
+		return u.getMessageDoubleSignFee()
 	default:
 		return nil, typesUtil.ErrUnknownMessage(x)
 	}
@@ -3,9 +3,12 @@ package utility
 import (
 	"github.com/pokt-network/pocket/logger"
 	"github.com/pokt-network/pocket/runtime/configs"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/mempool"
 	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/pokt-network/pocket/shared/modules/base_modules"
+	"github.com/pokt-network/pocket/utility/service"
 	"github.com/pokt-network/pocket/utility/types"
 )
 
@@ -20,8 +23,9 @@ type utilityModule struct {
 
 	config *configs.UtilityConfig
 
-	logger  *modules.Logger
-	mempool mempool.TXMempool
+	logger   *modules.Logger
+	mempool  mempool.TXMempool
+	servicer service.Servicer // nil unless the node services relays
 }
 
 func Create(bus modules.Bus, options ...modules.ModuleOption) (modules.Module, error) {
@@ -45,6 +49,16 @@ func (*utilityModule) Create(bus modules.Bus, options ...modules.ModuleOption) (
 	m.config = utilityCfg
 	m.mempool = types.NewTxFIFOMempool(utilityCfg.MaxMempoolTransactionBytes, utilityCfg.MaxMempoolTransactions)
 
+	if utilityCfg.GetServicer().GetEnabled() {
+		privateKey, err := crypto.NewPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if m.servicer, err = service.NewServicer(bus, utilityCfg.Servicer, privateKey); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
@@ -53,6 +67,13 @@ func (u *utilityModule) Start() error {
 	return nil
 }
 
+func (u *utilityModule) Stop() error {
+	if u.servicer == nil {
+		return nil
+	}
+	return u.servicer.Stop()
+}
+
 func (u *utilityModule) GetModuleName() string {
 	return modules.UtilityModuleName
 }
@@ -60,3 +81,10 @@ func (u *utilityModule) GetModuleName() string {
 func (u *utilityModule) GetMempool() mempool.TXMempool {
 	return u.mempool
 }
+
+func (u *utilityModule) HandleRelay(relay *coreTypes.Relay) (*coreTypes.RelayResponse, error) {
+	if u.servicer == nil {
+		return nil, types.ErrServicerDisabled()
+	}
+	return u.servicer.HandleRelay(relay)
+}
@@ -0,0 +1,109 @@
+package utility
+
+// Internal business logic for the report cards of the servicers, which hold the quality of service sampled by the
+// fishermen in the last session they were scored for. See `utility/doc/PROTOCOL_TEST_SCORE.md`.
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+// getFishermanSession dispatches the session identified by `header` and ensures the fisherman & the servicer are
+// part of it
+func (u *utilityContext) getFishermanSession(header *coreTypes.SessionHeader, fishermanAddr, servicerAddr []byte) (*coreTypes.Session, typesUtil.Error) {
+	appPubKey, er := crypto.NewPublicKey(header.GetApplicationPublicKey())
+	if er != nil {
+		return nil, typesUtil.ErrNewPublicKeyFromBytes(er)
+	}
+	session, err := newSession(u.store, appPubKey.Address().String(), header.GetSessionHeight(), header.GetRelayChain(), header.GetGeoZone())
+	if err != nil {
+		return nil, err
+	}
+	if session.GetSessionHeight() != header.GetSessionHeight() {
+		return nil, typesUtil.ErrInvalidSession(fmt.Sprintf("no session starts at height %d", header.GetSessionHeight()))
+	}
+	if !containsActor(session.GetFishermen(), fishermanAddr) {
+		return nil, typesUtil.ErrFishermanNotInSession()
+	}
+	if !containsActor(session.GetServicers(), servicerAddr) {
+		return nil, typesUtil.ErrServicerNotInSession()
+	}
+	return session, nil
+}
+
+func containsActor(actors []*coreTypes.Actor, addr []byte) bool {
+	addrHex := hex.EncodeToString(addr)
+	for _, actor := range actors {
+		if actor.GetAddress() == addrHex {
+			return true
+		}
+	}
+	return false
+}
+
+// getReportCard returns the report card of the servicer, or an empty one if it was never tested
+func (u *utilityContext) getReportCard(servicerAddr []byte) (*coreTypes.ReportCard, typesUtil.Error) {
+	reportCard, err := u.store.GetReportCard(servicerAddr, u.height)
+	if err != nil {
+		return nil, typesUtil.ErrReportCard(err)
+	}
+	if reportCard == nil {
+		return &coreTypes.ReportCard{
+			ServicerAddress:   hex.EncodeToString(servicerAddr),
+			LastSessionHeight: typesUtil.HeightNotUsed,
+		}, nil
+	}
+	return reportCard, nil
+}
+
+func (u *utilityContext) setReportCard(reportCard *coreTypes.ReportCard) typesUtil.Error {
+	if err := u.store.SetReportCard(reportCard); err != nil {
+		return typesUtil.ErrReportCard(err)
+	}
+	return nil
+}
+
+// newTestScore aggregates the samples of the message
+func newTestScore(msg *typesUtil.MessageTestScore) *coreTypes.TestScore {
+	testScore := &coreTypes.TestScore{
+		SessionHeader:    msg.SessionHeader,
+		FishermanAddress: hex.EncodeToString(msg.FishermanAddress),
+		NumSamples:       uint64(len(msg.Samples)),
+	}
+	for _, sample := range msg.Samples {
+		if sample.Success {
+			testScore.NumSuccessfulSamples++
+			testScore.TotalLatencyMsec += sample.LatencyMsec
+		}
+	}
+	return testScore
+}
+
+// applyTestScore replaces the report card with the proven test score, so it only reflects the last session the
+// servicer was scored for rather than its lifetime
+func applyTestScore(reportCard *coreTypes.ReportCard, testScore *coreTypes.TestScore) {
+	reportCard.NumSamples = testScore.GetNumSamples()
+	reportCard.NumSuccessfulSamples = testScore.GetNumSuccessfulSamples()
+	reportCard.TotalLatencyMsec = testScore.GetTotalLatencyMsec()
+	reportCard.LastSessionHeight = testScore.GetSessionHeader().GetSessionHeight()
+	reportCard.PendingTestScore = nil
+}
+
+// getWeightedServicerReward weights the reward a servicer earns for the volume it serviced in the session starting at
+// `sessionHeight` by its report card: `reward * (numSuccessfulSamples + 1) / (numSamples + 2)`. A servicer that was
+// not scored for the session earns half of the reward, so being tested only pays off if it answers most samples.
+// TODO: Apply it to the rewards of the relays claimed by the servicers, once they can claim their volume.
+// TODO: Weight the rewards by the latency of the servicers as well.
+func getWeightedServicerReward(reward *big.Int, reportCard *coreTypes.ReportCard, sessionHeight int64) *big.Int {
+	var numSamples, numSuccessfulSamples uint64
+	if reportCard.GetLastSessionHeight() == sessionHeight {
+		numSamples, numSuccessfulSamples = reportCard.GetNumSamples(), reportCard.GetNumSuccessfulSamples()
+	}
+	weightedReward := new(big.Int).Mul(reward, new(big.Int).SetUint64(numSuccessfulSamples+1))
+	return weightedReward.Quo(weightedReward, new(big.Int).SetUint64(numSamples+2))
+}
@@ -0,0 +1,260 @@
+package utility
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testReportCardSessionHeight = 4
+
+type testReportCardSession struct {
+	header              *coreTypes.SessionHeader
+	servicer            *coreTypes.Actor
+	servicerKey         crypto.PrivateKey
+	fisherman           *coreTypes.Actor
+	reportCards         map[string]*coreTypes.ReportCard
+	servicerPauseHeight int64
+}
+
+// Returns a utility context at `height` whose store dispatches a session at `testReportCardSessionHeight` with a
+// single servicer & fisherman, and keeps the report cards in memory
+func newTestReportCardContext(t *testing.T, height int64) (*utilityContext, *testReportCardSession) {
+	app := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_APP, testSessionRelayChain)
+	servicerKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	servicer := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_SERVICER, testSessionRelayChain)
+	servicer.Address = servicerKey.Address().String()
+	servicer.PublicKey = servicerKey.PublicKey().String()
+	fisherman := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_FISH, testSessionRelayChain)
+	session := &testReportCardSession{
+		header: &coreTypes.SessionHeader{
+			ApplicationPublicKey: app.GetPublicKey(),
+			RelayChain:           testSessionRelayChain,
+			SessionHeight:        testReportCardSessionHeight,
+		},
+		servicer:            servicer,
+		servicerKey:         servicerKey,
+		fisherman:           fisherman,
+		reportCards:         make(map[string]*coreTypes.ReportCard),
+		servicerPauseHeight: typesUtil.HeightNotUsed,
+	}
+
+	ctrl := gomock.NewController(t)
+	storeMock := mockModules.NewMockPersistenceRWContext(ctrl)
+	storeMock.EXPECT().GetIntParam(typesUtil.BlocksPerSessionParamName, gomock.Any()).Return(testSessionBlocksPerSession, nil).AnyTimes()
+	storeMock.EXPECT().GetIntParam(typesUtil.ServicersPerSessionParamName, gomock.Any()).Return(1, nil).AnyTimes()
+	appAddr, err := hex.DecodeString(app.GetAddress())
+	require.NoError(t, err)
+	storeMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_APP, appAddr, int64(testReportCardSessionHeight)).Return(app, nil).AnyTimes()
+	storeMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_SERVICER, session.addressBytes(t, servicer), int64(testReportCardSessionHeight)).Return(servicer, nil).AnyTimes()
+	storeMock.EXPECT().GetBlockHash(int64(testReportCardSessionHeight)).Return(testSessionBlockHash, nil).AnyTimes()
+	storeMock.EXPECT().GetAllServicers(int64(testReportCardSessionHeight)).Return([]*coreTypes.Actor{servicer}, nil).AnyTimes()
+	storeMock.EXPECT().GetAllFishermen(int64(testReportCardSessionHeight)).Return([]*coreTypes.Actor{fisherman}, nil).AnyTimes()
+	storeMock.EXPECT().GetReportCard(gomock.Any(), height).DoAndReturn(func(servicerAddr []byte, _ int64) (*coreTypes.ReportCard, error) {
+		reportCard, ok := session.reportCards[hex.EncodeToString(servicerAddr)]
+		if !ok {
+			return nil, nil
+		}
+		return codec.GetCodec().Clone(reportCard).(*coreTypes.ReportCard), nil
+	}).AnyTimes()
+	storeMock.EXPECT().SetReportCard(gomock.Any()).DoAndReturn(func(reportCard *coreTypes.ReportCard) error {
+		session.reportCards[reportCard.GetServicerAddress()] = codec.GetCodec().Clone(reportCard).(*coreTypes.ReportCard)
+		return nil
+	}).AnyTimes()
+	storeMock.EXPECT().GetServicerPauseHeightIfExists(gomock.Any(), height).DoAndReturn(func([]byte, int64) (int64, error) {
+		return session.servicerPauseHeight, nil
+	}).AnyTimes()
+	storeMock.EXPECT().SetServicerPauseHeight(gomock.Any(), gomock.Any()).DoAndReturn(func(_ []byte, pauseHeight int64) error {
+		session.servicerPauseHeight = pauseHeight
+		return nil
+	}).AnyTimes()
+
+	return &utilityContext{height: height, store: storeMock}, session
+}
+
+func (s *testReportCardSession) newMessageTestScore(t *testing.T, samples ...*typesUtil.TestScoreSample) *typesUtil.MessageTestScore {
+	return &typesUtil.MessageTestScore{
+		SessionHeader:    s.header,
+		FishermanAddress: s.addressBytes(t, s.fisherman),
+		ServicerAddress:  s.addressBytes(t, s.servicer),
+		Samples:          samples,
+	}
+}
+
+func (s *testReportCardSession) newMessageProveTestScore(t *testing.T) *typesUtil.MessageProveTestScore {
+	relay := &coreTypes.Relay{
+		Payload: &coreTypes.RelayPayload{Data: "{}"},
+		Meta: &coreTypes.RelayMeta{
+			BlockHeight:       testReportCardSessionHeight + 1,
+			ServicerPublicKey: s.servicer.GetPublicKey(),
+			RelayChain:        testSessionRelayChain,
+		},
+	}
+	requestHash, err := relay.Hash()
+	require.NoError(t, err)
+	response := &coreTypes.RelayResponse{Payload: "{}", StatusCode: 200, RequestHash: requestHash}
+	require.NoError(t, response.Sign(s.servicerKey))
+	return &typesUtil.MessageProveTestScore{
+		SessionHeader:    s.header,
+		FishermanAddress: s.addressBytes(t, s.fisherman),
+		ServicerAddress:  s.addressBytes(t, s.servicer),
+		Relay:            relay,
+		Response:         response,
+	}
+}
+
+func (s *testReportCardSession) addressBytes(t *testing.T, actor *coreTypes.Actor) []byte {
+	addr, err := hex.DecodeString(actor.GetAddress())
+	require.NoError(t, err)
+	return addr
+}
+
+func TestReportCard_HandleMessageTestScore_ProveTestScore(t *testing.T) {
+	ctx, session := newTestReportCardContext(t, testReportCardSessionHeight+testSessionBlocksPerSession+1)
+	samples := []*typesUtil.TestScoreSample{
+		{LatencyMsec: 100, Success: true},
+		{LatencyMsec: 300, Success: true},
+		{LatencyMsec: 200, Success: true},
+		{LatencyMsec: 5000, Success: false},
+	}
+
+	require.Nil(t, ctx.handleMessageTestScore(session.newMessageTestScore(t, samples...)))
+	reportCard := session.reportCards[session.servicer.GetAddress()]
+	require.Equal(t, typesUtil.HeightNotUsed, reportCard.GetLastSessionHeight(), "the test score is pending until it is proven")
+	require.Equal(t, uint64(4), reportCard.GetPendingTestScore().GetNumSamples())
+	require.Equal(t, uint64(3), reportCard.GetPendingTestScore().GetNumSuccessfulSamples())
+	require.Equal(t, uint64(600), reportCard.GetPendingTestScore().GetTotalLatencyMsec())
+
+	err := ctx.handleMessageTestScore(session.newMessageTestScore(t, samples...))
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code(), "a single test score can be pending for a session")
+
+	// The response must be signed by the servicer
+	invalidProof := session.newMessageProveTestScore(t)
+	invalidProof.Response.Payload = "tampered"
+	err = ctx.handleMessageProveTestScore(invalidProof)
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code())
+
+	// The response must answer the relay, so the response signed for another relay cannot be replayed
+	invalidProof = session.newMessageProveTestScore(t)
+	invalidProof.Relay.Payload.Data = `{"id":2}`
+	err = ctx.handleMessageProveTestScore(invalidProof)
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code())
+
+	// The relay must be for the session
+	invalidProof = session.newMessageProveTestScore(t)
+	invalidProof.Relay.Meta.BlockHeight = testReportCardSessionHeight + testSessionBlocksPerSession
+	err = ctx.handleMessageProveTestScore(invalidProof)
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code())
+
+	require.Nil(t, ctx.handleMessageProveTestScore(session.newMessageProveTestScore(t)))
+	reportCard = session.reportCards[session.servicer.GetAddress()]
+	require.Nil(t, reportCard.GetPendingTestScore())
+	require.Equal(t, int64(testReportCardSessionHeight), reportCard.GetLastSessionHeight())
+	require.Equal(t, uint64(4), reportCard.GetNumSamples())
+	require.Equal(t, uint64(3), reportCard.GetNumSuccessfulSamples())
+	require.Equal(t, uint64(600), reportCard.GetTotalLatencyMsec())
+
+	// Neither the test score nor its proof can be replayed
+	err = ctx.handleMessageProveTestScore(session.newMessageProveTestScore(t))
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code())
+	err = ctx.handleMessageTestScore(session.newMessageTestScore(t, samples...))
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code())
+}
+
+func TestReportCard_HandleMessageTestScore_Invalid(t *testing.T) {
+	sample := &typesUtil.TestScoreSample{LatencyMsec: 100, Success: true}
+
+	ctx, session := newTestReportCardContext(t, testReportCardSessionHeight+testSessionBlocksPerSession-1)
+	err := ctx.handleMessageTestScore(session.newMessageTestScore(t, sample))
+	require.Equal(t, typesUtil.CodeSessionNotEndedError, err.Code())
+
+	ctx, session = newTestReportCardContext(t, testReportCardSessionHeight+testSessionBlocksPerSession)
+	msg := session.newMessageTestScore(t, sample)
+	msg.FishermanAddress = session.addressBytes(t, session.servicer)
+	err = ctx.handleMessageTestScore(msg)
+	require.Equal(t, typesUtil.CodeFishermanNotInSessionError, err.Code())
+
+	msg = session.newMessageTestScore(t, sample)
+	msg.ServicerAddress = session.addressBytes(t, session.fisherman)
+	err = ctx.handleMessageTestScore(msg)
+	require.Equal(t, typesUtil.CodeServicerNotInSessionError, err.Code())
+
+	err = ctx.handleMessageProveTestScore(session.newMessageProveTestScore(t))
+	require.Equal(t, typesUtil.CodeInvalidTestScoreError, err.Code(), "no test score is pending")
+}
+
+func TestReportCard_HandleMessageFishermanPauseServicer(t *testing.T) {
+	ctx, session := newTestReportCardContext(t, testReportCardSessionHeight+2*testSessionBlocksPerSession)
+	msg := &typesUtil.MessageFishermanPauseServicer{
+		SessionHeader:    session.header,
+		FishermanAddress: session.addressBytes(t, session.fisherman),
+		ServicerAddress:  session.addressBytes(t, session.servicer),
+	}
+	err := ctx.handleMessageFishermanPauseServicer(msg)
+	require.Equal(t, typesUtil.CodeInvalidSessionError, err.Code(), "the session is not recent")
+
+	height := int64(testReportCardSessionHeight + testSessionBlocksPerSession + 1)
+	ctx, session = newTestReportCardContext(t, height)
+	msg.SessionHeader = session.header
+	msg.FishermanAddress = session.addressBytes(t, session.fisherman)
+	msg.ServicerAddress = session.addressBytes(t, session.servicer)
+	require.Nil(t, ctx.handleMessageFishermanPauseServicer(msg))
+	require.Equal(t, height, session.servicerPauseHeight)
+
+	err = ctx.handleMessageFishermanPauseServicer(msg)
+	require.Equal(t, typesUtil.CodeAlreadyPausedError, err.Code())
+}
+
+func TestReportCard_ApplyTestScore(t *testing.T) {
+	reportCard := &coreTypes.ReportCard{
+		NumSamples:           10,
+		NumSuccessfulSamples: 10,
+		TotalLatencyMsec:     1000,
+		LastSessionHeight:    testReportCardSessionHeight,
+	}
+	testScore := &coreTypes.TestScore{
+		SessionHeader:        &coreTypes.SessionHeader{SessionHeight: testReportCardSessionHeight + testSessionBlocksPerSession},
+		NumSamples:           2,
+		NumSuccessfulSamples: 1,
+		TotalLatencyMsec:     100,
+	}
+	reportCard.PendingTestScore = testScore
+
+	// The report card only reflects the last session, so a servicer cannot live off the scores of its past sessions
+	applyTestScore(reportCard, testScore)
+	require.Equal(t, uint64(2), reportCard.GetNumSamples())
+	require.Equal(t, uint64(1), reportCard.GetNumSuccessfulSamples())
+	require.Equal(t, uint64(100), reportCard.GetTotalLatencyMsec())
+	require.Equal(t, int64(testReportCardSessionHeight+testSessionBlocksPerSession), reportCard.GetLastSessionHeight())
+	require.Nil(t, reportCard.GetPendingTestScore())
+}
+
+func TestReportCard_GetWeightedServicerReward(t *testing.T) {
+	reward := big.NewInt(1200)
+	tests := []struct {
+		name       string
+		reportCard *coreTypes.ReportCard
+		want       *big.Int
+	}{
+		{"untested servicer", &coreTypes.ReportCard{LastSessionHeight: typesUtil.HeightNotUsed}, big.NewInt(600)},
+		{"servicer scored for another session", &coreTypes.ReportCard{NumSamples: 10, NumSuccessfulSamples: 10}, big.NewInt(600)},
+		{"successful servicer", &coreTypes.ReportCard{NumSamples: 10, NumSuccessfulSamples: 10, LastSessionHeight: testReportCardSessionHeight}, big.NewInt(1100)},
+		{"partially successful servicer", &coreTypes.ReportCard{NumSamples: 4, NumSuccessfulSamples: 3, LastSessionHeight: testReportCardSessionHeight}, big.NewInt(800)},
+		{"failing servicer", &coreTypes.ReportCard{NumSamples: 10, LastSessionHeight: testReportCardSessionHeight}, big.NewInt(100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getWeightedServicerReward(reward, tt.reportCard, testReportCardSessionHeight))
+		})
+	}
+	require.Equal(t, big.NewInt(1200), reward, "the reward is not modified")
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+var (
+	relaysPrefix      = []byte("relays/")
+	relayCountsPrefix = []byte("relay_counts/")
+)
+
+// relayStore persists the relays serviced by the node, indexed by session, until they are reported or expire
+type relayStore struct {
+	kv kvstore.KVStore
+	// Serializes the session token quota checks with the storage of the relays they account for
+	m sync.Mutex
+	// The sessions that started before this height were already pruned
+	prunedBefore int64
+}
+
+func newRelayStore(path string) (*relayStore, error) {
+	if path == "" {
+		return &relayStore{kv: kvstore.NewMemKVStore()}, nil
+	}
+	kv, err := kvstore.NewKVStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return &relayStore{kv: kv}, nil
+}
+
+// sessionKey identifies the session of an application on a relay chain in a geo zone. It starts with the zero padded
+// session height, so the sessions are ordered by height in the store.
+func sessionKey(header *coreTypes.SessionHeader) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s/%s/", sessionHeightKey(header.GetSessionHeight()), header.GetRelayChain(), header.GetGeoZone(), header.GetApplicationPublicKey()))
+}
+
+func sessionHeightKey(sessionHeight int64) string {
+	return fmt.Sprintf("%020d", sessionHeight)
+}
+
+// storeRelay persists the relay under its session, unless the application already used its `sessionTokens` in the
+// session or the relay was already stored
+func (s *relayStore) storeRelay(sessionKey []byte, relay *coreTypes.Relay, sessionTokens *big.Int) typesUtil.Error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	relayCount, err := s.getRelayCount(sessionKey)
+	if err != nil {
+		return typesUtil.ErrStoreRelay(err)
+	}
+	if new(big.Int).SetUint64(relayCount).Cmp(sessionTokens) >= 0 {
+		return typesUtil.ErrAppOverServiced(sessionTokens.String())
+	}
+
+	relayKey, relayBz, err := relayStoreKey(sessionKey, relay)
+	if err != nil {
+		return typesUtil.ErrProtoMarshal(err)
+	}
+	// `Exists` fails on the missing keys
+	if _, err := s.kv.Get(relayKey); err == nil {
+		return typesUtil.ErrInvalidRelay("the relay was already serviced")
+	} else if !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return typesUtil.ErrStoreRelay(err)
+	}
+
+	relayCountBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(relayCountBz, relayCount+1)
+	batch := s.kv.NewBatch()
+	defer batch.Cancel()
+	if err := batch.Set(relayKey, relayBz); err != nil {
+		return typesUtil.ErrStoreRelay(err)
+	}
+	if err := batch.Set(relayCountKey(sessionKey), relayCountBz); err != nil {
+		return typesUtil.ErrStoreRelay(err)
+	}
+	if err := batch.Write(); err != nil {
+		return typesUtil.ErrStoreRelay(err)
+	}
+	return nil
+}
+
+// removeRelay deletes a relay stored in the session and releases the session token it used, e.g. when its execution
+// failed and it must not be claimed
+func (s *relayStore) removeRelay(sessionKey []byte, relay *coreTypes.Relay) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	relayKey, _, err := relayStoreKey(sessionKey, relay)
+	if err != nil {
+		return err
+	}
+	if _, err := s.kv.Get(relayKey); errors.Is(err, kvstore.ErrKeyNotFound) {
+		// Already pruned along with its session
+		return nil
+	} else if err != nil {
+		return err
+	}
+	relayCount, err := s.getRelayCount(sessionKey)
+	if err != nil {
+		return err
+	}
+
+	batch := s.kv.NewBatch()
+	defer batch.Cancel()
+	if err := batch.Delete(relayKey); err != nil {
+		return err
+	}
+	if relayCount > 0 {
+		relayCountBz := make([]byte, 8)
+		binary.BigEndian.PutUint64(relayCountBz, relayCount-1)
+		if err := batch.Set(relayCountKey(sessionKey), relayCountBz); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// getRelayCount returns the number of relays stored in the session
+func (s *relayStore) getRelayCount(sessionKey []byte) (uint64, error) {
+	relayCountBz, err := s.kv.Get(relayCountKey(sessionKey))
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(relayCountBz), nil
+}
+
+// reapVolumeApplicableRelays returns the relays of the session whose hex encoded hash ends with `hashEndWith`, and
+// deletes the others since they do not count towards the volume of the session
+func (s *relayStore) reapVolumeApplicableRelays(sessionKey []byte, hashEndWith string) ([]*coreTypes.Relay, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	prefix := sessionRelaysKey(sessionKey)
+	keys, values, err := s.kv.GetAll(prefix, false)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := s.kv.NewBatch()
+	defer batch.Cancel()
+	var relays []*coreTypes.Relay
+	for i, key := range keys {
+		if !strings.HasSuffix(string(key[len(prefix):]), hashEndWith) {
+			if err := batch.Delete(key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		relay := &coreTypes.Relay{}
+		if err := codec.GetCodec().Unmarshal(values[i], relay); err != nil {
+			return nil, err
+		}
+		relays = append(relays, relay)
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return relays, nil
+}
+
+// pruneSession deletes the relays of the session, e.g. once they were reported
+func (s *relayStore) pruneSession(sessionKey []byte) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.deleteRange(sessionKey, append(append([]byte{}, sessionKey...), 0xff))
+}
+
+// pruneSessionsBefore deletes the relays of all the sessions that started before `sessionHeight`
+func (s *relayStore) pruneSessionsBefore(sessionHeight int64) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if sessionHeight <= s.prunedBefore {
+		return nil
+	}
+	if err := s.deleteRange(nil, []byte(sessionHeightKey(sessionHeight))); err != nil {
+		return err
+	}
+	s.prunedBefore = sessionHeight
+	return nil
+}
+
+// deleteRange deletes the relays & relay counts of the sessions whose key is in the `[startSessionKey, endSessionKey)`
+// range, where a nil bound leaves the range unbounded on that side
+func (s *relayStore) deleteRange(startSessionKey, endSessionKey []byte) error {
+	batch := s.kv.NewBatch()
+	defer batch.Cancel()
+	for _, prefix := range [][]byte{relaysPrefix, relayCountsPrefix} {
+		var start, end []byte
+		if startSessionKey != nil {
+			start = append(append([]byte{}, prefix...), startSessionKey...)
+		}
+		if endSessionKey != nil {
+			end = append(append([]byte{}, prefix...), endSessionKey...)
+		}
+		it, err := s.kv.Iterator(prefix, start, end, false)
+		if err != nil {
+			return err
+		}
+		for it.Next() {
+			if err := batch.Delete(append([]byte{}, it.Key()...)); err != nil {
+				it.Close()
+				return err
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// relayStoreKey returns the key of the relay in the session, i.e. the hash of its serialization, along with the latter
+func relayStoreKey(sessionKey []byte, relay *coreTypes.Relay) (key, relayBz []byte, err error) {
+	relayBz, err = codec.GetCodec().Marshal(relay)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(sessionRelaysKey(sessionKey), crypto.GetHashStringFromBytes(relayBz)...), relayBz, nil
+}
+
+func sessionRelaysKey(sessionKey []byte) []byte {
+	return append(append([]byte{}, relaysPrefix...), sessionKey...)
+}
+
+func relayCountKey(sessionKey []byte) []byte {
+	return append(append([]byte{}, relayCountsPrefix...), sessionKey...)
+}
+
+func (s *relayStore) stop() error {
+	return s.kv.Stop()
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRelayStore(t *testing.T) *relayStore {
+	store, err := newRelayStore("")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.stop())
+	})
+	return store
+}
+
+// Stores `numRelays` relays in the session and returns them
+func storeTestRelays(t *testing.T, store *relayStore, header *coreTypes.SessionHeader, numRelays int) []*coreTypes.Relay {
+	relays := make([]*coreTypes.Relay, 0, numRelays)
+	for i := 0; i < numRelays; i++ {
+		relay := &coreTypes.Relay{
+			Payload: &coreTypes.RelayPayload{Data: fmt.Sprintf("%d", i)},
+			Meta:    &coreTypes.RelayMeta{BlockHeight: header.GetSessionHeight(), RelayChain: header.GetRelayChain()},
+		}
+		require.Nil(t, store.storeRelay(sessionKey(header), relay, big.NewInt(int64(numRelays))))
+		relays = append(relays, relay)
+	}
+	return relays
+}
+
+func getTestRelayHash(t *testing.T, relay *coreTypes.Relay) string {
+	relayBz, err := codec.GetCodec().Marshal(relay)
+	require.NoError(t, err)
+	return crypto.GetHashStringFromBytes(relayBz)
+}
+
+func TestRelayStore_ReapVolumeApplicableRelays(t *testing.T) {
+	store := newTestRelayStore(t)
+	header := &coreTypes.SessionHeader{ApplicationPublicKey: "app", RelayChain: testRelayChain, SessionHeight: 4}
+	relays := storeTestRelays(t, store, header, 50)
+
+	hashEndWith := getTestRelayHash(t, relays[0])[63:]
+	var expectedRelays []*coreTypes.Relay
+	for _, relay := range relays {
+		if strings.HasSuffix(getTestRelayHash(t, relay), hashEndWith) {
+			expectedRelays = append(expectedRelays, relay)
+		}
+	}
+
+	volumeRelays, err := store.reapVolumeApplicableRelays(sessionKey(header), hashEndWith)
+	require.NoError(t, err)
+	require.Len(t, volumeRelays, len(expectedRelays))
+	for _, relay := range volumeRelays {
+		require.True(t, strings.HasSuffix(getTestRelayHash(t, relay), hashEndWith))
+	}
+
+	// The relays that are not volume applicable are pruned
+	keys, _, err := store.kv.GetAll(sessionRelaysKey(sessionKey(header)), false)
+	require.NoError(t, err)
+	require.Len(t, keys, len(expectedRelays))
+
+	volumeRelays, err = store.reapVolumeApplicableRelays(sessionKey(header), hashEndWith)
+	require.NoError(t, err)
+	require.Len(t, volumeRelays, len(expectedRelays))
+}
+
+func TestRelayStore_Prune(t *testing.T) {
+	store := newTestRelayStore(t)
+	var headers []*coreTypes.SessionHeader
+	for _, sessionHeight := range []int64{0, 4, 8} {
+		for _, app := range []string{"app1", "app2"} {
+			header := &coreTypes.SessionHeader{ApplicationPublicKey: app, RelayChain: testRelayChain, SessionHeight: sessionHeight}
+			storeTestRelays(t, store, header, 2)
+			headers = append(headers, header)
+		}
+	}
+	requireStoredRelays := func(header *coreTypes.SessionHeader, expectedCount int) {
+		keys, _, err := store.kv.GetAll(sessionRelaysKey(sessionKey(header)), false)
+		require.NoError(t, err)
+		require.Len(t, keys, expectedCount)
+		relayCount, err := store.getRelayCount(sessionKey(header))
+		require.NoError(t, err)
+		require.Equal(t, uint64(expectedCount), relayCount)
+	}
+
+	// A reported session is pruned on its own
+	require.NoError(t, store.pruneSession(sessionKey(headers[4])))
+	requireStoredRelays(headers[4], 0)
+	requireStoredRelays(headers[5], 2)
+
+	// The sessions that expired are pruned
+	require.NoError(t, store.pruneSessionsBefore(8))
+	for _, header := range headers[:4] {
+		requireStoredRelays(header, 0)
+	}
+	requireStoredRelays(headers[5], 2)
+}
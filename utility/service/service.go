@@ -1,125 +1,327 @@
 package service
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/runtime/defaults"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/shared/modules/base_modules"
+	"github.com/pokt-network/pocket/shared/utils"
 	"github.com/pokt-network/pocket/utility/types"
 )
 
-type Relay interface {
-	RelayPayload
-	RelayMeta
+// Servicer executes the relays sent to the node against the `RelayChain`s it supports, following the lifecycle
+// described in `utility/doc/PROTOCOL_RELAY.md`
+type Servicer interface {
+	modules.IntegratableModule
+
+	// HandleRelay validates, stores and executes the relay, returning the signed response of the `RelayChain`
+	HandleRelay(relay *coreTypes.Relay) (*coreTypes.RelayResponse, types.Error)
+	// GetVolumeApplicableRelays returns the relays of an ended session whose hash collides with the secret key revealed
+	// at its end, i.e. the evidence of the volume of relays serviced in the session, and prunes the other relays
+	GetVolumeApplicableRelays(header *coreTypes.SessionHeader) ([]*coreTypes.Relay, types.Error)
+	// PruneSession deletes the relays of the session, once they were reported
+	PruneSession(header *coreTypes.SessionHeader) types.Error
+	// Stop releases the store of the serviced relays
+	Stop() error
 }
 
-type RelayPayload interface {
-	GetData() string               // the actual data string for the external chain
-	GetMethod() string             // the http CRUD method
-	GetHTTPPath() string           // the HTTP Path
-	GetHeaders() map[string]string // http headers
-}
-
-type RelayMeta interface {
-	GetBlockHeight() int64 // the block height when the request is made
-	GetServicerPublicKey() crypto.PublicKey
-	GetRelayChain() RelayChain
-	GetGeoZone() GeoZone
-	GetToken() AAT
-	GetSignature() string
-}
+var _ Servicer = &servicer{}
 
-type RelayResponse interface {
-	Payload() string
-	ServicerSignature() string
-}
+const (
+	// The number of trailing hex characters of the hash of a relay that must match the secret key of its session for
+	// the relay to be volume applicable, i.e. 1 relay in 16^n is expected to be volume applicable
+	// TODO: Replace with a governance parameter so the fishermen can verify the volume applicable relays
+	volumeApplicableRelayHashSuffixLength = 1
+	// The number of sessions the relays are kept for after their session ended, unless they are pruned once reported
+	// TODO: Derive it from the window in which the volume of a session can be reported
+	relaysRetentionInSessions = 2
+)
 
-type RelayChain Identifiable
-type GeoZone Identifiable
+type servicer struct {
+	base_modules.IntegratableModule
 
-type AAT interface {
-	GetVersion() string              // confirm a valid AAT version
-	GetApplicationPublicKey() string // confirm the identity/signature of the app
-	GetClientPublicKey() string      // confirm the identity/signature of the client
-	GetApplicationSignature() string // confirm the application signed the token
+	config     *configs.ServicerConfig
+	privateKey crypto.PrivateKey
+	relays     *relayStore
+	client     *http.Client
+	// The maximum size of a response of a `RelayChain`
+	maxResponseBytes uint64
 }
 
-type Identifiable interface {
-	Name() string
-	ID() string
+func NewServicer(bus modules.Bus, servicerCfg *configs.ServicerConfig, privateKey crypto.PrivateKey) (Servicer, error) {
+	relays, err := newRelayStore(servicerCfg.GetRelaysStorePath())
+	if err != nil {
+		return nil, fmt.Errorf("opening the relays store: %w", err)
+	}
+
+	s := &servicer{
+		config:     servicerCfg,
+		privateKey: privateKey,
+		relays:     relays,
+		client:     &http.Client{Timeout: time.Duration(servicerCfg.GetRelayTimeoutMsec()) * time.Millisecond},
+
+		maxResponseBytes: servicerCfg.GetMaxRelayResponseBytes(),
+	}
+	if s.maxResponseBytes == 0 {
+		s.maxResponseBytes = defaults.DefaultServicerMaxRelayResponseBytes
+	}
+	s.SetBus(bus)
+
+	return s, nil
 }
 
-var _ Relay = &relay{}
+func (s *servicer) HandleRelay(relay *coreTypes.Relay) (*coreTypes.RelayResponse, types.Error) {
+	session, sessionTokens, err := s.validateRelay(relay)
+	if err != nil {
+		return nil, err
+	}
+	// The relays of the expired sessions are pruned once the first relay of a new session is serviced
+	expiryHeight := session.GetSessionHeight() - relaysRetentionInSessions*session.GetNumSessionBlocks()
+	if err := s.relays.pruneSessionsBefore(expiryHeight); err != nil {
+		return nil, types.ErrRelayEvidence(err)
+	}
+	// The relay is stored before its execution so it counts towards the session tokens of the application while it is
+	// executed, and is deleted if the `RelayChain` did not serve it so it cannot be claimed
+	key := sessionKey(session.GetHeader())
+	if err := s.relays.storeRelay(key, relay, sessionTokens); err != nil {
+		return nil, err
+	}
+	response, err := s.executeRelay(relay)
+	if err == nil && response.GetStatusCode() < http.StatusBadRequest {
+		return response, nil
+	}
+	if er := s.relays.removeRelay(key, relay); er != nil {
+		return nil, types.ErrRelayEvidence(er)
+	}
+	return response, err
+}
 
-type relay struct{}
+func (s *servicer) GetVolumeApplicableRelays(header *coreTypes.SessionHeader) ([]*coreTypes.Relay, types.Error) {
+	hashEndWith, err := s.getSessionSecretKey(header)
+	if err != nil {
+		return nil, err
+	}
+	hashEndWith = hashEndWith[len(hashEndWith)-volumeApplicableRelayHashSuffixLength:]
+
+	relays, er := s.relays.reapVolumeApplicableRelays(sessionKey(header), hashEndWith)
+	if er != nil {
+		return nil, types.ErrRelayEvidence(er)
+	}
+	return relays, nil
+}
 
-// Validate a submitted relay by a client before servicing
-func (r *relay) Validate() types.Error {
+func (s *servicer) PruneSession(header *coreTypes.SessionHeader) types.Error {
+	if err := s.relays.pruneSession(sessionKey(header)); err != nil {
+		return types.ErrRelayEvidence(err)
+	}
+	return nil
+}
 
-	// validate payload
+func (s *servicer) Stop() error {
+	return s.relays.stop()
+}
 
-	// validate the metadata
+// validateRelay validates a relay submitted by a client before servicing it, and returns its session along with the
+// number of session tokens of its application
+func (s *servicer) validateRelay(relay *coreTypes.Relay) (*coreTypes.Session, *big.Int, types.Error) {
+	// validate payload & metadata
+	if err := validateRelayBasic(relay); err != nil {
+		return nil, nil, err
+	}
+	meta := relay.GetMeta()
 
 	// ensure the RelayChain is supported locally
+	if _, ok := s.config.GetRelayChains()[meta.GetRelayChain()]; !ok {
+		return nil, nil, types.ErrUnsupportedRelayChain(meta.GetRelayChain())
+	}
+	if meta.GetServicerPublicKey() != s.privateKey.PublicKey().String() {
+		return nil, nil, types.ErrInvalidRelay("the relay is addressed to another servicer")
+	}
+	if er := meta.GetToken().ValidateSignature(); er != nil {
+		return nil, nil, types.ErrInvalidAAT(er)
+	}
+	if er := relay.ValidateSignature(); er != nil {
+		return nil, nil, types.ErrInvalidRelay(er.Error())
+	}
+
+	readCtx, er := s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if er != nil {
+		return nil, nil, types.ErrNewPersistenceContext(er)
+	}
+	defer readCtx.Close()
+
+	// get the session of the application from the AAT, dispatched at the latest height
+	latestHeight, er := readCtx.GetMaximumBlockHeight()
+	if er != nil {
+		return nil, nil, types.ErrGetHeight(er)
+	}
+	appPublicKey, er := crypto.NewPublicKey(meta.GetToken().GetApplicationPublicKey())
+	if er != nil {
+		return nil, nil, types.ErrNewPublicKeyFromBytes(er)
+	}
+	session, er := s.GetBus().GetUtilityModule().GetSession(appPublicKey.Address().String(), int64(latestHeight), meta.GetRelayChain(), meta.GetGeoZone())
+	if er != nil {
+		if err, ok := er.(types.Error); ok {
+			return nil, nil, err
+		}
+		return nil, nil, types.ErrInvalidSession(er.Error())
+	}
 
 	// ensure session block height is current
-
-	// get the session context
-
-	// get the application object from the r.AAT()
-
-	// get session node count from that session height
-
-	// get maximum possible relays for the application
-
-	// ensure not over serviced
-
-	// generate the session from seed data
+	sessionHeight := getSessionHeight(meta.GetBlockHeight(), session.GetNumSessionBlocks())
+	if sessionHeight != session.GetSessionHeight() {
+		return nil, nil, types.ErrInvalidRelaySessionHeight(sessionHeight, session.GetSessionHeight())
+	}
 
 	// validate self against the session
-
-	return nil
+	if !isInSession(session, s.privateKey.Address().String()) {
+		return nil, nil, types.ErrServicerNotInSession()
+	}
+
+	// get maximum possible relays for the application; the store ensures it is not over serviced
+	stakeToSessionTokensMultiplier, er := readCtx.GetIntParam(types.AppSessionTokensMultiplierParamName, sessionHeight)
+	if er != nil {
+		return nil, nil, types.ErrGetParam(types.AppSessionTokensMultiplierParamName, er)
+	}
+	appStake, er := utils.StringToBigInt(session.GetApplication().GetStakedAmount())
+	if er != nil {
+		return nil, nil, types.ErrStringToBigInt(er)
+	}
+
+	return session, types.CalculateAppSessionTokens(appStake, stakeToSessionTokensMultiplier), nil
 }
 
-// Store a submitted relay by a client for volume tracking
-func (r *relay) Store() types.Error {
-
-	// marshal relay object into protoBytes
-
-	// calculate the hashOf(protoBytes) <needed for volume tracking>
-
-	// persist relay object, indexing under session
-
+func validateRelayBasic(relay *coreTypes.Relay) types.Error {
+	if relay.GetPayload() == nil {
+		return types.ErrInvalidRelay("the payload is empty")
+	}
+	meta := relay.GetMeta()
+	switch {
+	case meta == nil:
+		return types.ErrInvalidRelay("the metadata is empty")
+	case meta.GetRelayChain() == "":
+		return types.ErrInvalidRelay(types.EmptyRelayChainError)
+	case meta.GetToken() == nil:
+		return types.ErrInvalidRelay("the application authentication token is empty")
+	case meta.GetSignature() == "":
+		return types.ErrInvalidRelay("the signature is empty")
+	}
 	return nil
 }
 
-// Execute a submitted relay by a client after validation
-func (r *relay) Execute() (RelayResponse, types.Error) {
-
-	// retrieve the RelayChain url from the servicer's local configuration file
-
-	// execute http request with the relay payload
-
-	// format and digitally sign the response
-
-	return nil, nil
+// executeRelay executes the relay against the URL of its `RelayChain` in the servicer configuration, and signs the
+// response along with the hash of the relay
+func (s *servicer) executeRelay(relay *coreTypes.Relay) (*coreTypes.RelayResponse, types.Error) {
+	payload := relay.GetPayload()
+	method := payload.GetMethod()
+	if method == "" {
+		method = http.MethodPost
+	}
+	url := strings.TrimSuffix(s.config.GetRelayChains()[relay.GetMeta().GetRelayChain()], "/")
+	if path := payload.GetHttpPath(); path != "" {
+		url += "/" + strings.TrimPrefix(path, "/")
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewBufferString(payload.GetData()))
+	if err != nil {
+		return nil, types.ErrRelayExecution(err)
+	}
+	for key, value := range payload.GetHeaders() {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, types.ErrRelayExecution(err)
+	}
+	defer resp.Body.Close()
+	// Read one more byte than allowed to detect the responses that are too large
+	respBz, err := io.ReadAll(io.LimitReader(resp.Body, int64(s.maxResponseBytes)+1))
+	if err != nil {
+		return nil, types.ErrRelayExecution(err)
+	}
+	if uint64(len(respBz)) > s.maxResponseBytes {
+		return nil, types.ErrRelayExecution(fmt.Errorf("the response exceeds %d bytes", s.maxResponseBytes))
+	}
+
+	requestHash, err := relay.Hash()
+	if err != nil {
+		return nil, types.ErrRelayExecution(err)
+	}
+	response := &coreTypes.RelayResponse{
+		Payload:     string(respBz),
+		StatusCode:  int32(resp.StatusCode),
+		RequestHash: requestHash,
+	}
+	if err := response.Sign(s.privateKey); err != nil {
+		return nil, types.ErrRelayExecution(err)
+	}
+	return response, nil
 }
 
-// Get volume metric applicable relays from store
-func (r *relay) ReapStoreForHashCollision(sessionBlockHeight int64, hashEndWith string) ([]Relay, types.Error) {
-
-	// Pull all relays whose hash collides with the revealed secret key
-	// It's important to note, the secret key isn't revealed by the network until the session is over
-	// to prevent volume based bias. The secret key is usually a pseudorandom selection using the block hash as a seed.
-	// (See the session protocol)
-	//
-	// Demonstrable pseudocode below:
-	//   `SELECT * from RELAY where HashOf(relay) ends with hashEndWith AND sessionBlockHeight=sessionBlockHeight`
+// getSessionHeight returns the height at which the session containing `height` started
+func getSessionHeight(height, blocksPerSession int64) int64 {
+	if blocksPerSession <= 0 {
+		return height
+	}
+	return height - height%blocksPerSession
+}
 
-	// This function also signifies deleting the non-volume-applicable Relays
+func isInSession(session *coreTypes.Session, servicerAddr string) bool {
+	for _, servicer := range session.GetServicers() {
+		if servicer.GetAddress() == servicerAddr {
+			return true
+		}
+	}
+	return false
+}
 
-	return nil, nil
+// getSessionSecretKey returns the hex encoded secret key of an ended session, which is revealed by the network at its
+// end to prevent volume based bias: `secretKey = Hash(blockHash + sessionKey)`, where `blockHash` is the hash of the
+// first block after the session
+func (s *servicer) getSessionSecretKey(header *coreTypes.SessionHeader) (string, types.Error) {
+	readCtx, err := s.GetBus().GetPersistenceModule().NewReadContext(-1) // Unknown height
+	if err != nil {
+		return "", types.ErrNewPersistenceContext(err)
+	}
+	defer readCtx.Close()
+
+	latestHeight, err := readCtx.GetMaximumBlockHeight()
+	if err != nil {
+		return "", types.ErrGetHeight(err)
+	}
+	blocksPerSession, err := readCtx.GetIntParam(types.BlocksPerSessionParamName, header.GetSessionHeight())
+	if err != nil {
+		return "", types.ErrGetParam(types.BlocksPerSessionParamName, err)
+	}
+	sessionEndHeight := header.GetSessionHeight() + int64(blocksPerSession)
+	if int64(latestHeight) < sessionEndHeight {
+		return "", types.ErrSessionNotEnded(sessionEndHeight)
+	}
+
+	blockHash, err := readCtx.GetBlockHash(sessionEndHeight)
+	if err != nil {
+		return "", types.ErrGetBlockHash(err)
+	}
+	blockHashBz, err := hex.DecodeString(blockHash)
+	if err != nil {
+		return "", types.ErrHexDecodeFromString(err)
+	}
+	return crypto.GetHashStringFromBytes(append(blockHashBz, sessionKey(header)...)), nil
 }
 
 // Report volume metric applicable relays to Fisherman
-func (r *relay) ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []Relay) types.Error {
+func (s *servicer) ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []*coreTypes.Relay) types.Error {
 
 	// Send all volume applicable relays to the assigned trusted Fisherman for
 	// a proper verification of the volume completed. Send volumeRelays to fishermanServiceURL
@@ -135,14 +337,3 @@ func (r *relay) ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []R
 
 	return nil
 }
-
-func (r *relay) GetData() string                        { return "" }
-func (r *relay) GetMethod() string                      { return "" }
-func (r *relay) GetHTTPPath() string                    { return "" }
-func (r *relay) GetHeaders() map[string]string          { return nil }
-func (r *relay) GetBlockHeight() int64                  { return 0 }
-func (r *relay) GetServicerPublicKey() crypto.PublicKey { return nil }
-func (r *relay) GetRelayChain() RelayChain              { return nil }
-func (r *relay) GetGeoZone() GeoZone                    { return nil }
-func (r *relay) GetToken() AAT                          { return nil }
-func (r *relay) GetSignature() string                   { return "" }
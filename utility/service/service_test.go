@@ -0,0 +1,375 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pokt-network/pocket/runtime/configs"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRelayChain       = "0001"
+	testLatestHeight     = 5
+	testBlocksPerSession = 4
+	testSessionHeight    = 4
+	testBlockHash        = "0a1b2c3d"
+	// The application stakes 1 and gets 2 session tokens, i.e. 2 relays per session
+	testAppStake                       = "1"
+	testStakeToSessionTokensMultiplier = 2
+)
+
+type testRelayActors struct {
+	app      crypto.PrivateKey
+	client   crypto.PrivateKey
+	servicer crypto.PrivateKey
+}
+
+func newTestRelayActors(t *testing.T) *testRelayActors {
+	actors := &testRelayActors{}
+	for _, key := range []*crypto.PrivateKey{&actors.app, &actors.client, &actors.servicer} {
+		privateKey, err := crypto.GeneratePrivateKey()
+		require.NoError(t, err)
+		*key = privateKey
+	}
+	return actors
+}
+
+// Returns a servicer of `actors.servicer` whose world state dispatches a session of `testRelayChain` to the application,
+// containing the servicer, unless they are in `unstakedActors`, which relays to `relayChainURL`
+func newTestServicer(t *testing.T, actors *testRelayActors, relayChainURL string, unstakedActors ...crypto.PrivateKey) Servicer {
+	ctrl := gomock.NewController(t)
+
+	isStaked := func(privateKey crypto.PrivateKey) bool {
+		for _, unstaked := range unstakedActors {
+			if unstaked.Equals(privateKey) {
+				return false
+			}
+		}
+		return true
+	}
+	newActor := func(actorType coreTypes.ActorType, privateKey crypto.PrivateKey) *coreTypes.Actor {
+		return &coreTypes.Actor{
+			ActorType:    actorType,
+			Address:      privateKey.Address().String(),
+			PublicKey:    privateKey.PublicKey().String(),
+			Chains:       []string{testRelayChain},
+			StakedAmount: testAppStake,
+		}
+	}
+
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetMaximumBlockHeight().Return(uint64(testLatestHeight), nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(types.AppSessionTokensMultiplierParamName, int64(testSessionHeight)).Return(testStakeToSessionTokensMultiplier, nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(types.BlocksPerSessionParamName, gomock.Any()).Return(testBlocksPerSession, nil).AnyTimes()
+	readCtxMock.EXPECT().GetBlockHash(int64(testSessionHeight)).Return(testBlockHash, nil).AnyTimes()
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+
+	utilityMock := mockModules.NewMockUtilityModule(ctrl)
+	utilityMock.EXPECT().
+		GetSession(actors.app.Address().String(), int64(testLatestHeight), testRelayChain, "").
+		DoAndReturn(func(string, int64, string, string) (*coreTypes.Session, error) {
+			if !isStaked(actors.app) {
+				return nil, types.ErrInvalidSession("the application is not staked")
+			}
+			session := &coreTypes.Session{
+				SessionHeight:    testSessionHeight,
+				NumSessionBlocks: testBlocksPerSession,
+				RelayChain:       testRelayChain,
+				Application:      newActor(coreTypes.ActorType_ACTOR_TYPE_APP, actors.app),
+			}
+			if isStaked(actors.servicer) {
+				session.Servicers = []*coreTypes.Actor{newActor(coreTypes.ActorType_ACTOR_TYPE_SERVICER, actors.servicer)}
+			}
+			return session, nil
+		}).
+		AnyTimes()
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(int64(-1)).Return(readCtxMock, nil).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	busMock.EXPECT().GetUtilityModule().Return(utilityMock).AnyTimes()
+
+	s, err := NewServicer(busMock, &configs.ServicerConfig{
+		Enabled:          true,
+		RelayChains:      map[string]string{testRelayChain: relayChainURL},
+		RelayTimeoutMsec: 1000,
+	}, actors.servicer)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+	return s
+}
+
+// Returns a relay of `data` to `testRelayChain`, signed by the client of a token signed by the application
+func newTestRelay(t *testing.T, actors *testRelayActors, data string) *coreTypes.Relay {
+	token := &coreTypes.AAT{
+		Version:              coreTypes.AATVersion,
+		ApplicationPublicKey: actors.app.PublicKey().String(),
+		ClientPublicKey:      actors.client.PublicKey().String(),
+	}
+	require.NoError(t, token.Sign(actors.app))
+
+	relay := &coreTypes.Relay{
+		Payload: &coreTypes.RelayPayload{
+			Data:     data,
+			Method:   http.MethodPost,
+			HttpPath: "/rpc",
+			Headers:  map[string]string{"Content-Type": "application/json"},
+		},
+		Meta: &coreTypes.RelayMeta{
+			BlockHeight:       testLatestHeight,
+			ServicerPublicKey: actors.servicer.PublicKey().String(),
+			RelayChain:        testRelayChain,
+			Token:             token,
+		},
+	}
+	require.NoError(t, relay.Sign(actors.client))
+	return relay
+}
+
+// Returns a relay chain stub answering the requests with their own body, and the requests it received
+func newTestRelayChain(t *testing.T) (*httptest.Server, *[]*http.Request) {
+	var requests []*http.Request
+	relayChain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, r)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(relayChain.Close)
+	return relayChain, &requests
+}
+
+func TestServicer_HandleRelay(t *testing.T) {
+	actors := newTestRelayActors(t)
+	relayChain, requests := newTestRelayChain(t)
+	s := newTestServicer(t, actors, relayChain.URL)
+
+	relay := newTestRelay(t, actors, `{"method":"eth_blockNumber"}`)
+	response, err := s.HandleRelay(relay)
+	require.NoError(t, err)
+	require.Equal(t, `{"method":"eth_blockNumber"}`, response.GetPayload())
+	require.Equal(t, int32(http.StatusAccepted), response.GetStatusCode())
+	require.NoError(t, response.ValidateSignature(actors.servicer.PublicKey().String()))
+	relayHash, er := relay.Hash()
+	require.NoError(t, er)
+	require.Equal(t, relayHash, response.GetRequestHash(), "the response is bound to the relay it answers")
+
+	require.Len(t, *requests, 1)
+	request := (*requests)[0]
+	require.Equal(t, http.MethodPost, request.Method)
+	require.Equal(t, "/rpc", request.URL.Path)
+	require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+}
+
+func TestServicer_HandleRelay_SessionTokens(t *testing.T) {
+	actors := newTestRelayActors(t)
+	relayChain, requests := newTestRelayChain(t)
+	s := newTestServicer(t, actors, relayChain.URL)
+
+	relay := newTestRelay(t, actors, "1")
+	_, err := s.HandleRelay(relay)
+	require.NoError(t, err)
+
+	// A replayed relay is only serviced once
+	_, err = s.HandleRelay(relay)
+	require.Equal(t, types.CodeInvalidRelayError, err.Code())
+
+	_, err = s.HandleRelay(newTestRelay(t, actors, "2"))
+	require.NoError(t, err)
+	_, err = s.HandleRelay(newTestRelay(t, actors, "3"))
+	require.Equal(t, types.CodeAppOverServicedError, err.Code())
+
+	require.Len(t, *requests, testStakeToSessionTokensMultiplier)
+}
+
+func TestServicer_HandleRelay_Invalid(t *testing.T) {
+	actors := newTestRelayActors(t)
+	otherKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	relayChain, requests := newTestRelayChain(t)
+
+	testCases := []struct {
+		name           string
+		malleate       func(relay *coreTypes.Relay)
+		unstakedActors []crypto.PrivateKey
+		expectedCode   types.Code
+	}{
+		{
+			name:         "empty payload",
+			malleate:     func(relay *coreTypes.Relay) { relay.Payload = nil },
+			expectedCode: types.CodeInvalidRelayError,
+		},
+		{
+			name:         "unsupported relay chain",
+			malleate:     func(relay *coreTypes.Relay) { relay.Meta.RelayChain = "0002" },
+			expectedCode: types.CodeUnsupportedRelayChainError,
+		},
+		{
+			name:         "relay addressed to another servicer",
+			malleate:     func(relay *coreTypes.Relay) { relay.Meta.ServicerPublicKey = otherKey.PublicKey().String() },
+			expectedCode: types.CodeInvalidRelayError,
+		},
+		{
+			name: "token not signed by the application",
+			malleate: func(relay *coreTypes.Relay) {
+				require.NoError(t, relay.Meta.Token.Sign(otherKey))
+				require.NoError(t, relay.Sign(actors.client))
+			},
+			expectedCode: types.CodeInvalidAATError,
+		},
+		{
+			name:         "relay not signed by the client",
+			malleate:     func(relay *coreTypes.Relay) { require.NoError(t, relay.Sign(otherKey)) },
+			expectedCode: types.CodeInvalidRelayError,
+		},
+		{
+			name: "relay of a past session",
+			malleate: func(relay *coreTypes.Relay) {
+				relay.Meta.BlockHeight = testSessionHeight - 1
+				require.NoError(t, relay.Sign(actors.client))
+			},
+			expectedCode: types.CodeInvalidRelaySessionHeightError,
+		},
+		{
+			name:           "application not staked",
+			unstakedActors: []crypto.PrivateKey{actors.app},
+			expectedCode:   types.CodeInvalidSessionError,
+		},
+		{
+			name:           "servicer not in the session",
+			unstakedActors: []crypto.PrivateKey{actors.servicer},
+			expectedCode:   types.CodeServicerNotInSessionError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestServicer(t, actors, relayChain.URL, tc.unstakedActors...)
+			relay := newTestRelay(t, actors, "data")
+			if tc.malleate != nil {
+				tc.malleate(relay)
+			}
+
+			_, err := s.HandleRelay(relay)
+			require.Error(t, err)
+			require.Equal(t, tc.expectedCode, err.Code(), err.Error())
+		})
+	}
+	require.Empty(t, *requests)
+}
+
+func TestServicer_HandleRelay_RelayChainUnavailable(t *testing.T) {
+	actors := newTestRelayActors(t)
+	relayChain, _ := newTestRelayChain(t)
+	relayChain.Close()
+	s := newTestServicer(t, actors, relayChain.URL)
+
+	_, err := s.HandleRelay(newTestRelay(t, actors, "data"))
+	require.Equal(t, types.CodeRelayExecutionError, err.Code())
+	requireTestRelayCount(t, s, actors, 0)
+}
+
+func TestServicer_HandleRelay_NotServed(t *testing.T) {
+	actors := newTestRelayActors(t)
+	relayChain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if string(body) == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(relayChain.Close)
+	s := newTestServicer(t, actors, relayChain.URL)
+	s.(*servicer).maxResponseBytes = 8
+
+	// The response of the relay chain is returned, but the relay cannot be claimed
+	response, err := s.HandleRelay(newTestRelay(t, actors, "rejected"))
+	require.Nil(t, err)
+	require.Equal(t, int32(http.StatusInternalServerError), response.GetStatusCode())
+	requireTestRelayCount(t, s, actors, 0)
+
+	_, err = s.HandleRelay(newTestRelay(t, actors, "too large"))
+	require.Equal(t, types.CodeRelayExecutionError, err.Code())
+	requireTestRelayCount(t, s, actors, 0)
+
+	// The relays that were not served do not use the session tokens of the application
+	for i := 0; i < testStakeToSessionTokensMultiplier; i++ {
+		_, err = s.HandleRelay(newTestRelay(t, actors, fmt.Sprint(i)))
+		require.Nil(t, err)
+	}
+	requireTestRelayCount(t, s, actors, testStakeToSessionTokensMultiplier)
+}
+
+// Requires `expectedCount` relays of the current session of the application to be stored as evidence
+func requireTestRelayCount(t *testing.T, s Servicer, actors *testRelayActors, expectedCount int) {
+	header := &coreTypes.SessionHeader{
+		ApplicationPublicKey: actors.app.PublicKey().String(),
+		RelayChain:           testRelayChain,
+		SessionHeight:        testSessionHeight,
+	}
+	relayCount, err := s.(*servicer).relays.getRelayCount(sessionKey(header))
+	require.NoError(t, err)
+	require.Equal(t, uint64(expectedCount), relayCount)
+	keys, _, err := s.(*servicer).relays.kv.GetAll(sessionRelaysKey(sessionKey(header)), false)
+	require.NoError(t, err)
+	require.Len(t, keys, expectedCount)
+}
+
+func TestServicer_GetVolumeApplicableRelays(t *testing.T) {
+	actors := newTestRelayActors(t)
+	relayChain, _ := newTestRelayChain(t)
+	s := newTestServicer(t, actors, relayChain.URL)
+
+	// The secret key of the current session is not revealed yet
+	_, err := s.GetVolumeApplicableRelays(&coreTypes.SessionHeader{
+		ApplicationPublicKey: actors.app.PublicKey().String(),
+		RelayChain:           testRelayChain,
+		SessionHeight:        testSessionHeight,
+	})
+	require.Equal(t, types.CodeSessionNotEndedError, err.Code())
+
+	// The previous session ended with the block at `testSessionHeight`
+	header := &coreTypes.SessionHeader{
+		ApplicationPublicKey: actors.app.PublicKey().String(),
+		RelayChain:           testRelayChain,
+		SessionHeight:        testSessionHeight - testBlocksPerSession,
+	}
+	relays := storeTestRelays(t, s.(*servicer).relays, header, 50)
+	blockHashBz, er := hex.DecodeString(testBlockHash)
+	require.NoError(t, er)
+	secretKey := crypto.GetHashStringFromBytes(append(blockHashBz, sessionKey(header)...))
+	hashEndWith := secretKey[len(secretKey)-volumeApplicableRelayHashSuffixLength:]
+
+	volumeRelays, err := s.GetVolumeApplicableRelays(header)
+	require.Nil(t, err)
+	expectedCount := 0
+	for _, relay := range relays {
+		if strings.HasSuffix(getTestRelayHash(t, relay), hashEndWith) {
+			expectedCount++
+		}
+	}
+	require.Len(t, volumeRelays, expectedCount)
+
+	// Nothing is left once the session is reported
+	require.Nil(t, s.PruneSession(header))
+	volumeRelays, err = s.GetVolumeApplicableRelays(header)
+	require.Nil(t, err)
+	require.Empty(t, volumeRelays)
+}
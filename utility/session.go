@@ -1,157 +1,229 @@
 package utility
 
-// IMPORTANT: The interface and implementation defined in this file are for illustrative purposes only
-// and need to be revisited before any implementation commences.
-
 import (
 	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
 
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
-	"github.com/pokt-network/pocket/utility/types"
+	"github.com/pokt-network/pocket/shared/modules"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
 
 // TODO: When implementing please review if block height tolerance (+,-1) is included in the session protocol: pokt-network/pocket-core#1464 CC @Olshansk
 
-// REFACTOR: Move these into `utility/types` and consider creating an enum
-type RelayChain string
-type GeoZone string
-
-type Session interface {
-	NewSession(sessionHeight int64, relayChain RelayChain, geoZone GeoZone, application *coreTypes.Actor) (Session, types.Error)
-	GetSessionID() []byte             // the identifier of the dispatched session
-	GetSessionHeight() int64          // the block height when the session started
-	GetRelayChain() RelayChain        // the web3 chain identifier
-	GetGeoZone() GeoZone              // the geo-location zone where the application is intending to operate during the session
-	GetApplication() *coreTypes.Actor // the Application consuming the web3 access
-	GetServicers() []*coreTypes.Actor // the Servicers providing Web3 to the application
-	GetFishermen() []*coreTypes.Actor // the Fishermen monitoring the servicers
-}
+// TODO: Replace with a governance parameter (e.g. `fishermen_per_session`)
+const numFishermenPerSession = 1
+
+// The number of values of `blocks_per_session` tried to find the start of a session, which is only exceeded if the
+// parameter changed back and forth within a few sessions
+const maxSessionAlignmentAttempts = 4
 
-var _ Session = &session{}
+// GetSession dispatches the session of the application at `appAddr` on `relayChain` in `geoZone` containing `height`,
+// following the Session Protocol described in `utility/doc/PROTOCOL_SESSION.md`. The session only depends on the world
+// state at the height it started, so any node dispatches the same one.
+func (u *utilityModule) GetSession(appAddr string, height int64, relayChain, geoZone string) (*coreTypes.Session, error) {
+	readCtx, err := u.GetBus().GetPersistenceModule().NewReadContext(height)
+	if err != nil {
+		return nil, typesUtil.ErrNewPersistenceContext(err)
+	}
+	defer readCtx.Close()
 
-type session struct {
-	sessionId   []byte
-	height      int64
-	relayChain  RelayChain
-	geoZone     GeoZone
-	application *coreTypes.Actor
-	servicers   []*coreTypes.Actor
-	fishermen   []*coreTypes.Actor
+	return newSession(readCtx, appAddr, height, relayChain, geoZone)
 }
 
-func (*session) NewSession(
-	sessionHeight int64,
-	relayChain RelayChain,
-	geoZone GeoZone,
-	application *coreTypes.Actor,
-) (Session, types.Error) {
-	s := &session{
-		height:      sessionHeight,
-		relayChain:  relayChain,
-		geoZone:     geoZone,
-		application: application,
+func newSession(readCtx modules.PersistenceReadContext, appAddr string, height int64, relayChain, geoZone string) (*coreTypes.Session, typesUtil.Error) {
+	if relayChain == "" {
+		return nil, typesUtil.ErrInvalidSession(typesUtil.EmptyRelayChainError)
+	}
+	appAddrBz, err := hex.DecodeString(appAddr)
+	if err != nil {
+		return nil, typesUtil.ErrHexDecodeFromString(err)
 	}
 
-	// TODO: make these configurable or based on governance params
-	numServicers := 1
-	numFisherman := 1
-
-	var err types.Error
-	if s.servicers, err = s.selectSessionServicers(numServicers); err != nil {
-		return nil, err
+	sessionHeight, numSessionBlocks, er := getSessionBounds(readCtx, height)
+	if er != nil {
+		return nil, er
 	}
-	if s.fishermen, err = s.selectSessionFishermen(numFisherman); err != nil {
-		return nil, err
+
+	// The world state at the start of the session determines its actors
+	app, err := readCtx.GetActor(coreTypes.ActorType_ACTOR_TYPE_APP, appAddrBz, sessionHeight)
+	if err != nil {
+		return nil, typesUtil.ErrGetExists(err)
 	}
-	if s.sessionId, err = s.getSessionId(); err != nil {
-		return nil, err
+	if app == nil || !isActiveForChain(app, relayChain) {
+		return nil, typesUtil.ErrInvalidSession(fmt.Sprintf("the application %s is not actively staked for relay chain %s at height %d", appAddr, relayChain, sessionHeight))
 	}
-	return s, nil
-}
 
-func (s *session) GetSessionID() []byte {
-	return s.sessionId
-}
+	key, er := getSessionKey(readCtx, sessionHeight, appAddrBz, relayChain, geoZone)
+	if er != nil {
+		return nil, er
+	}
 
-func (s *session) GetSessionHeight() int64 {
-	return s.height
-}
+	numServicers, err := readCtx.GetIntParam(typesUtil.ServicersPerSessionParamName, sessionHeight)
+	if err != nil {
+		return nil, typesUtil.ErrGetServicersPerSessionAt(sessionHeight, err)
+	}
+	allServicers, err := readCtx.GetAllServicers(sessionHeight)
+	if err != nil {
+		return nil, typesUtil.ErrGetAllServicers(err)
+	}
+	servicers, er := pseudoRandomSelection(key, filterSessionCandidates(allServicers, relayChain), numServicers)
+	if er != nil {
+		return nil, er
+	}
 
-func (s *session) GetRelayChain() RelayChain {
-	return s.relayChain
-}
+	allFishermen, err := readCtx.GetAllFishermen(sessionHeight)
+	if err != nil {
+		return nil, typesUtil.ErrGetAllFishermen(err)
+	}
+	fishermen, er := pseudoRandomSelection(key, filterSessionCandidates(allFishermen, relayChain), numFishermenPerSession)
+	if er != nil {
+		return nil, er
+	}
 
-func (s *session) GetGeoZone() GeoZone {
-	return s.geoZone
+	return &coreTypes.Session{
+		Id:               hex.EncodeToString(key),
+		SessionNumber:    sessionHeight / numSessionBlocks,
+		SessionHeight:    sessionHeight,
+		NumSessionBlocks: numSessionBlocks,
+		RelayChain:       relayChain,
+		GeoZone:          geoZone,
+		Application:      app,
+		Servicers:        servicers,
+		Fishermen:        fishermen,
+	}, nil
 }
 
-func (s *session) GetApplication() *coreTypes.Actor {
-	return s.application
+// getSessionBounds returns the height at which the session containing `height` started and its number of blocks, which
+// is the value of `blocks_per_session` at the height the session started, so a change of the parameter never changes
+// the running session. Sessions start at a multiple of their number of blocks: when the parameter changes during a
+// session, the session keeps running until it ends, which shortens the first session aligned on the new value.
+func getSessionBounds(readCtx modules.PersistenceReadContext, height int64) (sessionHeight, numSessionBlocks int64, err typesUtil.Error) {
+	sessionHeight, numSessionBlocks, err = getAlignedSessionBounds(readCtx, height)
+	if err != nil || sessionHeight == 0 {
+		return sessionHeight, numSessionBlocks, err
+	}
+	prevSessionHeight, prevNumSessionBlocks, err := getAlignedSessionBounds(readCtx, sessionHeight-1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if prevSessionHeight+prevNumSessionBlocks > height {
+		return prevSessionHeight, prevNumSessionBlocks, nil
+	}
+	return sessionHeight, numSessionBlocks, nil
 }
 
-func (s *session) GetFishermen() []*coreTypes.Actor {
-	return s.fishermen
+// getAlignedSessionBounds returns the last height up to `height` that is a multiple of the value of `blocks_per_session`
+// at that height, along with that value. The value at `height` is tried first, then the one at the candidate height
+// until both agree, i.e. until the parameter did not change between them.
+func getAlignedSessionBounds(readCtx modules.PersistenceReadContext, height int64) (sessionHeight, numSessionBlocks int64, err typesUtil.Error) {
+	numSessionBlocks, err = getBlocksPerSession(readCtx, height)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i := 0; i < maxSessionAlignmentAttempts; i++ {
+		sessionHeight = height - height%numSessionBlocks
+		numBlocksAtSessionHeight, err := getBlocksPerSession(readCtx, sessionHeight)
+		if err != nil {
+			return 0, 0, err
+		}
+		if numBlocksAtSessionHeight == numSessionBlocks {
+			return sessionHeight, numSessionBlocks, nil
+		}
+		numSessionBlocks = numBlocksAtSessionHeight
+	}
+	return 0, 0, typesUtil.ErrInvalidSession(fmt.Sprintf("no session start can be found for height %d", height))
 }
 
-func (s *session) GetServicers() []*coreTypes.Actor {
-	return s.servicers
+func getBlocksPerSession(readCtx modules.PersistenceReadContext, height int64) (int64, typesUtil.Error) {
+	blocksPerSession, err := readCtx.GetIntParam(typesUtil.BlocksPerSessionParamName, height)
+	if err != nil {
+		return 0, typesUtil.ErrGetParam(typesUtil.BlocksPerSessionParamName, err)
+	}
+	if blocksPerSession <= 0 {
+		return 0, typesUtil.ErrInvalidSession(fmt.Sprintf("%s is not positive: %d", typesUtil.BlocksPerSessionParamName, blocksPerSession))
+	}
+	return int64(blocksPerSession), nil
 }
 
-// use the seed information to determine a SHA3Hash that is used to find the closest N actors based
-// by comparing the sessionKey with the actors' public key
-func (s *session) getSessionId() ([]byte, types.Error) {
+// getSessionKey hashes the seed data of the session: `key = Hash(sessionHeight + blockHash + geoZone + relayChain + appAddress)`
+func getSessionKey(readCtx modules.PersistenceReadContext, sessionHeight int64, appAddrBz []byte, relayChain, geoZone string) ([]byte, typesUtil.Error) {
 	sessionHeightBz := make([]byte, 8)
-	binary.LittleEndian.PutUint64(sessionHeightBz, uint64(s.height))
+	binary.LittleEndian.PutUint64(sessionHeightBz, uint64(sessionHeight))
 
-	blockHashBz := []byte("get block hash bytes at s.sessionHeight from persistence module")
-
-	appPubKey, err := crypto.NewPublicKey(s.application.GetPublicKey())
+	blockHash, err := readCtx.GetBlockHash(sessionHeight)
+	if err != nil {
+		return nil, typesUtil.ErrGetBlockHash(err)
+	}
+	blockHashBz, err := hex.DecodeString(blockHash)
 	if err != nil {
-		return nil, types.ErrNewPublicKeyFromBytes(err)
+		return nil, typesUtil.ErrHexDecodeFromString(err)
 	}
 
-	return concat(sessionHeightBz, blockHashBz, []byte(s.geoZone), []byte(s.relayChain), appPubKey.Bytes()), nil
+	return crypto.SHA3Hash(concat(sessionHeightBz, blockHashBz, []byte(geoZone), []byte(relayChain), appAddrBz)), nil
 }
 
-// uses the current 'world state' to determine the servicers in the session
-// 1) get an ordered list of the public keys of servicers who are:
-//   - actively staked
-//   - staked within geo-zone (or closest geo-zones)
-//   - staked for relay-chain
-//
-// 2) calls `pseudoRandomSelection(servicers, numberOfNodesPerSession)`
-func (s *session) selectSessionServicers(numServicers int) ([]*coreTypes.Actor, types.Error) {
-	// IMPORTANT: This function is for behaviour illustrative purposes only and implementation may differ.
-	return nil, nil
+// filterSessionCandidates returns the actors that are actively staked (i.e. neither paused nor unstaking) for `relayChain`
+// TODO: Only keep the actors staked within the geo zone of the session (or the closest ones) once actors stake for one
+func filterSessionCandidates(actors []*coreTypes.Actor, relayChain string) []*coreTypes.Actor {
+	candidates := make([]*coreTypes.Actor, 0, len(actors))
+	for _, actor := range actors {
+		if isActiveForChain(actor, relayChain) {
+			candidates = append(candidates, actor)
+		}
+	}
+	return candidates
 }
 
-// uses the current 'world state' to determine the fishermen in the session
-// 1) get an ordered list of the public keys of fishermen who are:
-//   - actively staked
-//   - staked within geo-zone  (or closest geo-zones)
-//   - staked for relay-chain
-//
-// 2) calls `pseudoRandomSelection(fishermen, numberOfFishPerSession)`
-func (s *session) selectSessionFishermen(numFishermen int) ([]*coreTypes.Actor, types.Error) {
-	// IMPORTANT: This function is for behaviour illustrative purposes only and implementation may differ.
-	return nil, nil
+func isActiveForChain(actor *coreTypes.Actor, relayChain string) bool {
+	if actor.GetPausedHeight() != typesUtil.HeightNotUsed || actor.GetUnstakingHeight() != typesUtil.HeightNotUsed {
+		return false
+	}
+	for _, chain := range actor.GetChains() {
+		if chain == relayChain {
+			return true
+		}
+	}
+	return false
 }
 
-// 1) passed an ordered list of the public keys of actors and number of nodes
-// 2) pseudo-insert the session `key` string into the list and find the first actor directly below
+// 1) orders the candidates by public key
+// 2) pseudo-inserts the hex encoded session `key` into the list and selects the first actor directly above (wrapping around)
 // 3) newKey = Hash( key + actor1PublicKey )
-// 4) repeat steps 2 and 3 until all N actor are found
+// 4) repeats steps 2 and 3 until `numActorsToSelect` actors are selected or no candidate is left
 // FAQ:
 // Q) why do we hash to find a newKey between every actor selection?
 // A) pseudo-random selection only works if each iteration is re-randomized
 //
 //	or it would be subject to lexicographical proximity bias attacks
-//
-//nolint:unused // This is a demonstratable function
-func (s *session) pseudoRandomSelection(orderedListOfPublicKeys []string, numActorsToSelect int) []*coreTypes.Actor {
-	// IMPORTANT: This function is for behaviour illustrative purposes only and implementation may differ.
-	return nil
+func pseudoRandomSelection(key []byte, candidates []*coreTypes.Actor, numActorsToSelect int) ([]*coreTypes.Actor, typesUtil.Error) {
+	ordered := make([]*coreTypes.Actor, len(candidates))
+	copy(ordered, candidates)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].GetPublicKey() < ordered[j].GetPublicKey()
+	})
+
+	selected := make([]*coreTypes.Actor, 0, numActorsToSelect)
+	for len(selected) < numActorsToSelect && len(ordered) > 0 {
+		hexKey := hex.EncodeToString(key)
+		i := sort.Search(len(ordered), func(i int) bool {
+			return ordered[i].GetPublicKey() > hexKey
+		}) % len(ordered)
+
+		actor := ordered[i]
+		selected = append(selected, actor)
+		ordered = append(ordered[:i], ordered[i+1:]...)
+
+		publicKeyBz, err := hex.DecodeString(actor.GetPublicKey())
+		if err != nil {
+			return nil, typesUtil.ErrHexDecodeFromString(err)
+		}
+		key = crypto.SHA3Hash(concat(key, publicKeyBz))
+	}
+	return selected, nil
 }
 
 func concat(b ...[]byte) (result []byte) {
@@ -0,0 +1,167 @@
+package utility
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSessionRelayChain       = "0001"
+	testSessionBlocksPerSession = 4
+	testSessionBlockHash        = "a1b2c3d4"
+)
+
+func newTestSessionActor(t *testing.T, actorType coreTypes.ActorType, chains ...string) *coreTypes.Actor {
+	privateKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &coreTypes.Actor{
+		ActorType:       actorType,
+		Address:         privateKey.Address().String(),
+		PublicKey:       privateKey.PublicKey().String(),
+		Chains:          chains,
+		StakedAmount:    "1000",
+		PausedHeight:    typesUtil.HeightNotUsed,
+		UnstakingHeight: typesUtil.HeightNotUsed,
+	}
+}
+
+// Returns a read context whose world state at `sessionHeight` contains `app`, `servicers` and `fishermen`
+func newTestSessionReadContext(t *testing.T, sessionHeight int64, app *coreTypes.Actor, servicers, fishermen []*coreTypes.Actor, numServicers int) *mockModules.MockPersistenceReadContext {
+	ctrl := gomock.NewController(t)
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetIntParam(typesUtil.BlocksPerSessionParamName, gomock.Any()).Return(testSessionBlocksPerSession, nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(typesUtil.ServicersPerSessionParamName, sessionHeight).Return(numServicers, nil).AnyTimes()
+	appAddr, err := hex.DecodeString(app.GetAddress())
+	require.NoError(t, err)
+	readCtxMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_APP, appAddr, sessionHeight).Return(app, nil).AnyTimes()
+	readCtxMock.EXPECT().GetBlockHash(sessionHeight).Return(testSessionBlockHash, nil).AnyTimes()
+	readCtxMock.EXPECT().GetAllServicers(sessionHeight).Return(servicers, nil).AnyTimes()
+	readCtxMock.EXPECT().GetAllFishermen(sessionHeight).Return(fishermen, nil).AnyTimes()
+	return readCtxMock
+}
+
+func TestSession_NewSession(t *testing.T) {
+	app := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_APP, testSessionRelayChain)
+	var servicers []*coreTypes.Actor
+	for i := 0; i < 10; i++ {
+		servicers = append(servicers, newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_SERVICER, testSessionRelayChain))
+	}
+	otherChainServicer := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_SERVICER, "0002")
+	pausedServicer := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_SERVICER, testSessionRelayChain)
+	pausedServicer.PausedHeight = 2
+	fisherman := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_FISH, testSessionRelayChain)
+	allServicers := append([]*coreTypes.Actor{otherChainServicer, pausedServicer}, servicers...)
+
+	readCtx := newTestSessionReadContext(t, 4, app, allServicers, []*coreTypes.Actor{fisherman}, 3)
+	session, err := newSession(readCtx, app.GetAddress(), 6, testSessionRelayChain, "zone")
+	require.NoError(t, err)
+
+	require.Equal(t, int64(4), session.GetSessionHeight())
+	require.Equal(t, int64(1), session.GetSessionNumber())
+	require.Equal(t, int64(testSessionBlocksPerSession), session.GetNumSessionBlocks())
+	require.Equal(t, testSessionRelayChain, session.GetRelayChain())
+	require.Equal(t, "zone", session.GetGeoZone())
+	require.Equal(t, app, session.GetApplication())
+	require.Equal(t, []*coreTypes.Actor{fisherman}, session.GetFishermen())
+	require.Len(t, session.GetServicers(), 3)
+	selected := make(map[string]bool)
+	for _, servicer := range session.GetServicers() {
+		require.Contains(t, servicers, servicer, "only the actors actively staked for the relay chain are dispatched")
+		require.False(t, selected[servicer.GetAddress()], "an actor is dispatched at most once")
+		selected[servicer.GetAddress()] = true
+	}
+
+	// Every height of the session dispatches the same session, regardless of the order of the actors in the world state
+	reversedServicers := make([]*coreTypes.Actor, len(allServicers))
+	for i, servicer := range allServicers {
+		reversedServicers[len(allServicers)-1-i] = servicer
+	}
+	readCtx = newTestSessionReadContext(t, 4, app, reversedServicers, []*coreTypes.Actor{fisherman}, 3)
+	sameSession, err := newSession(readCtx, app.GetAddress(), 7, testSessionRelayChain, "zone")
+	require.NoError(t, err)
+	require.Equal(t, session, sameSession)
+
+	// Another geo zone changes the seed of the session
+	otherSession, err := newSession(readCtx, app.GetAddress(), 7, testSessionRelayChain, "other_zone")
+	require.NoError(t, err)
+	require.NotEqual(t, session.GetId(), otherSession.GetId())
+}
+
+func TestSession_NewSession_NotEnoughCandidates(t *testing.T) {
+	app := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_APP, testSessionRelayChain)
+	servicer := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_SERVICER, testSessionRelayChain)
+
+	readCtx := newTestSessionReadContext(t, 0, app, []*coreTypes.Actor{servicer}, nil, 24)
+	session, err := newSession(readCtx, app.GetAddress(), 0, testSessionRelayChain, "")
+	require.NoError(t, err)
+	require.Equal(t, []*coreTypes.Actor{servicer}, session.GetServicers())
+	require.Empty(t, session.GetFishermen())
+}
+
+func TestSession_NewSession_InvalidApplication(t *testing.T) {
+	otherChainApp := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_APP, "0002")
+	unstakingApp := newTestSessionActor(t, coreTypes.ActorType_ACTOR_TYPE_APP, testSessionRelayChain)
+	unstakingApp.UnstakingHeight = 10
+
+	for _, app := range []*coreTypes.Actor{otherChainApp, unstakingApp} {
+		readCtx := newTestSessionReadContext(t, 0, app, nil, nil, 1)
+		_, err := newSession(readCtx, app.GetAddress(), 1, testSessionRelayChain, "")
+		require.Equal(t, typesUtil.CodeInvalidSessionError, err.Code())
+	}
+
+	readCtx := newTestSessionReadContext(t, 0, otherChainApp, nil, nil, 1)
+	_, err := newSession(readCtx, otherChainApp.GetAddress(), 1, "", "")
+	require.Equal(t, typesUtil.CodeInvalidSessionError, err.Code())
+}
+
+func TestSession_GetSessionBounds_BlocksPerSessionChanged(t *testing.T) {
+	// `blocks_per_session` is 4 until height 9, where it grows to 6 during the session at height 8, then shrinks back
+	// to 4 at height 13, during the session at height 12
+	blocksPerSessionAt := func(height int64) int {
+		switch {
+		case height < 9:
+			return 4
+		case height < 13:
+			return 6
+		default:
+			return 4
+		}
+	}
+	ctrl := gomock.NewController(t)
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().GetIntParam(typesUtil.BlocksPerSessionParamName, gomock.Any()).DoAndReturn(func(_ string, height int64) (int, error) {
+		return blocksPerSessionAt(height), nil
+	}).AnyTimes()
+
+	testCases := []struct {
+		height                   int64
+		expectedSessionHeight    int64
+		expectedNumSessionBlocks int64
+	}{
+		{height: 7, expectedSessionHeight: 4, expectedNumSessionBlocks: 4},
+		{height: 8, expectedSessionHeight: 8, expectedNumSessionBlocks: 4},
+		// The session at height 8 keeps its length once the parameter changed
+		{height: 9, expectedSessionHeight: 8, expectedNumSessionBlocks: 4},
+		{height: 11, expectedSessionHeight: 8, expectedNumSessionBlocks: 4},
+		// The next session is aligned on the new value
+		{height: 12, expectedSessionHeight: 12, expectedNumSessionBlocks: 6},
+		{height: 13, expectedSessionHeight: 12, expectedNumSessionBlocks: 6},
+		{height: 17, expectedSessionHeight: 12, expectedNumSessionBlocks: 6},
+		// The session at height 16 only starts once the one at height 12 ended
+		{height: 18, expectedSessionHeight: 16, expectedNumSessionBlocks: 4},
+		{height: 20, expectedSessionHeight: 20, expectedNumSessionBlocks: 4},
+	}
+	for _, tc := range testCases {
+		sessionHeight, numSessionBlocks, err := getSessionBounds(readCtxMock, tc.height)
+		require.Nil(t, err)
+		require.Equal(t, tc.expectedSessionHeight, sessionHeight, "height %d", tc.height)
+		require.Equal(t, tc.expectedNumSessionBlocks, numSessionBlocks, "height %d", tc.height)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/messaging"
 	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/shared/utils"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
 
@@ -39,7 +41,57 @@ func (u *utilityModule) HandleTransaction(txProtoBytes []byte) error {
 	}
 
 	// Store the tx in the mempool
-	return u.mempool.AddTx(txProtoBytes)
+	if err := u.mempool.AddTx(txProtoBytes); err != nil {
+		return err
+	}
+
+	return u.publishMempoolTxAdmittedEvent(txProtoBytes)
+}
+
+// SimulateTransaction implements the exposed functionality of the shared utilityModule interface.
+func (u *utilityModule) SimulateTransaction(txProtoBytes []byte) (*coreTypes.TxSimulation, error) {
+	tx, err := coreTypes.TxFromBytes(txProtoBytes)
+	if err != nil {
+		return nil, typesUtil.ErrProtoUnmarshal(err)
+	}
+	if err := tx.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	readCtx, err := u.GetBus().GetPersistenceModule().NewReadContext(-1)
+	if err != nil {
+		return nil, typesUtil.ErrNewPersistenceContext(err)
+	}
+	defer readCtx.Close()
+	latestHeight, err := readCtx.GetMaximumBlockHeight()
+	if err != nil {
+		return nil, typesUtil.ErrGetHeight(err)
+	}
+
+	// The transaction is simulated as if it was included in the next block
+	ctx, er := u.newSimulationContext(int64(latestHeight) + 1)
+	if er != nil {
+		return nil, er
+	}
+	defer func() {
+		if err := ctx.Release(); err != nil {
+			u.logger.Error().Err(err).Msg("Error releasing the simulation context")
+		}
+	}()
+
+	return ctx.simulateTransaction(tx)
+}
+
+// publishMempoolTxAdmittedEvent publishes a mempool admission event to the bus so that other interested
+// IntegratableModules can react to it if necessary
+func (u *utilityModule) publishMempoolTxAdmittedEvent(txProtoBytes []byte) error {
+	mempoolTxAdmittedEvent, err := messaging.PackMessage(&messaging.MempoolTxAdmittedEvent{Tx: txProtoBytes})
+	if err != nil {
+		return err
+	}
+	// Gossiped transactions are handled by the bus' event loop, which would deadlock publishing to a full bus
+	go u.GetBus().PublishEventToBus(mempoolTxAdmittedEvent)
+	return nil
 }
 
 // hydrateTxResult converts a `Transaction` proto into a `TxResult` struct` after doing basic validation
@@ -57,6 +109,40 @@ func (u *utilityContext) hydrateTxResult(tx *coreTypes.Transaction, index int) (
 	return typesUtil.TxToTxResult(tx, u.height, index, msg, msgHandlingResult)
 }
 
+// simulateTransaction applies the transaction to the context the same way a block does, without indexing it, and
+// reports the changes it made to the state
+func (u *utilityContext) simulateTransaction(tx *coreTypes.Transaction) (*coreTypes.TxSimulation, error) {
+	simulation := &coreTypes.TxSimulation{Height: u.height}
+
+	// The fee is reported even if the signer cannot pay it
+	if anyMsg, err := tx.GetMessage(); err == nil {
+		if msg, ok := anyMsg.(typesUtil.Message); ok {
+			if fee, err := u.getFee(msg, msg.GetActorType()); err == nil {
+				simulation.Fee = utils.BigIntToString(fee)
+			}
+		}
+	}
+
+	// A transaction rejected by `anteHandleMessage` is left out of the block, so it does not change the state
+	msg, err := u.anteHandleMessage(tx)
+	if err != nil {
+		simulation.ResultCode, simulation.Error = int32(err.Code()), err.Error()
+		return simulation, nil
+	}
+
+	// A transaction whose message fails is still included in the block, so its signer still pays the fee
+	if err := u.handleMessage(msg); err != nil {
+		simulation.ResultCode, simulation.Error = int32(err.Code()), err.Error()
+	}
+
+	stateChanges, er := u.store.GetStateChanges()
+	if er != nil {
+		return nil, er
+	}
+	simulation.StateChanges = stateChanges
+	return simulation, nil
+}
+
 // anteHandleMessage handles basic validation of the message in the Transaction before it is processed
 // REFACTOR: Splitting this into a `feeValidation`, `signerValidation`, and `messageValidation` etc
 // would make it more modular and readable.
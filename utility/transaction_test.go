@@ -67,6 +67,42 @@ func TestUtilityContext_HandleTransaction(t *testing.T) {
 	require.Equal(t, testUtilityMod.HandleTransaction(txBz).Error(), typesUtil.ErrDuplicateTransaction().Error())
 }
 
+func TestUtilityContext_SimulateTransaction(t *testing.T) {
+	ctx := newTestingUtilityContext(t, 1)
+
+	tx, startingBalance, amount, signer := newTestingTransaction(t, ctx)
+	simulation, err := ctx.simulateTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), simulation.Height)
+	require.Equal(t, int32(0), simulation.ResultCode)
+	require.Empty(t, simulation.Error)
+	feeBig, er := ctx.getMessageSendFee()
+	require.NoError(t, er)
+	require.Equal(t, utils.BigIntToString(feeBig), simulation.Fee)
+
+	// The changes include the balances of the signer & recipient and the fee collected
+	expectedSignerBalance := new(big.Int).Sub(startingBalance, new(big.Int).Add(amount, feeBig))
+	signerAddr := signer.Address().String()
+	var signerAccount *coreTypes.Account
+	for _, account := range simulation.StateChanges.Accounts {
+		if account.Address == signerAddr {
+			signerAccount = account
+		}
+	}
+	require.NotNil(t, signerAccount)
+	require.Equal(t, utils.BigIntToString(expectedSignerBalance), signerAccount.Amount)
+	require.Len(t, simulation.StateChanges.Pools, 1)
+	require.Equal(t, coreTypes.Pools_POOLS_FEE_COLLECTOR.FriendlyName(), simulation.StateChanges.Pools[0].Address)
+
+	// A signer that cannot pay the fee is rejected before the message is handled
+	require.NoError(t, ctx.setAccountAmount(signer.Address(), big.NewInt(0)))
+	simulation, err = ctx.simulateTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, int32(typesUtil.CodeInsufficientAmountError), simulation.ResultCode)
+	require.Equal(t, utils.BigIntToString(feeBig), simulation.Fee)
+	require.Nil(t, simulation.StateChanges)
+}
+
 func TestUtilityContext_GetSignerCandidates(t *testing.T) {
 	ctx := newTestingUtilityContext(t, 0)
 	accs := getAllTestingAccounts(t, ctx)
@@ -2,6 +2,7 @@ package utility
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 
 	"github.com/pokt-network/pocket/shared/codec"
@@ -9,6 +10,7 @@ import (
 	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/utils"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"google.golang.org/protobuf/proto"
 )
 
 func (u *utilityContext) handleMessage(msg typesUtil.Message) (err typesUtil.Error) {
@@ -25,6 +27,14 @@ func (u *utilityContext) handleMessage(msg typesUtil.Message) (err typesUtil.Err
 		return u.handleUnpauseMessage(x)
 	case *typesUtil.MessageChangeParameter:
 		return u.handleMessageChangeParameter(x)
+	case *typesUtil.MessageTestScore:
+		return u.handleMessageTestScore(x)
+	case *typesUtil.MessageProveTestScore:
+		return u.handleMessageProveTestScore(x)
+	case *typesUtil.MessageFishermanPauseServicer:
+		return u.handleMessageFishermanPauseServicer(x)
+	case *typesUtil.MessageDoubleSign:
+		return u.handleMessageDoubleSign(x)
 	default:
 		return typesUtil.ErrUnknownMessage(x)
 	}
@@ -219,6 +229,172 @@ func (u *utilityContext) handleMessageChangeParameter(message *typesUtil.Message
 	return u.updateParam(message.ParameterKey, v)
 }
 
+// handleMessageTestScore sets the test score of a servicer of a session that ended, which is pending until the
+// fisherman proves it. A servicer is scored at most once per session height.
+func (u *utilityContext) handleMessageTestScore(message *typesUtil.MessageTestScore) typesUtil.Error {
+	header := message.SessionHeader
+	session, err := u.getFishermanSession(header, message.FishermanAddress, message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	if sessionEndHeight := header.SessionHeight + session.NumSessionBlocks; u.height < sessionEndHeight {
+		return typesUtil.ErrSessionNotEnded(sessionEndHeight)
+	}
+	reportCard, err := u.getReportCard(message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	if header.SessionHeight <= reportCard.LastSessionHeight {
+		return typesUtil.ErrInvalidTestScore("the servicer was already scored for a session at or after this one")
+	}
+	// A pending test score that was not proven is dropped in favour of the one of a later session
+	if pending := reportCard.PendingTestScore; pending != nil && header.SessionHeight <= pending.GetSessionHeader().GetSessionHeight() {
+		return typesUtil.ErrInvalidTestScore("a test score is already pending for a session at or after this one")
+	}
+	reportCard.PendingTestScore = newTestScore(message)
+	return u.setReportCard(reportCard)
+}
+
+// handleMessageProveTestScore applies the pending test score of a servicer to its report card, once the fisherman
+// who submitted it shows a relay of the session and the response the servicer signed for it
+func (u *utilityContext) handleMessageProveTestScore(message *typesUtil.MessageProveTestScore) typesUtil.Error {
+	header := message.SessionHeader
+	reportCard, err := u.getReportCard(message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	pending := reportCard.PendingTestScore
+	if pending == nil {
+		return typesUtil.ErrInvalidTestScore("no test score is pending")
+	}
+	if pending.FishermanAddress != hex.EncodeToString(message.FishermanAddress) {
+		return typesUtil.ErrInvalidTestScore("the test score is pending for another fisherman")
+	}
+	if !proto.Equal(pending.SessionHeader, header) {
+		return typesUtil.ErrInvalidTestScore("the test score is pending for another session")
+	}
+
+	servicer, er := u.store.GetActor(coreTypes.ActorType_ACTOR_TYPE_SERVICER, message.ServicerAddress, header.SessionHeight)
+	if er != nil {
+		return typesUtil.ErrGetExists(er)
+	}
+	if servicer == nil {
+		return typesUtil.ErrNotExists()
+	}
+	// The number of blocks of the session is the value of `blocks_per_session` when it started
+	_, numSessionBlocks, err := getSessionBounds(u.store, header.SessionHeight)
+	if err != nil {
+		return err
+	}
+	meta := message.Relay.Meta
+	if meta.ServicerPublicKey != servicer.PublicKey {
+		return typesUtil.ErrInvalidTestScore("the relay was not sent to the servicer")
+	}
+	if meta.RelayChain != header.RelayChain {
+		return typesUtil.ErrInvalidTestScore("the relay is for another relay chain")
+	}
+	if meta.BlockHeight < header.SessionHeight || meta.BlockHeight >= header.SessionHeight+numSessionBlocks {
+		return typesUtil.ErrInvalidTestScore("the relay is not for the session")
+	}
+	// The signature covers the hash of the relay, so the response cannot be replayed from another relay
+	relayHash, er := message.Relay.Hash()
+	if er != nil {
+		return typesUtil.ErrInvalidTestScore(fmt.Sprintf("the relay cannot be hashed: %s", er.Error()))
+	}
+	if message.Response.RequestHash != relayHash {
+		return typesUtil.ErrInvalidTestScore("the response does not answer the relay")
+	}
+	if er := message.Response.ValidateSignature(servicer.PublicKey); er != nil {
+		return typesUtil.ErrInvalidTestScore(fmt.Sprintf("the response is not signed by the servicer: %s", er.Error()))
+	}
+
+	applyTestScore(reportCard, pending)
+	return u.setReportCard(reportCard)
+}
+
+// handleMessageFishermanPauseServicer pauses a servicer of the current or the last session of the fisherman
+func (u *utilityContext) handleMessageFishermanPauseServicer(message *typesUtil.MessageFishermanPauseServicer) typesUtil.Error {
+	header := message.SessionHeader
+	// The session must have started in a committed block, and ended at most one session ago
+	if header.SessionHeight >= u.height {
+		return typesUtil.ErrInvalidSession(fmt.Sprintf("the session at height %d is not recent", header.SessionHeight))
+	}
+	session, err := u.getFishermanSession(header, message.FishermanAddress, message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	if u.height >= header.SessionHeight+2*session.NumSessionBlocks {
+		return typesUtil.ErrInvalidSession(fmt.Sprintf("the session at height %d is not recent", header.SessionHeight))
+	}
+	pausedHeight, err := u.getPausedHeightIfExists(coreTypes.ActorType_ACTOR_TYPE_SERVICER, message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	if pausedHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrAlreadyPaused()
+	}
+	return u.setActorPausedHeight(coreTypes.ActorType_ACTOR_TYPE_SERVICER, message.ServicerAddress, u.height)
+}
+
+// handleMessageDoubleSign burns the stake of a validator that signed two conflicting votes and pauses it. The evidence
+// must not be older than `validator_max_evidence_age_in_blocks` and a paused validator is not burnt again.
+func (u *utilityContext) handleMessageDoubleSign(message *typesUtil.MessageDoubleSign) typesUtil.Error {
+	voteHeight := int64(message.VoteA.Height)
+	if voteHeight > u.height {
+		return typesUtil.ErrInvalidBlockHeight()
+	}
+	maxEvidenceAge, err := u.getMaxEvidenceAgeInBlocks()
+	if err != nil {
+		return err
+	}
+	if u.height-voteHeight > int64(maxEvidenceAge) {
+		return typesUtil.ErrMaxEvidenceAge()
+	}
+
+	validatorAddr, er := hex.DecodeString(message.VoteA.GetPartialSignature().GetAddress())
+	if er != nil {
+		return typesUtil.ErrHexDecodeFromString(er)
+	}
+	validator, er := u.store.GetActor(coreTypes.ActorType_ACTOR_TYPE_VAL, validatorAddr, u.height)
+	if er != nil {
+		return typesUtil.ErrGetExists(er)
+	}
+	if validator == nil {
+		return typesUtil.ErrNotExists()
+	}
+	if err := validateVoteSignature(message.VoteA, validator.PublicKey); err != nil {
+		return err
+	}
+	if err := validateVoteSignature(message.VoteB, validator.PublicKey); err != nil {
+		return err
+	}
+	// The evidence is tracked by the height, round & step of the votes, since it is still within the max evidence
+	// age once the validator unpauses if `validator_minimum_pause_blocks` is lower than it
+	voteRound, voteStep := message.VoteA.GetRound(), int32(message.VoteA.GetStep())
+	handled, er := u.store.GetDoubleSignEvidenceExists(validatorAddr, uint64(voteHeight), voteRound, voteStep, u.height)
+	if er != nil {
+		return typesUtil.ErrDoubleSignEvidence(er)
+	}
+	if handled {
+		return typesUtil.ErrDuplicateDoubleSignEvidence()
+	}
+	if validator.PausedHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrAlreadyPaused()
+	}
+
+	burnPercent, err := u.getDoubleSignBurnPercentage()
+	if err != nil {
+		return err
+	}
+	if err := u.burnValidator(validatorAddr, burnPercent); err != nil {
+		return err
+	}
+	if er := u.store.SetDoubleSignEvidence(validatorAddr, uint64(voteHeight), voteRound, voteStep); er != nil {
+		return typesUtil.ErrDoubleSignEvidence(er)
+	}
+	return u.setActorPausedHeight(coreTypes.ActorType_ACTOR_TYPE_VAL, validatorAddr, u.height)
+}
+
 // REFACTOR: This can be moved over into utility/types/message.go
 func (u *utilityContext) getSignerCandidates(msg typesUtil.Message) ([][]byte, typesUtil.Error) {
 	switch x := msg.(type) {
@@ -232,6 +408,8 @@ func (u *utilityContext) getSignerCandidates(msg typesUtil.Message) ([][]byte, t
 		return u.getMessageUnpauseSignerCandidates(x)
 	case *typesUtil.MessageChangeParameter:
 		return u.getMessageChangeParameterSignerCandidates(x)
+	case *typesUtil.MessageTestScore, *typesUtil.MessageProveTestScore, *typesUtil.MessageFishermanPauseServicer, *typesUtil.MessageDoubleSign:
+		return [][]byte{x.GetSigner()}, nil
 	default:
 		return nil, typesUtil.ErrUnknownMessage(x)
 	}
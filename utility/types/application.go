@@ -0,0 +1,9 @@
+package types
+
+import "math/big"
+
+// CalculateAppSessionTokens returns the number of "session tokens" an application staking `appStake` gets at the
+// beginning of every session, 1 session token equating to a quota of 1 relay.
+func CalculateAppSessionTokens(appStake *big.Int, stakeToSessionTokensMultiplier int) *big.Int {
+	return new(big.Int).Mul(appStake, big.NewInt(int64(stakeToSessionTokensMultiplier)))
+}
@@ -40,7 +40,7 @@ func NewError(code Code, msg string) Error {
 	}
 }
 
-// NextCode: 133
+// NextCode: 150
 type Code float64 // CONSIDERATION: Should these be a proto enum or a golang iota?
 
 //nolint:gosec // G101 - Not hard-coded credentials
@@ -174,6 +174,23 @@ const (
 	CodeGetHeightError                    Code = 129
 	CodeUnknownActorType                  Code = 130
 	CodeUnknownMessageType                Code = 131
+	CodeServicerDisabledError             Code = 133
+	CodeInvalidRelayError                 Code = 134
+	CodeUnsupportedRelayChainError        Code = 135
+	CodeInvalidRelaySessionHeightError    Code = 136
+	CodeInvalidAATError                   Code = 137
+	CodeServicerNotInSessionError         Code = 138
+	CodeAppOverServicedError              Code = 139
+	CodeStoreRelayError                   Code = 140
+	CodeRelayExecutionError               Code = 141
+	CodeInvalidSessionError               Code = 142
+	CodeSessionNotEndedError              Code = 143
+	CodeRelayEvidenceError                Code = 144
+	CodeInvalidTestScoreError             Code = 145
+	CodeReportCardError                   Code = 146
+	CodeFishermanNotInSessionError        Code = 147
+	CodeDuplicateDoubleSignEvidenceError  Code = 148
+	CodeDoubleSignEvidenceError           Code = 149
 )
 
 const (
@@ -304,6 +321,23 @@ const (
 	NegativeAmountError               = "the amount is negative"
 	UnknownActorTypeError             = "the actor type is not recognized"
 	UnknownMessageTypeError           = "the message being by the utility message is not recognized"
+	ServicerDisabledError             = "the node does not service relays"
+	InvalidRelayError                 = "the relay is not valid"
+	UnsupportedRelayChainError        = "the relay chain is not supported by the servicer"
+	InvalidRelaySessionHeightError    = "the relay is not for the current session"
+	InvalidAATError                   = "the application authentication token is not valid"
+	ServicerNotInSessionError         = "the servicer is not in the session of the relay"
+	AppOverServicedError              = "the application has used all of its session tokens"
+	StoreRelayError                   = "an error occurred storing the relay"
+	RelayExecutionError               = "an error occurred executing the relay against the relay chain"
+	InvalidSessionError               = "the session cannot be dispatched"
+	SessionNotEndedError              = "the session has not ended yet"
+	RelayEvidenceError                = "an error occurred accessing the relays stored as evidence of the session"
+	InvalidTestScoreError             = "the test score is not valid"
+	ReportCardError                   = "an error occurred accessing the report card of the servicer"
+	FishermanNotInSessionError        = "the fisherman is not in the session"
+	DuplicateDoubleSignEvidenceError  = "the double sign evidence was already handled"
+	DoubleSignEvidenceError           = "an error occurred accessing the double sign evidence"
 )
 
 func ErrUnknownParam(paramName string) Error {
@@ -819,3 +853,71 @@ func ErrUnknownActorType(actorType string) Error {
 func ErrUnknownMessageType(messageType any) Error {
 	return NewError(CodeUnknownMessageType, fmt.Sprintf("%s: %v", UnknownMessageTypeError, messageType))
 }
+
+func ErrServicerDisabled() Error {
+	return NewError(CodeServicerDisabledError, ServicerDisabledError)
+}
+
+func ErrInvalidRelay(reason string) Error {
+	return NewError(CodeInvalidRelayError, fmt.Sprintf("%s: %s", InvalidRelayError, reason))
+}
+
+func ErrUnsupportedRelayChain(relayChain string) Error {
+	return NewError(CodeUnsupportedRelayChainError, fmt.Sprintf("%s: %s", UnsupportedRelayChainError, relayChain))
+}
+
+func ErrInvalidRelaySessionHeight(relaySessionHeight, currentSessionHeight int64) Error {
+	return NewError(CodeInvalidRelaySessionHeightError, fmt.Sprintf("%s: the relay session starts at height %d, the current one at height %d", InvalidRelaySessionHeightError, relaySessionHeight, currentSessionHeight))
+}
+
+func ErrInvalidAAT(err error) Error {
+	return NewError(CodeInvalidAATError, fmt.Sprintf("%s: %s", InvalidAATError, err.Error()))
+}
+
+func ErrServicerNotInSession() Error {
+	return NewError(CodeServicerNotInSessionError, ServicerNotInSessionError)
+}
+
+func ErrAppOverServiced(sessionTokens string) Error {
+	return NewError(CodeAppOverServicedError, fmt.Sprintf("%s: %s", AppOverServicedError, sessionTokens))
+}
+
+func ErrStoreRelay(err error) Error {
+	return NewError(CodeStoreRelayError, fmt.Sprintf("%s: %s", StoreRelayError, err.Error()))
+}
+
+func ErrRelayExecution(err error) Error {
+	return NewError(CodeRelayExecutionError, fmt.Sprintf("%s: %s", RelayExecutionError, err.Error()))
+}
+
+func ErrInvalidSession(reason string) Error {
+	return NewError(CodeInvalidSessionError, fmt.Sprintf("%s: %s", InvalidSessionError, reason))
+}
+
+func ErrSessionNotEnded(sessionEndHeight int64) Error {
+	return NewError(CodeSessionNotEndedError, fmt.Sprintf("%s: it ends at height %d", SessionNotEndedError, sessionEndHeight))
+}
+
+func ErrRelayEvidence(err error) Error {
+	return NewError(CodeRelayEvidenceError, fmt.Sprintf("%s: %s", RelayEvidenceError, err.Error()))
+}
+
+func ErrInvalidTestScore(reason string) Error {
+	return NewError(CodeInvalidTestScoreError, fmt.Sprintf("%s: %s", InvalidTestScoreError, reason))
+}
+
+func ErrReportCard(err error) Error {
+	return NewError(CodeReportCardError, fmt.Sprintf("%s: %s", ReportCardError, err.Error()))
+}
+
+func ErrFishermanNotInSession() Error {
+	return NewError(CodeFishermanNotInSessionError, FishermanNotInSessionError)
+}
+
+func ErrDuplicateDoubleSignEvidence() Error {
+	return NewError(CodeDuplicateDoubleSignEvidenceError, DuplicateDoubleSignEvidenceError)
+}
+
+func ErrDoubleSignEvidence(err error) Error {
+	return NewError(CodeDoubleSignEvidenceError, fmt.Sprintf("%s: %s", DoubleSignEvidenceError, err.Error()))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"log"
 
+	typesCons "github.com/pokt-network/pocket/consensus/types"
 	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	cryptoPocket "github.com/pokt-network/pocket/shared/crypto"
@@ -34,6 +35,10 @@ var (
 	_ Message = &MessageUnstake{}
 	_ Message = &MessageUnpause{}
 	_ Message = &MessageChangeParameter{}
+	_ Message = &MessageTestScore{}
+	_ Message = &MessageProveTestScore{}
+	_ Message = &MessageFishermanPauseServicer{}
+	_ Message = &MessageDoubleSign{}
 )
 
 func (msg *MessageSend) ValidateBasic() Error {
@@ -81,29 +86,96 @@ func (msg *MessageChangeParameter) ValidateBasic() Error {
 	}
 	return nil
 }
+func (msg *MessageTestScore) ValidateBasic() Error {
+	if err := validateFishermanMessage(msg); err != nil {
+		return err
+	}
+	if len(msg.Samples) == 0 {
+		return ErrInvalidTestScore("no samples")
+	}
+	return nil
+}
+func (msg *MessageProveTestScore) ValidateBasic() Error {
+	if err := validateFishermanMessage(msg); err != nil {
+		return err
+	}
+	if msg.Relay.GetMeta() == nil || msg.Relay.GetPayload() == nil {
+		return ErrInvalidTestScore("the relay is incomplete")
+	}
+	if msg.Response.GetServicerSignature() == "" {
+		return ErrInvalidTestScore("the relay response is not signed")
+	}
+	if msg.Response.GetRequestHash() == "" {
+		return ErrInvalidTestScore("the relay response does not reference its relay")
+	}
+	return nil
+}
+func (msg *MessageFishermanPauseServicer) ValidateBasic() Error {
+	return validateFishermanMessage(msg)
+}
+func (msg *MessageDoubleSign) ValidateBasic() Error {
+	if err := validateVote(msg.VoteA); err != nil {
+		return err
+	}
+	if err := validateVote(msg.VoteB); err != nil {
+		return err
+	}
+	if msg.VoteA.GetPartialSignature().GetAddress() != msg.VoteB.GetPartialSignature().GetAddress() {
+		return ErrUnequalPublicKeys()
+	}
+	if msg.VoteA.GetHeight() != msg.VoteB.GetHeight() {
+		return ErrUnequalHeights()
+	}
+	if msg.VoteA.GetRound() != msg.VoteB.GetRound() {
+		return ErrUnequalRounds()
+	}
+	if msg.VoteA.GetStep() != msg.VoteB.GetStep() {
+		return ErrUnequalVoteTypes()
+	}
+	if !msg.VoteA.ConflictsWith(msg.VoteB) {
+		return ErrEqualVotes()
+	}
+	return validateAddress(msg.ReporterAddress)
+}
 
-func (msg *MessageSend) SetSigner(signer []byte)            { /* no-op */ }
-func (msg *MessageStake) SetSigner(signer []byte)           { msg.Signer = signer }
-func (msg *MessageEditStake) SetSigner(signer []byte)       { msg.Signer = signer }
-func (msg *MessageUnstake) SetSigner(signer []byte)         { msg.Signer = signer }
-func (msg *MessageUnpause) SetSigner(signer []byte)         { msg.Signer = signer }
-func (msg *MessageChangeParameter) SetSigner(signer []byte) { msg.Signer = signer }
+func (msg *MessageSend) SetSigner(signer []byte)                   { /* no-op */ }
+func (msg *MessageStake) SetSigner(signer []byte)                  { msg.Signer = signer }
+func (msg *MessageEditStake) SetSigner(signer []byte)              { msg.Signer = signer }
+func (msg *MessageUnstake) SetSigner(signer []byte)                { msg.Signer = signer }
+func (msg *MessageUnpause) SetSigner(signer []byte)                { msg.Signer = signer }
+func (msg *MessageChangeParameter) SetSigner(signer []byte)        { msg.Signer = signer }
+func (msg *MessageTestScore) SetSigner(signer []byte)              { /* no-op */ }
+func (msg *MessageProveTestScore) SetSigner(signer []byte)         { /* no-op */ }
+func (msg *MessageFishermanPauseServicer) SetSigner(signer []byte) { /* no-op */ }
+func (msg *MessageDoubleSign) SetSigner(signer []byte)             { /* no-op */ }
 
-func (msg *MessageSend) GetMessageName() string            { return getMessageType(msg) }
-func (msg *MessageStake) GetMessageName() string           { return getMessageType(msg) }
-func (msg *MessageEditStake) GetMessageName() string       { return getMessageType(msg) }
-func (msg *MessageUnstake) GetMessageName() string         { return getMessageType(msg) }
-func (msg *MessageUnpause) GetMessageName() string         { return getMessageType(msg) }
-func (msg *MessageChangeParameter) GetMessageName() string { return getMessageType(msg) }
+func (msg *MessageSend) GetMessageName() string                   { return getMessageType(msg) }
+func (msg *MessageStake) GetMessageName() string                  { return getMessageType(msg) }
+func (msg *MessageEditStake) GetMessageName() string              { return getMessageType(msg) }
+func (msg *MessageUnstake) GetMessageName() string                { return getMessageType(msg) }
+func (msg *MessageUnpause) GetMessageName() string                { return getMessageType(msg) }
+func (msg *MessageChangeParameter) GetMessageName() string        { return getMessageType(msg) }
+func (msg *MessageTestScore) GetMessageName() string              { return getMessageType(msg) }
+func (msg *MessageProveTestScore) GetMessageName() string         { return getMessageType(msg) }
+func (msg *MessageFishermanPauseServicer) GetMessageName() string { return getMessageType(msg) }
+func (msg *MessageDoubleSign) GetMessageName() string             { return getMessageType(msg) }
 
-func (msg *MessageSend) GetMessageRecipient() string            { return hex.EncodeToString(msg.ToAddress) }
-func (msg *MessageStake) GetMessageRecipient() string           { return "" }
-func (msg *MessageEditStake) GetMessageRecipient() string       { return "" }
-func (msg *MessageUnstake) GetMessageRecipient() string         { return "" }
-func (msg *MessageUnpause) GetMessageRecipient() string         { return "" }
-func (msg *MessageChangeParameter) GetMessageRecipient() string { return "" }
+func (msg *MessageSend) GetMessageRecipient() string                   { return hex.EncodeToString(msg.ToAddress) }
+func (msg *MessageStake) GetMessageRecipient() string                  { return "" }
+func (msg *MessageEditStake) GetMessageRecipient() string              { return "" }
+func (msg *MessageUnstake) GetMessageRecipient() string                { return "" }
+func (msg *MessageUnpause) GetMessageRecipient() string                { return "" }
+func (msg *MessageChangeParameter) GetMessageRecipient() string        { return "" }
+func (msg *MessageTestScore) GetMessageRecipient() string              { return "" }
+func (msg *MessageProveTestScore) GetMessageRecipient() string         { return "" }
+func (msg *MessageFishermanPauseServicer) GetMessageRecipient() string { return "" }
+func (msg *MessageDoubleSign) GetMessageRecipient() string             { return "" }
 
-func (msg *MessageSend) GetSigner() []byte { return msg.FromAddress }
+func (msg *MessageSend) GetSigner() []byte                   { return msg.FromAddress }
+func (msg *MessageTestScore) GetSigner() []byte              { return msg.FishermanAddress }
+func (msg *MessageProveTestScore) GetSigner() []byte         { return msg.FishermanAddress }
+func (msg *MessageFishermanPauseServicer) GetSigner() []byte { return msg.FishermanAddress }
+func (msg *MessageDoubleSign) GetSigner() []byte             { return msg.ReporterAddress }
 
 func (msg *MessageSend) GetActorType() coreTypes.ActorType {
 	return coreTypes.ActorType_ACTOR_TYPE_UNSPECIFIED // there's no actor type for message send, so return zero to allow fee retrieval
@@ -111,13 +183,29 @@ func (msg *MessageSend) GetActorType() coreTypes.ActorType {
 func (msg *MessageChangeParameter) GetActorType() coreTypes.ActorType {
 	return -1 // CONSIDERATION: Should we create an actor for the DAO or ACLed addresses?
 }
+func (msg *MessageTestScore) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
+func (msg *MessageProveTestScore) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
+func (msg *MessageFishermanPauseServicer) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
+func (msg *MessageDoubleSign) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_VAL
+}
 
-func (msg *MessageSend) GetCanonicalBytes() []byte            { return getCanonicalBytes(msg) }
-func (msg *MessageStake) GetCanonicalBytes() []byte           { return getCanonicalBytes(msg) }
-func (msg *MessageEditStake) GetCanonicalBytes() []byte       { return getCanonicalBytes(msg) }
-func (msg *MessageUnstake) GetCanonicalBytes() []byte         { return getCanonicalBytes(msg) }
-func (msg *MessageUnpause) GetCanonicalBytes() []byte         { return getCanonicalBytes(msg) }
-func (msg *MessageChangeParameter) GetCanonicalBytes() []byte { return getCanonicalBytes(msg) }
+func (msg *MessageSend) GetCanonicalBytes() []byte                   { return getCanonicalBytes(msg) }
+func (msg *MessageStake) GetCanonicalBytes() []byte                  { return getCanonicalBytes(msg) }
+func (msg *MessageEditStake) GetCanonicalBytes() []byte              { return getCanonicalBytes(msg) }
+func (msg *MessageUnstake) GetCanonicalBytes() []byte                { return getCanonicalBytes(msg) }
+func (msg *MessageUnpause) GetCanonicalBytes() []byte                { return getCanonicalBytes(msg) }
+func (msg *MessageChangeParameter) GetCanonicalBytes() []byte        { return getCanonicalBytes(msg) }
+func (msg *MessageTestScore) GetCanonicalBytes() []byte              { return getCanonicalBytes(msg) }
+func (msg *MessageProveTestScore) GetCanonicalBytes() []byte         { return getCanonicalBytes(msg) }
+func (msg *MessageFishermanPauseServicer) GetCanonicalBytes() []byte { return getCanonicalBytes(msg) }
+func (msg *MessageDoubleSign) GetCanonicalBytes() []byte             { return getCanonicalBytes(msg) }
 
 // Helpers
 
@@ -184,6 +272,60 @@ func validateRelayChains(chains []string) Error {
 	return nil
 }
 
+// This interface is useful in validating the messages a fisherman signs about a servicer of one of its sessions and
+// is not intended to be used outside of this package
+type fishermanMessage interface {
+	GetSessionHeader() *coreTypes.SessionHeader
+	GetFishermanAddress() []byte
+	GetServicerAddress() []byte
+}
+
+func validateFishermanMessage(msg fishermanMessage) Error {
+	if err := validateSessionHeader(msg.GetSessionHeader()); err != nil {
+		return err
+	}
+	if err := validateAddress(msg.GetFishermanAddress()); err != nil {
+		return err
+	}
+	return validateAddress(msg.GetServicerAddress())
+}
+
+func validateSessionHeader(header *coreTypes.SessionHeader) Error {
+	if header == nil {
+		return ErrInvalidSession("empty session header")
+	}
+	appPubKey, err := hex.DecodeString(header.ApplicationPublicKey)
+	if err != nil {
+		return ErrHexDecodeFromString(err)
+	}
+	if err := validatePublicKey(appPubKey); err != nil {
+		return err
+	}
+	if err := relayChain(header.RelayChain).ValidateBasic(); err != nil {
+		return err
+	}
+	if header.SessionHeight < 0 {
+		return ErrInvalidSession("negative session height")
+	}
+	return nil
+}
+
+// validateVote validates a hotstuff vote submitted as evidence of a validator double signing
+func validateVote(vote *typesCons.HotstuffMessage) Error {
+	if vote.GetType() != typesCons.HotstuffMessageType_HOTSTUFF_MESSAGE_VOTE {
+		return ErrInvalidEvidenceType()
+	}
+	partialSig := vote.GetPartialSignature()
+	if len(partialSig.GetSignature()) == 0 {
+		return ErrEmptySignature()
+	}
+	validatorAddr, err := hex.DecodeString(partialSig.GetAddress())
+	if err != nil {
+		return ErrHexDecodeFromString(err)
+	}
+	return validateAddress(validatorAddr)
+}
+
 func getMessageType(msg Message) string {
 	return string(msg.ProtoReflect().Descriptor().Name())
 }
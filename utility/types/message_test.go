@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	typesCons "github.com/pokt-network/pocket/consensus/types"
 	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
@@ -200,3 +201,163 @@ func TestMessage_Unpause_ValidateBasic(t *testing.T) {
 	er = msgMissingAddress.ValidateBasic()
 	require.Equal(t, ErrEmptyAddress().Code(), er.Code())
 }
+
+func newTestSessionHeader(t *testing.T) *coreTypes.SessionHeader {
+	appPubKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	return &coreTypes.SessionHeader{
+		ApplicationPublicKey: appPubKey.String(),
+		RelayChain:           defaultTestingChains[0],
+		SessionHeight:        4,
+	}
+}
+
+func TestMessage_TestScore_ValidateBasic(t *testing.T) {
+	fisherman, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	servicer, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	msg := MessageTestScore{
+		SessionHeader:    newTestSessionHeader(t),
+		FishermanAddress: fisherman,
+		ServicerAddress:  servicer,
+		Samples:          []*TestScoreSample{{LatencyMsec: 100, Success: true}},
+	}
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+	require.Equal(t, []byte(fisherman), msg.GetSigner())
+
+	msgMissingHeader := proto.Clone(&msg).(*MessageTestScore)
+	msgMissingHeader.SessionHeader = nil
+	require.Equal(t, CodeInvalidSessionError, msgMissingHeader.ValidateBasic().Code())
+
+	msgInvalidRelayChain := proto.Clone(&msg).(*MessageTestScore)
+	msgInvalidRelayChain.SessionHeader.RelayChain = "1"
+	require.Equal(t, CodeInvalidRelayChainLengthError, msgInvalidRelayChain.ValidateBasic().Code())
+
+	msgMissingServicer := proto.Clone(&msg).(*MessageTestScore)
+	msgMissingServicer.ServicerAddress = nil
+	require.Equal(t, ErrEmptyAddress().Code(), msgMissingServicer.ValidateBasic().Code())
+
+	msgMissingSamples := proto.Clone(&msg).(*MessageTestScore)
+	msgMissingSamples.Samples = nil
+	require.Equal(t, CodeInvalidTestScoreError, msgMissingSamples.ValidateBasic().Code())
+}
+
+func TestMessage_ProveTestScore_ValidateBasic(t *testing.T) {
+	fisherman, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	servicer, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	msg := MessageProveTestScore{
+		SessionHeader:    newTestSessionHeader(t),
+		FishermanAddress: fisherman,
+		ServicerAddress:  servicer,
+		Relay: &coreTypes.Relay{
+			Payload: &coreTypes.RelayPayload{Data: "{}"},
+			Meta:    &coreTypes.RelayMeta{BlockHeight: 5, RelayChain: defaultTestingChains[0]},
+		},
+		Response: &coreTypes.RelayResponse{Payload: "{}", ServicerSignature: "abcd", RequestHash: "ef01"},
+	}
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msgMissingFisherman := proto.Clone(&msg).(*MessageProveTestScore)
+	msgMissingFisherman.FishermanAddress = nil
+	require.Equal(t, ErrEmptyAddress().Code(), msgMissingFisherman.ValidateBasic().Code())
+
+	msgMissingRelayMeta := proto.Clone(&msg).(*MessageProveTestScore)
+	msgMissingRelayMeta.Relay.Meta = nil
+	require.Equal(t, CodeInvalidTestScoreError, msgMissingRelayMeta.ValidateBasic().Code())
+
+	msgUnsignedResponse := proto.Clone(&msg).(*MessageProveTestScore)
+	msgUnsignedResponse.Response.ServicerSignature = ""
+	require.Equal(t, CodeInvalidTestScoreError, msgUnsignedResponse.ValidateBasic().Code())
+
+	msgUnboundResponse := proto.Clone(&msg).(*MessageProveTestScore)
+	msgUnboundResponse.Response.RequestHash = ""
+	require.Equal(t, CodeInvalidTestScoreError, msgUnboundResponse.ValidateBasic().Code())
+}
+
+func TestMessage_FishermanPauseServicer_ValidateBasic(t *testing.T) {
+	fisherman, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	servicer, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	msg := MessageFishermanPauseServicer{
+		SessionHeader:    newTestSessionHeader(t),
+		FishermanAddress: fisherman,
+		ServicerAddress:  servicer,
+	}
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msgInvalidAppPubKey := proto.Clone(&msg).(*MessageFishermanPauseServicer)
+	msgInvalidAppPubKey.SessionHeader.ApplicationPublicKey = "abcd"
+	require.Equal(t, CodeInvalidPublicKeyLenError, msgInvalidAppPubKey.ValidateBasic().Code())
+
+	msgNegativeHeight := proto.Clone(&msg).(*MessageFishermanPauseServicer)
+	msgNegativeHeight.SessionHeader.SessionHeight = -1
+	require.Equal(t, CodeInvalidSessionError, msgNegativeHeight.ValidateBasic().Code())
+}
+
+func TestMessage_DoubleSign_ValidateBasic(t *testing.T) {
+	validator, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	reporter, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	newVote := func(stateHash string) *typesCons.HotstuffMessage {
+		return &typesCons.HotstuffMessage{
+			Type:   typesCons.HotstuffMessageType_HOTSTUFF_MESSAGE_VOTE,
+			Height: 1,
+			Step:   typesCons.HotstuffStep_HOTSTUFF_STEP_PREPARE,
+			Round:  1,
+			Block:  &coreTypes.Block{BlockHeader: &coreTypes.BlockHeader{Height: 1, StateHash: stateHash}},
+			Justification: &typesCons.HotstuffMessage_PartialSignature{
+				PartialSignature: &typesCons.PartialSignature{
+					Signature: []byte("signature"),
+					Address:   validator.String(),
+				},
+			},
+		}
+	}
+	msg := MessageDoubleSign{
+		VoteA:           newVote("a"),
+		VoteB:           newVote("b"),
+		ReporterAddress: reporter,
+	}
+	err = msg.ValidateBasic()
+	require.NoError(t, err)
+
+	msgEqualVotes := proto.Clone(&msg).(*MessageDoubleSign)
+	msgEqualVotes.VoteB.Block.BlockHeader.StateHash = "a"
+	require.Equal(t, CodeEqualVotesError, msgEqualVotes.ValidateBasic().Code())
+
+	msgUnequalHeights := proto.Clone(&msg).(*MessageDoubleSign)
+	msgUnequalHeights.VoteB.Height = 2
+	require.Equal(t, CodeUnequalHeightsError, msgUnequalHeights.ValidateBasic().Code())
+
+	msgUnequalRounds := proto.Clone(&msg).(*MessageDoubleSign)
+	msgUnequalRounds.VoteB.Round = 2
+	require.Equal(t, CodeUnequalRoundsError, msgUnequalRounds.ValidateBasic().Code())
+
+	msgUnequalSteps := proto.Clone(&msg).(*MessageDoubleSign)
+	msgUnequalSteps.VoteB.Step = typesCons.HotstuffStep_HOTSTUFF_STEP_COMMIT
+	require.Equal(t, CodeUnequalVoteTypesError, msgUnequalSteps.ValidateBasic().Code())
+
+	msgOtherValidator := proto.Clone(&msg).(*MessageDoubleSign)
+	msgOtherValidator.VoteB.GetPartialSignature().Address = reporter.String()
+	require.Equal(t, CodeUnequalPublicKeysError, msgOtherValidator.ValidateBasic().Code())
+
+	msgProposal := proto.Clone(&msg).(*MessageDoubleSign)
+	msgProposal.VoteA.Type = typesCons.HotstuffMessageType_HOTSTUFF_MESSAGE_PROPOSE
+	require.Equal(t, CodeInvalidEvidenceTypeError, msgProposal.ValidateBasic().Code())
+
+	msgUnsignedVote := proto.Clone(&msg).(*MessageDoubleSign)
+	msgUnsignedVote.VoteA.GetPartialSignature().Signature = nil
+	require.Equal(t, CodeEmptySignatureError, msgUnsignedVote.ValidateBasic().Code())
+}
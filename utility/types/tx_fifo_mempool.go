@@ -60,6 +60,20 @@ func (t *txFIFOMempool) RemoveTx(tx []byte) error {
 	return nil
 }
 
+// GetTx returns the tx with the given hash, or nil if it is not in the mempool
+func (t *txFIFOMempool) GetTx(hash string) []byte {
+	tx, ok := t.g.Get(hash)
+	if !ok {
+		return nil
+	}
+	return tx
+}
+
+// Range iterates over the txs in the mempool, from the oldest to the newest, until `fn` returns false
+func (t *txFIFOMempool) Range(fn func(tx []byte) bool) {
+	t.g.Range(fn)
+}
+
 // TxCount returns the number of txs in the mempool
 func (t *txFIFOMempool) TxCount() uint32 {
 	t.m.Lock()
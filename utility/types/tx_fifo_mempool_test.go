@@ -249,6 +249,71 @@ func TestTxFIFOMempool(t *testing.T) {
 				messageSendFactory(10),
 			},
 		},
+		{
+			name: "GetTx should return a transaction that is in the mempool and nil viceversa",
+			args: args{
+				maxTxBytes: 1000,
+				maxTxs:     100,
+				initialTxs: &[][]byte{
+					messageSendFactory(9),
+					messageSendFactory(10),
+				},
+				actions: &[]func(*txFIFOMempool){
+					func(txFIFOMempool *txFIFOMempool) {
+						txHashOK := crypto.GetHashStringFromBytes(messageSendFactory(10))
+						require.Equal(t, messageSendFactory(10), txFIFOMempool.GetTx(txHashOK), "mismatching GetTx")
+						txHashKO := crypto.GetHashStringFromBytes(messageSendFactory(19))
+						require.Nil(t, txFIFOMempool.GetTx(txHashKO), "mismatching GetTx")
+					},
+				},
+			},
+			wantItems: [][]byte{
+				messageSendFactory(9),
+				messageSendFactory(10),
+			},
+		},
+		{
+			name: "Range should iterate over the transactions in the order they are popped until told to stop",
+			args: args{
+				maxTxBytes: 1000,
+				maxTxs:     100,
+				initialTxs: &[][]byte{
+					messageSendFactory(9),
+					messageSendFactory(10),
+					messageSendFactory(8),
+				},
+				actions: &[]func(*txFIFOMempool){
+					func(txFIFOMempool *txFIFOMempool) {
+						gotItems := make([][]byte, 0)
+						txFIFOMempool.Range(func(tx []byte) bool {
+							gotItems = append(gotItems, tx)
+							return len(gotItems) < 2
+						})
+						require.Equal(t, [][]byte{messageSendFactory(9), messageSendFactory(10)}, gotItems, "mismatching Range")
+					},
+				},
+			},
+			wantItems: [][]byte{
+				messageSendFactory(9),
+				messageSendFactory(10),
+				messageSendFactory(8),
+			},
+		},
+		{
+			name: "TxsBytesTotal should only account for the transactions left once the oldest one is removed (in number of txs)",
+			args: args{
+				maxTxBytes: 1000,
+				maxTxs:     1,
+				initialTxs: &[][]byte{messageSendFactory(20)},
+				actions: &[]func(*txFIFOMempool){
+					func(txFIFOMempool *txFIFOMempool) {
+						require.NoError(t, txFIFOMempool.AddTx(messageSendFactory(5)))
+						require.Equal(t, uint64(len(messageSendFactory(5))), txFIFOMempool.TxsBytesTotal(), "mismatching TxsBytesTotal")
+					},
+				},
+			},
+			wantItems: [][]byte{messageSendFactory(5)},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
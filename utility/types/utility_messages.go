@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/pokt-network/pocket/shared/codec"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// PrepareTxGossipMessage wraps the transaction in a `TxGossipMessage` so it can be broadcast to the utility modules
+// of the other nodes
+func PrepareTxGossipMessage(txBz []byte) (*anypb.Any, error) {
+	txGossipMessage := &TxGossipMessage{
+		Tx: txBz,
+	}
+
+	// nolint:gocritic // TODO: keeping commented out code in place because this is how it should work in the future
+	// pocketEnvelope, err := messaging.PackMessage(txGossipMessage)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	anyMessage, err := codec.GetCodec().ToAny(txGossipMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	return anyMessage, nil
+}
@@ -5,7 +5,9 @@ package utility
 import (
 	"math/big"
 
+	typesCons "github.com/pokt-network/pocket/consensus/types"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
 
@@ -18,6 +20,10 @@ func (u *utilityContext) handleByzantineValidators(prevBlockByzantineValidators
 	if err != nil {
 		return err
 	}
+	burnPercent, err := u.getMissedBlocksBurnPercentage()
+	if err != nil {
+		return err
+	}
 
 	for _, address := range prevBlockByzantineValidators {
 		// Get the latest number of missed blocks by the validator
@@ -46,18 +52,17 @@ func (u *utilityContext) handleByzantineValidators(prevBlockByzantineValidators
 			return typesUtil.ErrSetMissedBlocks(err)
 		}
 		// burn validator for missing blocks
-		if err := u.burnValidator(address); err != nil {
+		if err := u.burnValidator(address, burnPercent); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// burnValidator burns a validator's stake based on governance parameters for missing blocks
-// and begins unstaking if the stake falls below the necessary threshold
+// burnValidator burns `burnPercent` percent of a validator's stake, i.e. the governance parameter for missing blocks
+// or double signing, and begins unstaking if the stake falls below the necessary threshold
 // REFACTOR: Extend this to support burning other actors types & pools once the logic is implemented
-// ADDTEST: There are no good tests for this functionality, which MUST be added.
-func (u *utilityContext) burnValidator(addr []byte) typesUtil.Error {
+func (u *utilityContext) burnValidator(addr []byte, burnPercent int) typesUtil.Error {
 	actorType := coreTypes.ActorType_ACTOR_TYPE_VAL
 	actorPool := coreTypes.Pools_POOLS_VALIDATOR_STAKE
 
@@ -66,11 +71,6 @@ func (u *utilityContext) burnValidator(addr []byte) typesUtil.Error {
 		return err
 	}
 
-	burnPercent, err := u.getMissedBlocksBurnPercentage()
-	if err != nil {
-		return err
-	}
-
 	// currentStake * burnPercent / 100
 	burnAmount := new(big.Float).SetInt(stakeAmount)
 	burnAmount.Mul(burnAmount, big.NewFloat(float64(burnPercent)))
@@ -101,7 +101,7 @@ func (u *utilityContext) burnValidator(addr []byte) typesUtil.Error {
 	}
 
 	// Check if amount after burn is below the min required stake
-	if minRequiredStake.Cmp(newAmountAfterBurn) == -1 {
+	if newAmountAfterBurn.Cmp(minRequiredStake) == -1 {
 		unbondingHeight, err := u.getUnbondingHeight(actorType)
 		if err != nil {
 			return err
@@ -113,3 +113,20 @@ func (u *utilityContext) burnValidator(addr []byte) typesUtil.Error {
 
 	return nil
 }
+
+// validateVoteSignature verifies the partial signature of a hotstuff vote submitted as evidence of a validator double
+// signing, which is computed over the same bytes as in consensus
+func validateVoteSignature(vote *typesCons.HotstuffMessage, publicKey string) typesUtil.Error {
+	pubKey, er := crypto.NewPublicKey(publicKey)
+	if er != nil {
+		return typesUtil.ErrNewPublicKeyFromBytes(er)
+	}
+	signableBytes, er := vote.SignableBytes()
+	if er != nil {
+		return typesUtil.ErrProtoMarshal(er)
+	}
+	if !pubKey.Verify(signableBytes, vote.GetPartialSignature().GetSignature()) {
+		return typesUtil.ErrSignatureVerificationFailed()
+	}
+	return nil
+}
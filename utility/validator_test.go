@@ -0,0 +1,256 @@
+package utility
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	typesCons "github.com/pokt-network/pocket/consensus/types"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	mocksPer "github.com/pokt-network/pocket/persistence/types/mocks"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/shared/utils"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDoubleSignHeight          = 10
+	testDoubleSignMaxEvidenceAge  = 4
+	testDoubleSignBurnPercentage  = 10
+	testDoubleSignUnstakingBlocks = 100
+)
+
+var testDoubleSignMinimumStake = big.NewInt(900)
+
+type testDoubleSignValidator struct {
+	key             crypto.PrivateKey
+	actor           *coreTypes.Actor
+	stake           *big.Int
+	burntAmount     *big.Int
+	unstakingHeight int64
+	handledEvidence map[string]bool
+}
+
+// Returns a utility context at `testDoubleSignHeight` whose store keeps the stake, the pause height & the unstaking
+// height of a single validator in memory
+func newTestDoubleSignContext(t *testing.T, stake *big.Int) (*utilityContext, *testDoubleSignValidator) {
+	key, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	validator := &testDoubleSignValidator{
+		key: key,
+		actor: &coreTypes.Actor{
+			ActorType:       coreTypes.ActorType_ACTOR_TYPE_VAL,
+			Address:         key.Address().String(),
+			PublicKey:       key.PublicKey().String(),
+			StakedAmount:    utils.BigIntToString(stake),
+			PausedHeight:    typesUtil.HeightNotUsed,
+			UnstakingHeight: typesUtil.HeightNotUsed,
+		},
+		stake:           stake,
+		burntAmount:     big.NewInt(0),
+		unstakingHeight: typesUtil.HeightNotUsed,
+		handledEvidence: make(map[string]bool),
+	}
+	addr := key.Address().Bytes()
+
+	ctrl := gomock.NewController(t)
+	storeMock := mockModules.NewMockPersistenceRWContext(ctrl)
+	storeMock.EXPECT().GetIntParam(typesUtil.ValidatorMaxEvidenceAgeInBlocksParamName, gomock.Any()).Return(testDoubleSignMaxEvidenceAge, nil).AnyTimes()
+	storeMock.EXPECT().GetIntParam(typesUtil.DoubleSignBurnPercentageParamName, gomock.Any()).Return(testDoubleSignBurnPercentage, nil).AnyTimes()
+	storeMock.EXPECT().GetIntParam(typesUtil.ValidatorUnstakingBlocksParamName, gomock.Any()).Return(testDoubleSignUnstakingBlocks, nil).AnyTimes()
+	storeMock.EXPECT().GetStringParam(typesUtil.ValidatorMinimumStakeParamName, gomock.Any()).Return(utils.BigIntToString(testDoubleSignMinimumStake), nil).AnyTimes()
+	storeMock.EXPECT().GetActor(coreTypes.ActorType_ACTOR_TYPE_VAL, addr, int64(testDoubleSignHeight)).Return(validator.actor, nil).AnyTimes()
+	storeMock.EXPECT().GetValidatorStakeAmount(int64(testDoubleSignHeight), addr).DoAndReturn(func(int64, []byte) (string, error) {
+		return utils.BigIntToString(validator.stake), nil
+	}).AnyTimes()
+	storeMock.EXPECT().SetValidatorStakeAmount(addr, gomock.Any()).DoAndReturn(func(_ []byte, amount string) error {
+		validator.stake, err = utils.StringToBigInt(amount)
+		return err
+	}).AnyTimes()
+	storeMock.EXPECT().SubtractPoolAmount(coreTypes.Pools_POOLS_VALIDATOR_STAKE.FriendlyName(), gomock.Any()).DoAndReturn(func(_ string, amount string) error {
+		burntAmount, err := utils.StringToBigInt(amount)
+		validator.burntAmount.Add(validator.burntAmount, burntAmount)
+		return err
+	}).AnyTimes()
+	storeMock.EXPECT().SetValidatorPauseHeight(addr, gomock.Any()).DoAndReturn(func(_ []byte, height int64) error {
+		validator.actor.PausedHeight = height
+		return nil
+	}).AnyTimes()
+	storeMock.EXPECT().GetDoubleSignEvidenceExists(addr, gomock.Any(), gomock.Any(), gomock.Any(), int64(testDoubleSignHeight)).DoAndReturn(func(_ []byte, voteHeight, voteRound uint64, voteStep int32, _ int64) (bool, error) {
+		return validator.handledEvidence[fmt.Sprintf("%d/%d/%d", voteHeight, voteRound, voteStep)], nil
+	}).AnyTimes()
+	storeMock.EXPECT().SetDoubleSignEvidence(addr, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ []byte, voteHeight, voteRound uint64, voteStep int32) error {
+		validator.handledEvidence[fmt.Sprintf("%d/%d/%d", voteHeight, voteRound, voteStep)] = true
+		return nil
+	}).AnyTimes()
+	storeMock.EXPECT().SetValidatorUnstakingHeightAndStatus(addr, gomock.Any(), gomock.Any()).DoAndReturn(func(_ []byte, height int64, _ int32) error {
+		validator.unstakingHeight = height
+		return nil
+	}).AnyTimes()
+
+	return &utilityContext{height: testDoubleSignHeight, store: storeMock}, validator
+}
+
+func newTestVote(t *testing.T, key crypto.PrivateKey, height uint64, block *coreTypes.Block) *typesCons.HotstuffMessage {
+	vote := &typesCons.HotstuffMessage{
+		Type:   typesCons.HotstuffMessageType_HOTSTUFF_MESSAGE_VOTE,
+		Height: height,
+		Step:   typesCons.HotstuffStep_HOTSTUFF_STEP_PREPARE,
+		Round:  1,
+		Block:  block,
+	}
+	signableBytes, err := vote.SignableBytes()
+	require.NoError(t, err)
+	signature, err := key.Sign(signableBytes)
+	require.NoError(t, err)
+	vote.Justification = &typesCons.HotstuffMessage_PartialSignature{
+		PartialSignature: &typesCons.PartialSignature{
+			Signature: signature,
+			Address:   key.Address().String(),
+		},
+	}
+	return vote
+}
+
+func newTestMessageDoubleSign(t *testing.T, key crypto.PrivateKey, height uint64) *typesUtil.MessageDoubleSign {
+	reporterKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &typesUtil.MessageDoubleSign{
+		VoteA:           newTestVote(t, key, height, &coreTypes.Block{BlockHeader: &coreTypes.BlockHeader{Height: height, StateHash: "a"}}),
+		VoteB:           newTestVote(t, key, height, &coreTypes.Block{BlockHeader: &coreTypes.BlockHeader{Height: height, StateHash: "b"}}),
+		ReporterAddress: reporterKey.Address(),
+	}
+}
+
+func TestValidator_HandleMessageDoubleSign(t *testing.T) {
+	ctx, validator := newTestDoubleSignContext(t, big.NewInt(2000))
+	msg := newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight-1)
+	require.Nil(t, msg.ValidateBasic())
+
+	require.Nil(t, ctx.handleMessageDoubleSign(msg))
+	require.Equal(t, big.NewInt(1800), validator.stake, "10% of the stake is burnt")
+	require.Equal(t, big.NewInt(200), validator.burntAmount, "the burnt stake is removed from the pool")
+	require.Equal(t, int64(testDoubleSignHeight), validator.actor.GetPausedHeight())
+	require.Equal(t, typesUtil.HeightNotUsed, validator.unstakingHeight, "the stake is still above the minimum")
+
+	err := ctx.handleMessageDoubleSign(msg)
+	require.Equal(t, typesUtil.CodeDuplicateDoubleSignEvidenceError, err.Code(), "a double sign is only burnt once")
+	require.Equal(t, big.NewInt(1800), validator.stake)
+}
+
+func TestValidator_HandleMessageDoubleSign_ResubmittedAfterUnpause(t *testing.T) {
+	ctx, validator := newTestDoubleSignContext(t, big.NewInt(2000))
+	msg := newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight-1)
+	require.Nil(t, ctx.handleMessageDoubleSign(msg))
+
+	// The validator unpauses while the evidence is still within the max evidence age
+	validator.actor.PausedHeight = typesUtil.HeightNotUsed
+	resubmittedMsg := newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight-1)
+	err := ctx.handleMessageDoubleSign(resubmittedMsg)
+	require.Equal(t, typesUtil.CodeDuplicateDoubleSignEvidenceError, err.Code())
+	require.Equal(t, big.NewInt(1800), validator.stake, "the stake is only burnt once")
+	require.Equal(t, typesUtil.HeightNotUsed, validator.actor.GetPausedHeight())
+
+	// Evidence of a double sign in another round is handled separately
+	otherRoundMsg := newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight-1)
+	for _, vote := range []*typesCons.HotstuffMessage{otherRoundMsg.VoteA, otherRoundMsg.VoteB} {
+		vote.Round = 2
+		signableBytes, er := vote.SignableBytes()
+		require.NoError(t, er)
+		vote.GetPartialSignature().Signature, er = validator.key.Sign(signableBytes)
+		require.NoError(t, er)
+	}
+	require.Nil(t, ctx.handleMessageDoubleSign(otherRoundMsg))
+	require.Equal(t, big.NewInt(1620), validator.stake)
+}
+
+func TestValidator_HandleMessageDoubleSign_BelowMinimumStake(t *testing.T) {
+	ctx, validator := newTestDoubleSignContext(t, big.NewInt(950))
+
+	require.Nil(t, ctx.handleMessageDoubleSign(newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight)))
+	require.Equal(t, big.NewInt(855), validator.stake)
+	require.Equal(t, int64(testDoubleSignHeight+testDoubleSignUnstakingBlocks), validator.unstakingHeight, "the validator begins unstaking")
+}
+
+func TestValidator_HandleMessageDoubleSign_Invalid(t *testing.T) {
+	ctx, validator := newTestDoubleSignContext(t, big.NewInt(2000))
+
+	err := ctx.handleMessageDoubleSign(newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight-testDoubleSignMaxEvidenceAge-1))
+	require.Equal(t, typesUtil.CodeMaxEvidenceAgeError, err.Code())
+
+	err = ctx.handleMessageDoubleSign(newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight+1))
+	require.Equal(t, typesUtil.CodeInvalidBlockHeightError, err.Code(), "the votes cannot be ahead of the chain")
+
+	msg := newTestMessageDoubleSign(t, validator.key, testDoubleSignHeight)
+	msg.VoteB.GetPartialSignature().Signature = msg.VoteA.GetPartialSignature().GetSignature()
+	err = ctx.handleMessageDoubleSign(msg)
+	require.Equal(t, typesUtil.CodeSignatureVerificationFailedError, err.Code())
+
+	require.Equal(t, big.NewInt(2000), validator.stake)
+	require.Equal(t, typesUtil.HeightNotUsed, validator.actor.GetPausedHeight())
+}
+
+func TestValidator_PrevBlockByzantineValidators(t *testing.T) {
+	prevHeight := int64(testDoubleSignHeight - 1)
+	validators := make([]*coreTypes.Actor, 0, 3)
+	commitQC := &typesCons.QuorumCertificate{
+		Height:             uint64(prevHeight),
+		ThresholdSignature: &typesCons.ThresholdSignature{},
+	}
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GeneratePrivateKey()
+		require.NoError(t, err)
+		validators = append(validators, &coreTypes.Actor{
+			ActorType: coreTypes.ActorType_ACTOR_TYPE_VAL,
+			Address:   key.Address().String(),
+			PublicKey: key.PublicKey().String(),
+		})
+		// The last validator did not sign the previous block
+		if i < 2 {
+			commitQC.ThresholdSignature.Signatures = append(commitQC.ThresholdSignature.Signatures, &typesCons.PartialSignature{
+				Signature: []byte("signature"),
+				Address:   key.Address().String(),
+			})
+		}
+	}
+	qcBz, err := codec.GetCodec().Marshal(commitQC)
+	require.NoError(t, err)
+	blockBz, err := codec.GetCodec().Marshal(&coreTypes.Block{
+		BlockHeader: &coreTypes.BlockHeader{
+			Height:            uint64(prevHeight),
+			QuorumCertificate: qcBz,
+		},
+	})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	blockStoreMock := mocksPer.NewMockKVStore(ctrl)
+	blockStoreMock.EXPECT().Get(utils.HeightToBytes(uint64(prevHeight))).Return(blockBz, nil).AnyTimes()
+	blockStoreMock.EXPECT().Get(gomock.Any()).Return(nil, kvstore.ErrKeyNotFound).AnyTimes()
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().GetBlockStore().Return(blockStoreMock).AnyTimes()
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	storeMock := mockModules.NewMockPersistenceRWContext(ctrl)
+	storeMock.EXPECT().GetAllValidators(prevHeight).Return(validators, nil).AnyTimes()
+
+	ctx := &utilityContext{height: testDoubleSignHeight, store: storeMock}
+	ctx.SetBus(busMock)
+	byzantineValidators, err := ctx.prevBlockByzantineValidators()
+	require.NoError(t, err)
+	require.Len(t, byzantineValidators, 1)
+	require.Equal(t, validators[2].GetAddress(), hex.EncodeToString(byzantineValidators[0]))
+
+	// The validators cannot be told apart when the previous block is not in the block store
+	ctx = &utilityContext{height: testDoubleSignHeight + 1, store: storeMock}
+	ctx.SetBus(busMock)
+	byzantineValidators, err = ctx.prevBlockByzantineValidators()
+	require.NoError(t, err)
+	require.Empty(t, byzantineValidators)
+}